@@ -192,6 +192,22 @@ func (a *AuthController) PromoteUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "User promoted to admin"})
 }
 
+// Make user a reviewer handler
+func (a *AuthController) MakeReviewer(c *gin.Context) {
+	userID := c.Param("id")
+
+	ctx, cancel := utils.CreateContext()
+	defer cancel()
+
+	err := a.UserUsecase.MakeReviewer(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User is now a reviewer"})
+}
+
 // Demote user handler
 func (a *AuthController) DemoteUser(c *gin.Context) {
 	userID := c.Param("id")
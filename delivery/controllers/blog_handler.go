@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
@@ -19,12 +21,28 @@ func NewBlogHandler(uc interfaces.BlogUseCaseInterface) *BlogHandler {
 	return &BlogHandler{UseCase: uc}
 }
 
+// createBlogRequest holds the fields an author may set when creating a blog
+type createBlogRequest struct {
+	Title      string                  `json:"title"`
+	Content    string                  `json:"content"`
+	Tags       []string                `json:"tags"`
+	Status     entities.BlogStatus     `json:"status"`
+	Visibility entities.BlogVisibility `json:"visibility"`
+}
+
 func (h *BlogHandler) CreateBlog(c *gin.Context) {
-	var blog entities.Blog
-	if err := c.ShouldBindJSON(&blog); err != nil {
+	var req createBlogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(400, gin.H{"error": "Invalid request payload"})
 		return
 	}
+	blog := entities.Blog{
+		Title:      req.Title,
+		Content:    req.Content,
+		Tags:       req.Tags,
+		Status:     req.Status,
+		Visibility: req.Visibility,
+	}
 
 	// Get authenticated user ID from context (set by auth middleware)
 	userID, exists := c.Get("userID")
@@ -34,7 +52,7 @@ func (h *BlogHandler) CreateBlog(c *gin.Context) {
 	}
 
 	if err := h.UseCase.CreateBlog(c.Request.Context(), &blog, userID.(string)); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		respondWriteError(c, err)
 		return
 	}
 	c.JSON(201, blog)
@@ -69,8 +87,12 @@ func (h *BlogHandler) GetBlogsByUser(c *gin.Context) {
 	if limit > 5 {
 		limit = 5
 	}
-	blogs, err := h.UseCase.GetBlogsByUserID(c.Request.Context(), targetUserID, page, limit)
+	blogs, err := h.UseCase.GetBlogsByUserID(c.Request.Context(), targetUserID, userID.(string), page, limit, c.Query("cursor"))
 	if err != nil {
+		if isCursorError(err) {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
 	}
@@ -80,9 +102,48 @@ func (h *BlogHandler) GetBlogsByUser(c *gin.Context) {
 // GetBlogByID handles GET /blogs/:id
 func (h *BlogHandler) GetBlogByID(c *gin.Context) {
 	id := c.Param("id")
-	blog, err := h.UseCase.GetBlogByID(c.Request.Context(), id)
+	blog, err := h.UseCase.GetBlogByID(c.Request.Context(), id, blogAccess(c))
 	if err != nil {
-		c.JSON(404, gin.H{"error": "Blog not found"})
+		respondBlogReadError(c, err)
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// blogAccess collects what the reader can show for private and password-protected blogs:
+// the signed-in user (optional auth) and an access token from POST /blogs/:id/unlock
+func blogAccess(c *gin.Context) *entities.BlogAccess {
+	token := c.GetHeader("X-Blog-Access-Token")
+	if token == "" {
+		token = c.Query("access_token")
+	}
+	return &entities.BlogAccess{UserID: c.GetString("userID"), AccessToken: token}
+}
+
+// respondBlogReadError tells password-protected blogs apart from missing ones
+func respondBlogReadError(c *gin.Context, err error) {
+	if err.Error() == "this blog is password protected" {
+		c.JSON(401, gin.H{"error": "This blog is password protected", "password_required": true})
+		return
+	}
+	c.JSON(404, gin.H{"error": "Blog not found"})
+}
+
+// GetBlogBySlug handles GET /blogs/by-slug/:slug
+// Requests for a previous slug are redirected (301) to the current one
+func (h *BlogHandler) GetBlogBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	blog, err := h.UseCase.GetBlogBySlug(c.Request.Context(), slug, blogAccess(c))
+	if err != nil {
+		respondBlogReadError(c, err)
+		return
+	}
+	if blog.Slug != slug {
+		location := "/api/v1/blogs/by-slug/" + url.PathEscape(blog.Slug)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(301, location)
 		return
 	}
 	c.JSON(200, blog)
@@ -92,18 +153,49 @@ func (h *BlogHandler) GetBlogByID(c *gin.Context) {
 func (h *BlogHandler) UpdateBlog(c *gin.Context) {
 	id := c.Param("id")
 
-	// First, get the existing blog to preserve all its data
-	existingBlog, err := h.UseCase.GetBlogByID(c.Request.Context(), id)
+	// First, get the existing blog to preserve all its data (drafts included)
+	existingBlog, err := h.UseCase.GetBlogByIDForOwner(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(404, gin.H{"error": "Blog not found"})
 		return
 	}
+	if existingBlog.DeletedAt != nil {
+		c.JSON(400, gin.H{"error": "Blog is in the trash, restore it before editing"})
+		return
+	}
+
+	// Status and schedule only change through the publish/unpublish/archive/schedule endpoints
+	status, publishedAt := existingBlog.Status, existingBlog.PublishedAt
+	publishAt, unpublishAt := existingBlog.PublishAt, existingBlog.UnpublishAt
+	// Slugs are derived from the title
+	slug, oldSlugs := existingBlog.Slug, existingBlog.OldSlugs
+	// Ownership and co-authors are managed by the owner through the co-author endpoints
+	ownerID, coAuthors := existingBlog.UserID, existingBlog.CoAuthors
+	// The review state only changes through the review workflow
+	reviewStatus, reviewRound, submittedAt := existingBlog.ReviewStatus, existingBlog.ReviewRound, existingBlog.SubmittedAt
+	// Visibility only changes through the visibility endpoint (the passphrase hash is never bound from JSON)
+	visibility := existingBlog.Visibility
+	// Counters are kept by the interaction, comment and scoring code
+	views, likes, dislikes, comments := existingBlog.ViewCount, existingBlog.LikeCount, existingBlog.DislikeCount, existingBlog.CommentCount
+	popularity := existingBlog.PopularityScore
+	// Comments are locked and unlocked by the moderators of the blog only
+	commentsLocked := existingBlog.CommentsLocked
 
 	// Bind the JSON request to the existing blog (this only updates provided fields)
 	if err := c.ShouldBindJSON(existingBlog); err != nil {
 		c.JSON(400, gin.H{"error": "Invalid request payload"})
 		return
 	}
+	existingBlog.Status, existingBlog.PublishedAt = status, publishedAt
+	existingBlog.PublishAt, existingBlog.UnpublishAt = publishAt, unpublishAt
+	existingBlog.Slug, existingBlog.OldSlugs = slug, oldSlugs
+	existingBlog.DeletedAt, existingBlog.PurgeAt = nil, nil
+	existingBlog.UserID, existingBlog.CoAuthors = ownerID, coAuthors
+	existingBlog.ReviewStatus, existingBlog.ReviewRound, existingBlog.SubmittedAt = reviewStatus, reviewRound, submittedAt
+	existingBlog.Visibility = visibility
+	existingBlog.ViewCount, existingBlog.LikeCount, existingBlog.DislikeCount, existingBlog.CommentCount = views, likes, dislikes, comments
+	existingBlog.PopularityScore = popularity
+	existingBlog.CommentsLocked = commentsLocked
 
 	// Ensure the ID is preserved (shouldn't change during update)
 	objectID, err := primitive.ObjectIDFromHex(id)
@@ -113,24 +205,224 @@ func (h *BlogHandler) UpdateBlog(c *gin.Context) {
 	}
 	existingBlog.ID = objectID
 
-	// Update the blog
-	if err := h.UseCase.UpdateBlog(c.Request.Context(), existingBlog); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+	// Update the blog (the editor is recorded in the revision history)
+	if err := h.UseCase.UpdateBlog(c.Request.Context(), existingBlog, c.GetString("userID")); err != nil {
+		respondWriteError(c, err)
 		return
 	}
 	c.JSON(200, existingBlog)
 }
 
-// DeleteBlog handles DELETE /blogs/:id
+// PublishBlog handles POST /blogs/:id/publish
+func (h *BlogHandler) PublishBlog(c *gin.Context) {
+	blog, err := h.UseCase.PublishBlog(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondLifecycleError(c, err)
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// UnpublishBlog handles POST /blogs/:id/unpublish
+func (h *BlogHandler) UnpublishBlog(c *gin.Context) {
+	blog, err := h.UseCase.UnpublishBlog(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondLifecycleError(c, err)
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// ArchiveBlog handles POST /blogs/:id/archive
+func (h *BlogHandler) ArchiveBlog(c *gin.Context) {
+	blog, err := h.UseCase.ArchiveBlog(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondLifecycleError(c, err)
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// ScheduleBlog handles PUT /blogs/:id/schedule
+func (h *BlogHandler) ScheduleBlog(c *gin.Context) {
+	var schedule entities.BlogSchedule
+	if err := c.ShouldBindJSON(&schedule); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload. Times must be RFC3339, e.g. 2025-08-10T09:00:00Z"})
+		return
+	}
+
+	blog, err := h.UseCase.ScheduleBlog(c.Request.Context(), c.Param("id"), &schedule)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// CancelBlogSchedule handles DELETE /blogs/:id/schedule
+func (h *BlogHandler) CancelBlogSchedule(c *gin.Context) {
+	blog, err := h.UseCase.CancelBlogSchedule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondLifecycleError(c, err)
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// respondWriteError maps create and update errors; oversized content is the client's fault
+func respondWriteError(c *gin.Context, err error) {
+	if strings.HasPrefix(err.Error(), "content must be at most ") {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(500, gin.H{"error": err.Error()})
+}
+
+// respondLifecycleError reports failed status and schedule changes; trashed blogs have to be restored first
+func respondLifecycleError(c *gin.Context, err error) {
+	if err.Error() == "blog is in the trash, restore it first" {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(500, gin.H{"error": err.Error()})
+}
+
+// GetBlogRevisions handles GET /blogs/:id/revisions
+func (h *BlogHandler) GetBlogRevisions(c *gin.Context) {
+	revisions, err := h.UseCase.GetBlogRevisions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Blog revisions retrieved successfully",
+		"data":    revisions,
+		"count":   len(revisions),
+	})
+}
+
+// GetBlogRevision handles GET /blogs/:id/revisions/:version
+func (h *BlogHandler) GetBlogRevision(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(400, gin.H{"error": "Invalid revision version"})
+		return
+	}
+
+	revision, err := h.UseCase.GetBlogRevision(c.Request.Context(), c.Param("id"), version)
+	if err != nil {
+		c.JSON(404, gin.H{"error": "Revision not found"})
+		return
+	}
+	c.JSON(200, revision)
+}
+
+// DiffBlogRevisions handles GET /blogs/:id/revisions/diff?from=1&to=2
+func (h *BlogHandler) DiffBlogRevisions(c *gin.Context) {
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "Invalid from parameter"})
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "Invalid to parameter"})
+		return
+	}
+
+	diff, err := h.UseCase.DiffBlogRevisions(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, diff)
+}
+
+// RestoreBlogRevision handles POST /blogs/:id/revisions/:version/restore
+func (h *BlogHandler) RestoreBlogRevision(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(400, gin.H{"error": "Invalid revision version"})
+		return
+	}
+
+	blog, err := h.UseCase.RestoreBlogRevision(c.Request.Context(), c.Param("id"), version, c.GetString("userID"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// DeleteBlog handles DELETE /blogs/:id (moves the blog to the trash)
 func (h *BlogHandler) DeleteBlog(c *gin.Context) {
 	id := c.Param("id")
 	if err := h.UseCase.DeleteBlog(c.Request.Context(), id); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		c.JSON(404, gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(204)
 }
 
+// AddCoAuthor handles POST /blogs/:id/coauthors
+// The co-author is identified by "user_id" or "email"
+func (h *BlogHandler) AddCoAuthor(c *gin.Context) {
+	var req struct {
+		UserID string `json:"user_id"`
+		Email  string `json:"email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || (req.UserID == "") == (req.Email == "") {
+		c.JSON(400, gin.H{"error": "Provide either user_id or email"})
+		return
+	}
+
+	identifier := req.UserID
+	if identifier == "" {
+		identifier = req.Email
+	}
+	blog, err := h.UseCase.AddCoAuthor(c.Request.Context(), c.Param("id"), identifier)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// RemoveCoAuthor handles DELETE /blogs/:id/coauthors/:userId
+func (h *BlogHandler) RemoveCoAuthor(c *gin.Context) {
+	blog, err := h.UseCase.RemoveCoAuthor(c.Request.Context(), c.Param("id"), c.Param("userId"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// GetTrashedBlogs handles GET /blogs/trash
+func (h *BlogHandler) GetTrashedBlogs(c *gin.Context) {
+	userID := c.GetString("userID")
+	blogs, err := h.UseCase.GetTrashedBlogs(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Trashed blogs retrieved successfully",
+		"data":    blogs,
+		"count":   len(blogs),
+	})
+}
+
+// RestoreBlog handles POST /blogs/:id/restore
+func (h *BlogHandler) RestoreBlog(c *gin.Context) {
+	blog, err := h.UseCase.RestoreBlog(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
 // GetPopularBlogs handles GET /blogs/popular
 func (h *BlogHandler) GetPopularBlogs(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "10")
@@ -154,6 +446,62 @@ func (h *BlogHandler) GetPopularBlogs(c *gin.Context) {
 	})
 }
 
+// GetRelatedBlogs handles GET /blogs/:id/related
+func (h *BlogHandler) GetRelatedBlogs(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(400, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+		limit = parsed
+	}
+
+	blogs, err := h.UseCase.GetRelatedBlogs(c.Request.Context(), c.Param("id"), blogAccess(c), limit)
+	if err != nil {
+		respondBlogReadError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"message": "Related blogs retrieved successfully",
+		"data":    blogs,
+		"count":   len(blogs),
+	})
+}
+
+// GetTrendingBlogs handles GET /blogs/trending
+func (h *BlogHandler) GetTrendingBlogs(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(400, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+		limit = parsed
+	}
+
+	window := c.DefaultQuery("window", "24h")
+	blogs, err := h.UseCase.GetTrendingBlogs(c.Request.Context(), window, c.Query("tag"), limit)
+	if err != nil {
+		if err.Error() == "window must be one of 24h, 7d or 30d" {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"message": "Trending blogs retrieved successfully",
+		"data":    blogs,
+		"window":  window,
+		"count":   len(blogs),
+	})
+}
+
 // FilterBlogs handles GET /blogs/filter
 func (h *BlogHandler) FilterBlogs(c *gin.Context) {
 	var filter entities.BlogFilter
@@ -217,6 +565,9 @@ func (h *BlogHandler) FilterBlogs(c *gin.Context) {
 			filter.Skip = (page - 1) * limit
 		}
 	}
+
+	// A cursor from a previous response replaces skip and page
+	filter.Cursor = c.Query("cursor")
 	
 	// Call use case to filter blogs
 	response, err := h.UseCase.FilterBlogs(c.Request.Context(), &filter)
@@ -236,8 +587,11 @@ func (h *BlogHandler) SearchBlogs(c *gin.Context) {
 	var search entities.BlogSearch
 	
 	// Parse query parameters
+	search.Query = c.Query("q")
 	search.Title = c.Query("title")
 	search.Author = c.Query("author")
+	search.PopularitySort = c.Query("popularity_sort")
+	search.SortOrder = c.Query("sort_order")
 	
 	// Parse limit
 	if limitStr := c.Query("limit"); limitStr != "" {
@@ -270,6 +624,9 @@ func (h *BlogHandler) SearchBlogs(c *gin.Context) {
 			search.Skip = (page - 1) * limit
 		}
 	}
+
+	// A cursor from a previous response replaces skip and page
+	search.Cursor = c.Query("cursor")
 	
 	// Call use case to search blogs
 	response, err := h.UseCase.SearchBlogs(c.Request.Context(), &search)
@@ -283,3 +640,78 @@ func (h *BlogHandler) SearchBlogs(c *gin.Context) {
 		"data":    response,
 	})
 }
+
+// SuggestSearch handles GET /search/suggest
+func (h *BlogHandler) SuggestSearch(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(400, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+		limit = parsed
+	}
+
+	suggestions, err := h.UseCase.SuggestSearch(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"message": "Suggestions retrieved successfully",
+		"data":    suggestions,
+	})
+}
+
+// SetBlogVisibility handles PUT /blogs/:id/visibility
+func (h *BlogHandler) SetBlogVisibility(c *gin.Context) {
+	var req entities.BlogVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	blog, err := h.UseCase.SetBlogVisibility(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// UnlockBlog handles POST /blogs/:id/unlock
+// A correct passphrase returns a short-lived token for reading a password-protected blog
+func (h *BlogHandler) UnlockBlog(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Password is required"})
+		return
+	}
+
+	token, err := h.UseCase.UnlockBlog(c.Request.Context(), c.Param("id"), req.Password)
+	if err != nil {
+		switch err.Error() {
+		case "invalid password":
+			c.JSON(401, gin.H{"error": err.Error()})
+		case "blog not found":
+			c.JSON(404, gin.H{"error": "Blog not found"})
+		default:
+			c.JSON(400, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(200, token)
+}
+
+// isCursorError reports errors caused by the cursor query parameter of a list
+func isCursorError(err error) bool {
+	switch err.Error() {
+	case "invalid cursor", "cursor does not match the sort order":
+		return true
+	}
+	return false
+}
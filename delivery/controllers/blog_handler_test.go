@@ -1,10 +1,12 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	ucMocks "github.com/Abenuterefe/a2sv-project/mocks"
@@ -118,6 +120,26 @@ func TestCreateBlog_Happy(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, w.Code)
 }
 
+func TestCreateBlog_IgnoresProtectedFields(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	uc.On("CreateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.Title == "t" && b.Status == entities.BlogStatusDraft && b.LikeCount == 0 && b.ReviewStatus == "" && b.UserID == ""
+	}), "u1").Return(nil)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "u1") })
+	r.POST("/blogs", h.CreateBlog)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/blogs", strings.NewReader(`{"title":"t","status":"draft","LikeCount":99,"ReviewStatus":"approved","UserID":"u2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
 func TestGetBlogsByUser_UnauthorizedAndCapLimit(t *testing.T) {
 	t.Parallel()
 	gin.SetMode(gin.TestMode)
@@ -133,7 +155,7 @@ func TestGetBlogsByUser_UnauthorizedAndCapLimit(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 
 	// With user, limit capped to 5
-	uc.On("GetBlogsByUserID", mock.Anything, "u1", int64(1), int64(5)).Return([]*entities.Blog{}, nil)
+	uc.On("GetBlogsByUserID", mock.Anything, "u1", "u1", int64(1), int64(5), "").Return(&entities.BlogPage{Blogs: []*entities.Blog{}}, nil)
 	r = gin.New()
 	r.Use(func(c *gin.Context) { c.Set("userID", "u1") })
 	r.GET("/blogs", h.GetBlogsByUser)
@@ -149,7 +171,7 @@ func TestGetBlogByID_404And200(t *testing.T) {
 	uc := ucMocks.NewBlogUseCaseInterface(t)
 	h := NewBlogHandler(uc)
 
-	uc.On("GetBlogByID", mock.Anything, "missing").Return((*entities.Blog)(nil), assert.AnError)
+	uc.On("GetBlogByID", mock.Anything, "missing", mock.Anything).Return((*entities.Blog)(nil), assert.AnError)
 	r := gin.New()
 	r.GET("/blogs/:id", h.GetBlogByID)
 	w := httptest.NewRecorder()
@@ -159,7 +181,7 @@ func TestGetBlogByID_404And200(t *testing.T) {
 
 	blog := &entities.Blog{Title: "ok"}
 	uc.ExpectedCalls = nil // reset expectations
-	uc.On("GetBlogByID", mock.Anything, "507f1f77bcf86cd799439011").Return(blog, nil)
+	uc.On("GetBlogByID", mock.Anything, "507f1f77bcf86cd799439011", mock.Anything).Return(blog, nil)
 	r = gin.New()
 	r.GET("/blogs/:id", h.GetBlogByID)
 	w = httptest.NewRecorder()
@@ -168,14 +190,40 @@ func TestGetBlogByID_404And200(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestGetBlogBySlug_RedirectsOldSlug(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	uc.On("GetBlogBySlug", mock.Anything, "new-title", mock.Anything).Return(&entities.Blog{Slug: "new-title"}, nil)
+	uc.On("GetBlogBySlug", mock.Anything, "old-title", mock.Anything).Return(&entities.Blog{Slug: "new-title"}, nil)
+	uc.On("GetBlogBySlug", mock.Anything, "missing", mock.Anything).Return((*entities.Blog)(nil), assert.AnError)
+	r := gin.New()
+	r.GET("/blogs/by-slug/:slug", h.GetBlogBySlug)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/by-slug/new-title", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/by-slug/old-title", nil))
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/api/v1/blogs/by-slug/new-title", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/by-slug/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestUpdateBlog_InvalidIDAndSuccess(t *testing.T) {
 	t.Parallel()
 	gin.SetMode(gin.TestMode)
 	uc := ucMocks.NewBlogUseCaseInterface(t)
 	h := NewBlogHandler(uc)
 
-	// First call: GetBlogByID returns a blog, but then invalid hex triggers 400
-	uc.On("GetBlogByID", mock.Anything, "badid").Return(&entities.Blog{Title: "t"}, nil)
+	// First call: GetBlogByIDForOwner returns a blog, but then invalid hex triggers 400
+	uc.On("GetBlogByIDForOwner", mock.Anything, "badid").Return(&entities.Blog{Title: "t"}, nil)
 	r := gin.New()
 	r.PUT("/blogs/:id", h.UpdateBlog)
 	w := httptest.NewRecorder()
@@ -186,8 +234,8 @@ func TestUpdateBlog_InvalidIDAndSuccess(t *testing.T) {
 
 	// Success path
 	uc.ExpectedCalls = nil // reset
-	uc.On("GetBlogByID", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Title: "t"}, nil)
-	uc.On("UpdateBlog", mock.Anything, mock.AnythingOfType("*entities.Blog")).Return(nil)
+	uc.On("GetBlogByIDForOwner", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Title: "t"}, nil)
+	uc.On("UpdateBlog", mock.Anything, mock.AnythingOfType("*entities.Blog"), "").Return(nil)
 	r = gin.New()
 	r.PUT("/blogs/:id", h.UpdateBlog)
 	w = httptest.NewRecorder()
@@ -197,6 +245,131 @@ func TestUpdateBlog_InvalidIDAndSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestUpdateBlog_CannotChangeStatus(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	uc.On("GetBlogByIDForOwner", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Title: "t", Status: entities.BlogStatusDraft}, nil)
+	uc.On("UpdateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.Status == entities.BlogStatusDraft && b.PublishedAt == nil && b.Content == "x"
+	}), "").Return(nil)
+	r := gin.New()
+	r.PUT("/blogs/:id", h.UpdateBlog)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/blogs/507f1f77bcf86cd799439011", strings.NewReader(`{"content":"x","status":"published"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateBlog_KeepsCounters(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	stored := &entities.Blog{Title: "t", ViewCount: 40, LikeCount: 3, CommentCount: 2, PopularityScore: 23}
+	uc.On("GetBlogByIDForOwner", mock.Anything, "507f1f77bcf86cd799439011").Return(stored, nil)
+	uc.On("UpdateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.Content == "x" && b.ViewCount == 40 && b.LikeCount == 3 && b.DislikeCount == 0 && b.CommentCount == 2 && b.PopularityScore == 23
+	}), "").Return(nil)
+	r := gin.New()
+	r.PUT("/blogs/:id", h.UpdateBlog)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/blogs/507f1f77bcf86cd799439011", strings.NewReader(`{"Content":"x","ViewCount":9999,"DislikeCount":5,"CommentCount":0,"PopularityScore":1e9}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPublishAndUnpublishBlog(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	uc.On("PublishBlog", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Status: entities.BlogStatusPublished}, nil)
+	uc.On("UnpublishBlog", mock.Anything, "missing").Return((*entities.Blog)(nil), assert.AnError)
+	r := gin.New()
+	r.POST("/blogs/:id/publish", h.PublishBlog)
+	r.POST("/blogs/:id/unpublish", h.UnpublishBlog)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/blogs/507f1f77bcf86cd799439011/publish", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Status":"published"`)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/blogs/missing/unpublish", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestScheduleBlog_BadPayloadAndSuccess(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	r := gin.New()
+	r.PUT("/blogs/:id/schedule", h.ScheduleBlog)
+
+	// Times must be RFC3339
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/blogs/507f1f77bcf86cd799439011/schedule", strings.NewReader(`{"publish_at":"tomorrow"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.On("ScheduleBlog", mock.Anything, "507f1f77bcf86cd799439011", mock.MatchedBy(func(s *entities.BlogSchedule) bool {
+		return s.PublishAt != nil && s.UnpublishAt == nil
+	})).Return(&entities.Blog{Status: entities.BlogStatusScheduled}, nil)
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/blogs/507f1f77bcf86cd799439011/schedule", strings.NewReader(`{"publish_at":"2030-01-01T09:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestBlogRevisions_DiffAndRestore(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "u1") })
+	r.GET("/blogs/:id/revisions/diff", h.DiffBlogRevisions)
+	r.POST("/blogs/:id/revisions/:version/restore", h.RestoreBlogRevision)
+
+	// missing "to"
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/blogs/507f1f77bcf86cd799439011/revisions/diff?from=1", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.On("DiffBlogRevisions", mock.Anything, "507f1f77bcf86cd799439011", 1, 2).Return(&entities.BlogRevisionDiff{From: 1, To: 2, Diff: "-a\n+b\n"}, nil)
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/blogs/507f1f77bcf86cd799439011/revisions/diff?from=1&to=2", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// invalid version
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/blogs/507f1f77bcf86cd799439011/revisions/zero/restore", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.On("RestoreBlogRevision", mock.Anything, "507f1f77bcf86cd799439011", 1, "u1").Return(&entities.Blog{Title: "old"}, nil)
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/blogs/507f1f77bcf86cd799439011/revisions/1/restore", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestDeleteBlog_204(t *testing.T) {
 	t.Parallel()
 	gin.SetMode(gin.TestMode)
@@ -212,6 +385,110 @@ func TestDeleteBlog_204(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, w.Code)
 }
 
+func TestTrashedBlogs_ListRestoreAndEdit(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	deletedAt := time.Now()
+	uc.On("GetTrashedBlogs", mock.Anything, "u1").Return([]*entities.Blog{{Title: "gone", DeletedAt: &deletedAt}}, nil)
+	uc.On("RestoreBlog", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Title: "gone"}, nil)
+	uc.On("RestoreBlog", mock.Anything, "507f1f77bcf86cd799439012").Return(nil, errors.New("blog is not in the trash"))
+	uc.On("GetBlogByIDForOwner", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Title: "gone", DeletedAt: &deletedAt}, nil)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "u1"); c.Next() })
+	r.GET("/blogs/trash", h.GetTrashedBlogs)
+	r.POST("/blogs/:id/restore", h.RestoreBlog)
+	r.PUT("/blogs/:id", h.UpdateBlog)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/trash", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"count":1`)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blogs/507f1f77bcf86cd799439011/restore", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blogs/507f1f77bcf86cd799439012/restore", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// trashed blogs cannot be edited
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/blogs/507f1f77bcf86cd799439011", strings.NewReader(`{"content":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAddCoAuthor_RequiresExactlyOneIdentifier(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	r := gin.New()
+	r.POST("/blogs/:id/coauthors", h.AddCoAuthor)
+	for _, body := range []string{`{}`, `{"user_id":"u2","email":"a@b.c"}`} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/blogs/b1/coauthors", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	uc.On("AddCoAuthor", mock.Anything, "b1", "a@b.c").Return(&entities.Blog{CoAuthors: []string{"u2"}}, nil)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/blogs/b1/coauthors", strings.NewReader(`{"email":"a@b.c"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateBlog_CoAuthorCannotTakeOwnership(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	uc.On("GetBlogByIDForOwner", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Title: "t", UserID: "owner", CoAuthors: []string{"u2"}}, nil)
+	uc.On("UpdateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.UserID == "owner" && len(b.CoAuthors) == 1 && b.Content == "x"
+	}), "u2").Return(nil)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "u2"); c.Next() })
+	r.PUT("/blogs/:id", h.UpdateBlog)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/blogs/507f1f77bcf86cd799439011", strings.NewReader(`{"Content":"x","UserID":"u2","CoAuthors":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateBlog_CoAuthorCannotUnlockComments(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+
+	uc.On("GetBlogByIDForOwner", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{Title: "t", UserID: "owner", CoAuthors: []string{"u2"}, CommentsLocked: true}, nil)
+	uc.On("UpdateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.CommentsLocked && b.Content == "x"
+	}), "u2").Return(nil)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "u2"); c.Next() })
+	r.PUT("/blogs/:id", h.UpdateBlog)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/blogs/507f1f77bcf86cd799439011", strings.NewReader(`{"Content":"x","CommentsLocked":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"CommentsLocked":true`)
+}
+
 func TestGetPopularBlogs_DefaultAndCustomLimit(t *testing.T) {
 	t.Parallel()
 	gin.SetMode(gin.TestMode)
@@ -236,13 +513,62 @@ func TestGetPopularBlogs_DefaultAndCustomLimit(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestGetRelatedBlogs_ReturnsMatches(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.GET("/blogs/:id/related", h.GetRelatedBlogs)
+
+	relatedBlogs := []*entities.RelatedBlog{{Blog: entities.Blog{Title: "Channels"}, RelatedScore: 0.72, SharedTags: []string{"go"}}}
+	uc.On("GetRelatedBlogs", mock.Anything, "b1", mock.Anything, 3).Return(relatedBlogs, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/b1/related?limit=3", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"related_score":0.72`)
+	assert.Contains(t, w.Body.String(), `"shared_tags":["go"]`)
+
+	uc.On("GetRelatedBlogs", mock.Anything, "missing", mock.Anything, 0).Return(nil, errors.New("blog not found"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/missing/related", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetTrendingBlogs_WindowAndTag(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.GET("/blogs/trending", h.GetTrendingBlogs)
+
+	trending := []*entities.TrendingBlog{{Blog: entities.Blog{Title: "Go 1.24"}, TrendingScore: 4.2, Interactions: 12}}
+	uc.On("GetTrendingBlogs", mock.Anything, "7d", "go", 5).Return(trending, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/trending?window=7d&tag=go&limit=5", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"trending_score":4.2`)
+	assert.Contains(t, w.Body.String(), `"window":"7d"`)
+
+	// unknown windows are rejected by the use case
+	uc.On("GetTrendingBlogs", mock.Anything, "1y", "", 0).Return(nil, errors.New("window must be one of 24h, 7d or 30d"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/trending?window=1y", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/trending?limit=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestFilterBlogs_PageToSkip(t *testing.T) {
 	t.Parallel()
 	gin.SetMode(gin.TestMode)
 	uc := ucMocks.NewBlogUseCaseInterface(t)
 	h := NewBlogHandler(uc)
 
-	uc.On("FilterBlogs", mock.Anything, mock.Anything).Return(&entities.FilterResponse{Blogs: []*entities.Blog{}}, nil)
+	uc.On("FilterBlogs", mock.Anything, mock.Anything).Return(&entities.FilterResponse{Blogs: []*entities.ScoredBlog{}}, nil)
 
 	r := gin.New()
 	r.GET("/blogs/filter", h.FilterBlogs)
@@ -251,3 +577,164 @@ func TestFilterBlogs_PageToSkip(t *testing.T) {
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestGetBlogByID_PasswordProtected(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.GET("/blogs/:id", h.GetBlogByID)
+	r.POST("/blogs/:id/unlock", h.UnlockBlog)
+
+	uc.On("GetBlogByID", mock.Anything, "b1", &entities.BlogAccess{}).Return((*entities.Blog)(nil), errors.New("this blog is password protected"))
+	uc.On("GetBlogByID", mock.Anything, "b1", &entities.BlogAccess{AccessToken: "tok"}).Return(&entities.Blog{Title: "secret"}, nil)
+	uc.On("UnlockBlog", mock.Anything, "b1", "wrong").Return((*entities.BlogAccessToken)(nil), errors.New("invalid password"))
+	uc.On("UnlockBlog", mock.Anything, "b1", "open sesame").Return(&entities.BlogAccessToken{AccessToken: "tok", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/b1", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), `"password_required":true`)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/blogs/b1/unlock", strings.NewReader(`{"password":"wrong"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/blogs/b1/unlock", strings.NewReader(`{"password":"open sesame"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"access_token":"tok"`)
+
+	// the token works as a header or a query parameter
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/blogs/b1", nil)
+	req.Header.Set("X-Blog-Access-Token", "tok")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/b1?access_token=tok", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSetBlogVisibility_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.PUT("/blogs/:id/visibility", h.SetBlogVisibility)
+
+	uc.On("SetBlogVisibility", mock.Anything, "b1", &entities.BlogVisibilityRequest{Visibility: entities.BlogVisibilityPassword, Password: "open sesame"}).
+		Return(&entities.Blog{Visibility: entities.BlogVisibilityPassword, PasswordHash: "$2a$10$hash"}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/blogs/b1/visibility", strings.NewReader(`{"visibility":"password","password":"open sesame"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	// the passphrase hash is never returned
+	assert.NotContains(t, w.Body.String(), "hash")
+}
+
+func TestSearchBlogs_FullTextQuery(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.GET("/blogs/search", h.SearchBlogs)
+
+	uc.On("SearchBlogs", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
+		return s.Query == `"error handling" go`
+	})).Return(&entities.SearchResponse{Blogs: []*entities.BlogWithAuthor{{Score: 3, Highlights: &entities.SearchHighlights{Title: "<mark>Go</mark>"}}}, Count: 1}, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/search?q=%22error+handling%22+go", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"score":3`)
+	assert.Contains(t, w.Body.String(), `"highlights":{"title":"\u003cmark\u003eGo\u003c/mark\u003e"`)
+}
+
+func TestSearchBlogs_QueryLanguageAndSort(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.GET("/blogs/search", h.SearchBlogs)
+
+	uc.On("SearchBlogs", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
+		return s.Query == "tag:go likes:>10" && s.PopularitySort == "likes" && s.SortOrder == "asc"
+	})).Return(&entities.SearchResponse{Blogs: []*entities.BlogWithAuthor{}}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/search?q=tag%3Ago+likes%3A%3E10&popularity_sort=likes&sort_order=asc", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	uc.On("SearchBlogs", mock.Anything, mock.Anything).Return((*entities.SearchResponse)(nil), errors.New(`invalid date "soon" for after: (use YYYY-MM-DD)`)).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/search?q=after%3Asoon", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFilterBlogs_ReturnsFacets(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.GET("/blogs/filter", h.FilterBlogs)
+
+	score := 12.5
+	uc.On("FilterBlogs", mock.Anything, mock.Anything).Return(&entities.FilterResponse{
+		Blogs: []*entities.ScoredBlog{{Blog: entities.Blog{Title: "Go"}, EngagementScore: &score}},
+		Facets: &entities.SearchFacets{
+			Tags:    []entities.FacetCount{{Value: "go", Count: 7}},
+			Authors: []entities.FacetCount{{Value: "alice", ID: "u1", Count: 5}},
+			Months:  []entities.FacetCount{{Value: "2025-08", Count: 4}},
+		},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/filter?tags=go", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Title":"Go"`)
+	assert.Contains(t, w.Body.String(), `"engagement_score":12.5`)
+	assert.Contains(t, w.Body.String(), `"facets":{"tags":[{"value":"go","count":7}],"authors":[{"value":"alice","id":"u1","count":5}],"months":[{"value":"2025-08","count":4}]}`)
+}
+
+func TestSuggestSearch(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogUseCaseInterface(t)
+	h := NewBlogHandler(uc)
+	r := gin.New()
+	r.GET("/search/suggest", h.SuggestSearch)
+
+	uc.On("SuggestSearch", mock.Anything, "go", 3).Return(&entities.SearchSuggestions{
+		Query:   "go",
+		Titles:  []entities.Suggestion{{Value: "Go generics", ID: "b1", Slug: "go-generics", Popularity: 42}},
+		Tags:    []entities.Suggestion{{Value: "golang", Popularity: 12}},
+		Authors: []entities.Suggestion{},
+	}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search/suggest?q=go&limit=3", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"titles":[{"value":"Go generics","id":"b1","slug":"go-generics","popularity":42}]`)
+	assert.Contains(t, w.Body.String(), `"tags":[{"value":"golang","popularity":12}],"authors":[]`)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search/suggest?q=go&limit=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.On("SuggestSearch", mock.Anything, "", 0).Return((*entities.SearchSuggestions)(nil), errors.New("q is required")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search/suggest", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "q is required")
+}
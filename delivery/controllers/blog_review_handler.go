@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BlogReviewHandler struct {
+	UseCase interfaces.BlogReviewUseCaseInterface
+}
+
+func NewBlogReviewHandler(uc interfaces.BlogReviewUseCaseInterface) *BlogReviewHandler {
+	return &BlogReviewHandler{UseCase: uc}
+}
+
+// reviewCommentRequest is the optional body of reviewer decisions
+type reviewCommentRequest struct {
+	Comment string `json:"comment"`
+}
+
+// SubmitForReview handles POST /blogs/:id/review
+func (h *BlogReviewHandler) SubmitForReview(c *gin.Context) {
+	blog, err := h.UseCase.SubmitForReview(c.Request.Context(), c.Param("id"), c.GetString("userID"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// GetBlogReviews handles GET /blogs/:id/reviews
+func (h *BlogReviewHandler) GetBlogReviews(c *gin.Context) {
+	reviews, err := h.UseCase.GetBlogReviews(c.Request.Context(), c.Param("id"), c.GetString("userID"))
+	if err != nil {
+		c.JSON(403, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Reviews retrieved successfully",
+		"data":    reviews,
+		"count":   len(reviews),
+	})
+}
+
+// GetReviewQueue handles GET /reviews/queue
+func (h *BlogReviewHandler) GetReviewQueue(c *gin.Context) {
+	page, err := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	blogs, err := h.UseCase.GetReviewQueue(c.Request.Context(), page, limit)
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Review queue retrieved successfully",
+		"data":    blogs,
+		"count":   len(blogs),
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// ApproveBlog handles POST /reviews/:id/approve
+func (h *BlogReviewHandler) ApproveBlog(c *gin.Context) {
+	var req reviewCommentRequest
+	// The comment is optional, so an empty body is fine
+	_ = c.ShouldBindJSON(&req)
+
+	blog, err := h.UseCase.ApproveBlog(c.Request.Context(), c.Param("id"), c.GetString("userID"), req.Comment)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
+
+// RequestChanges handles POST /reviews/:id/request-changes
+func (h *BlogReviewHandler) RequestChanges(c *gin.Context) {
+	var req reviewCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	blog, err := h.UseCase.RequestChanges(c.Request.Context(), c.Param("id"), c.GetString("userID"), req.Comment)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, blog)
+}
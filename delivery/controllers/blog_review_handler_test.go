@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	ucMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newReviewRouter(h *BlogReviewHandler) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "u1"); c.Next() })
+	r.POST("/blogs/:id/review", h.SubmitForReview)
+	r.GET("/blogs/:id/reviews", h.GetBlogReviews)
+	r.GET("/reviews/queue", h.GetReviewQueue)
+	r.POST("/reviews/:id/approve", h.ApproveBlog)
+	r.POST("/reviews/:id/request-changes", h.RequestChanges)
+	return r
+}
+
+func TestSubmitForReview_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogReviewUseCaseInterface(t)
+	r := newReviewRouter(NewBlogReviewHandler(uc))
+
+	uc.On("SubmitForReview", mock.Anything, "b1", "u1").Return(&entities.Blog{ReviewStatus: entities.ReviewStatusSubmitted}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blogs/b1/review", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	uc.On("SubmitForReview", mock.Anything, "b2", "u1").Return(nil, errors.New("blog is already waiting for review")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blogs/b2/review", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReviewDecisions_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogReviewUseCaseInterface(t)
+	r := newReviewRouter(NewBlogReviewHandler(uc))
+
+	// approving works without a body
+	uc.On("ApproveBlog", mock.Anything, "b1", "u1", "").Return(&entities.Blog{ReviewStatus: entities.ReviewStatusApproved}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reviews/b1/approve", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	uc.On("RequestChanges", mock.Anything, "b1", "u1", "Add an intro").Return(&entities.Blog{ReviewStatus: entities.ReviewStatusChangesRequested}, nil).Once()
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/reviews/b1/request-changes", strings.NewReader(`{"comment":"Add an intro"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// invalid payload
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/reviews/b1/request-changes", strings.NewReader(`{`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetReviewQueue_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewBlogReviewUseCaseInterface(t)
+	r := newReviewRouter(NewBlogReviewHandler(uc))
+
+	// invalid paging falls back to the defaults
+	uc.On("GetReviewQueue", mock.Anything, int64(1), int64(20)).Return([]*entities.Blog{{Title: "Draft"}}, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reviews/queue?page=0&limit=500", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"count":1`)
+
+	uc.On("GetBlogReviews", mock.Anything, "b1", "u1").Return(nil, errors.New("only the authors and reviewers can see the reviews of a blog"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/b1/reviews", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -1,6 +1,11 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
 
@@ -25,8 +30,7 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 	}
 
 	// Get authenticated user ID from context (set by auth middleware)
-	userID, exists := c.Get("userID")
-	if !exists {
+	if _, exists := c.Get("userID"); !exists {
 		c.JSON(401, gin.H{"error": "User not authenticated"})
 		return
 	}
@@ -38,13 +42,51 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 		return
 	}
 
-	if err := h.UseCase.CreateComment(c.Request.Context(), &comment, userID.(string), blogID); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+	if err := h.UseCase.CreateComment(c.Request.Context(), &comment, commentActor(c), blogID); err != nil {
+		switch err.Error() {
+		case "blog not found", "this blog is password protected":
+			respondBlogReadError(c, err)
+		case "comments are locked on this blog":
+			c.JSON(403, gin.H{"error": err.Error()})
+		default:
+			c.JSON(500, gin.H{"error": err.Error()})
+		}
 		return
 	}
 	c.JSON(201, comment)
 }
 
+// CreateReply handles POST /comments/:id/replies
+func (h *CommentHandler) CreateReply(c *gin.Context) {
+	var reply entities.Comment
+	if err := c.ShouldBindJSON(&reply); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if _, exists := c.Get("userID"); !exists {
+		c.JSON(401, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := h.UseCase.CreateReply(c.Request.Context(), &reply, commentActor(c), c.Param("id")); err != nil {
+		switch err.Error() {
+		case "comment not found":
+			c.JSON(404, gin.H{"error": "Comment not found"})
+		case "blog not found", "this blog is password protected":
+			respondBlogReadError(c, err)
+		case "comments are locked on this blog":
+			c.JSON(403, gin.H{"error": err.Error()})
+		case "cannot reply to a deleted comment", "cannot reply to a hidden comment", "this thread cannot be nested any deeper":
+			c.JSON(400, gin.H{"error": err.Error()})
+		default:
+			c.JSON(500, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(201, reply)
+}
+
 // GetCommentsByBlog handles GET /blogs/:id/comments
 func (h *CommentHandler) GetCommentsByBlog(c *gin.Context) {
 	blogID := c.Param("id") // Changed from "blogId" to "id" to match the route parameter
@@ -53,19 +95,73 @@ func (h *CommentHandler) GetCommentsByBlog(c *gin.Context) {
 		return
 	}
 
-	comments, err := h.UseCase.GetCommentsByBlogID(c.Request.Context(), blogID)
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(400, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+		limit = parsed
+	}
+
+	comments, err := h.UseCase.GetCommentsByBlogID(c.Request.Context(), blogID, commentActor(c), c.Query("sort"), limit, c.Query("cursor"))
 	if err != nil {
+		if isBlogReadError(err) {
+			respondBlogReadError(c, err)
+			return
+		}
+		if isCommentListError(err) {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(200, comments)
 }
 
+// GetReplies handles GET /comments/:id/replies
+func (h *CommentHandler) GetReplies(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(400, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+		limit = parsed
+	}
+
+	replies, err := h.UseCase.GetReplies(c.Request.Context(), c.Param("id"), commentActor(c), c.Query("sort"), limit, c.Query("cursor"))
+	if err != nil {
+		if err.Error() == "comment not found" {
+			c.JSON(404, gin.H{"error": "Comment not found"})
+			return
+		}
+		if isBlogReadError(err) {
+			respondBlogReadError(c, err)
+			return
+		}
+		if isCommentListError(err) {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, replies)
+}
+
 // GetCommentByID handles GET /comments/:id
 func (h *CommentHandler) GetCommentByID(c *gin.Context) {
 	id := c.Param("id")
-	comment, err := h.UseCase.GetCommentByID(c.Request.Context(), id)
+	comment, err := h.UseCase.GetCommentByID(c.Request.Context(), id, commentActor(c))
 	if err != nil {
+		if err.Error() == "this blog is password protected" {
+			respondBlogReadError(c, err)
+			return
+		}
 		c.JSON(404, gin.H{"error": "Comment not found"})
 		return
 	}
@@ -84,7 +180,7 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 	}
 
 	// First, get the existing comment to check ownership and preserve data
-	existingComment, err := h.UseCase.GetCommentByID(c.Request.Context(), id)
+	existingComment, err := h.UseCase.GetCommentByID(c.Request.Context(), id, commentActor(c))
 	if err != nil {
 		c.JSON(404, gin.H{"error": "Comment not found"})
 		return
@@ -118,34 +214,149 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 	c.JSON(200, existingComment)
 }
 
+// GetCommentRevisions handles GET /comments/:id/revisions
+func (h *CommentHandler) GetCommentRevisions(c *gin.Context) {
+	revisions, err := h.UseCase.GetCommentRevisions(c.Request.Context(), c.Param("id"), commentActor(c))
+	if err != nil {
+		if isBlogReadError(err) {
+			respondBlogReadError(c, err)
+			return
+		}
+		respondModerationError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{"revisions": revisions, "count": len(revisions)})
+}
+
 // DeleteComment handles DELETE /comments/:id
+// The author of the comment, the author of the blog and admins may delete it; ?reason= is kept in the moderation log
 func (h *CommentHandler) DeleteComment(c *gin.Context) {
-	id := c.Param("id")
-
-	// Get authenticated user ID
-	userID, exists := c.Get("userID")
-	if !exists {
+	if _, exists := c.Get("userID"); !exists {
 		c.JSON(401, gin.H{"error": "User not authenticated"})
 		return
 	}
+	if err := h.UseCase.DeleteComment(c.Request.Context(), c.Param("id"), commentActor(c), c.Query("reason")); err != nil {
+		respondModerationError(c, err)
+		return
+	}
+	c.Status(204)
+}
+
+// HideComment handles POST /comments/:id/hide
+func (h *CommentHandler) HideComment(c *gin.Context) {
+	h.moderateComment(c, h.UseCase.HideComment, "Comment hidden successfully")
+}
 
-	// First, get the existing comment to check ownership
-	existingComment, err := h.UseCase.GetCommentByID(c.Request.Context(), id)
+// UnhideComment handles POST /comments/:id/unhide
+func (h *CommentHandler) UnhideComment(c *gin.Context) {
+	h.moderateComment(c, h.UseCase.UnhideComment, "Comment unhidden successfully")
+}
+
+// PinComment handles POST /comments/:id/pin
+func (h *CommentHandler) PinComment(c *gin.Context) {
+	h.moderateComment(c, h.UseCase.PinComment, "Comment pinned successfully")
+}
+
+// UnpinComment handles POST /comments/:id/unpin
+func (h *CommentHandler) UnpinComment(c *gin.Context) {
+	h.moderateComment(c, h.UseCase.UnpinComment, "Comment unpinned successfully")
+}
+
+// moderateComment runs a moderation action on the comment in the path, with the optional reason of the body
+func (h *CommentHandler) moderateComment(c *gin.Context, action func(ctx context.Context, id string, actor *entities.CommentActor, reason string) error, message string) {
+	reason, err := moderationReason(c)
 	if err != nil {
-		c.JSON(404, gin.H{"error": "Comment not found"})
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
 		return
 	}
+	if err := action(c.Request.Context(), c.Param("id"), commentActor(c), reason); err != nil {
+		respondModerationError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{"message": message})
+}
 
-	// Check ownership - user can only delete their own comments
-	if existingComment.UserID != userID.(string) {
-		c.JSON(403, gin.H{"error": "You can only delete your own comments"})
+// LockComments handles POST /blogs/:id/comments/lock
+func (h *CommentHandler) LockComments(c *gin.Context) {
+	h.setCommentsLocked(c, true, "Comments locked successfully")
+}
+
+// UnlockComments handles POST /blogs/:id/comments/unlock
+func (h *CommentHandler) UnlockComments(c *gin.Context) {
+	h.setCommentsLocked(c, false, "Comments unlocked successfully")
+}
+
+func (h *CommentHandler) setCommentsLocked(c *gin.Context, locked bool, message string) {
+	reason, err := moderationReason(c)
+	if err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if err := h.UseCase.SetCommentsLocked(c.Request.Context(), c.Param("id"), commentActor(c), locked, reason); err != nil {
+		respondModerationError(c, err)
 		return
 	}
+	c.JSON(200, gin.H{"message": message})
+}
 
-	// Delete the comment
-	if err := h.UseCase.DeleteComment(c.Request.Context(), id); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+// GetModerationLog handles GET /blogs/:id/comments/moderation-log
+func (h *CommentHandler) GetModerationLog(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(400, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+		limit = parsed
+	}
+	actions, err := h.UseCase.GetModerationLog(c.Request.Context(), c.Param("id"), commentActor(c), limit)
+	if err != nil {
+		respondModerationError(c, err)
 		return
 	}
-	c.Status(204)
+	c.JSON(200, gin.H{"actions": actions, "count": len(actions)})
+}
+
+// commentActor is the signed-in user of the request, if any, with the blog access token it carries
+func commentActor(c *gin.Context) *entities.CommentActor {
+	return &entities.CommentActor{UserID: c.GetString("userID"), Role: c.GetString("role"), AccessToken: blogAccess(c).AccessToken}
+}
+
+// isBlogReadError reports the errors of blogs the reader may not see, see respondBlogReadError
+func isBlogReadError(err error) bool {
+	return err.Error() == "blog not found" || err.Error() == "this blog is password protected"
+}
+
+// moderationReason reads the optional {"reason": "..."} body of a moderation action
+func moderationReason(c *gin.Context) (string, error) {
+	var req entities.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return req.Reason, nil
+}
+
+// respondModerationError maps the errors of deleting and moderating comments to responses
+func respondModerationError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "comment not found":
+		c.JSON(404, gin.H{"error": "Comment not found"})
+	case "blog not found":
+		c.JSON(404, gin.H{"error": "Blog not found"})
+	case "you can only delete your own comments":
+		c.JSON(403, gin.H{"error": "You can only delete your own comments"})
+	case "only the blog author or an admin can moderate its comments", "you cannot view the history of this comment":
+		c.JSON(403, gin.H{"error": err.Error()})
+	case "cannot hide a deleted comment", "only top-level comments can be pinned", "cannot pin a deleted comment",
+		"cannot pin a hidden comment", "pinned comment limit reached":
+		c.JSON(400, gin.H{"error": err.Error()})
+	default:
+		c.JSON(500, gin.H{"error": err.Error()})
+	}
+}
+
+// isCommentListError reports errors caused by the query parameters of a comment list
+func isCommentListError(err error) bool {
+	return isCursorError(err) || err.Error() == "invalid sort value. Valid values: oldest, newest, most_liked, top"
 }
@@ -3,6 +3,7 @@ package controllers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -26,7 +27,7 @@ func TestGetCommentsByBlog_BadID(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	// We'll use a normal ID and UC returns error 500 to exercise error path
-	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1").Return(nil, assert.AnError)
+	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1", &entities.CommentActor{}, "", 0, "").Return(nil, assert.AnError)
 	req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments", nil)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -56,7 +57,7 @@ func TestCreateComment_Happy(t *testing.T) {
 	uc := ucMocks.NewCommentUseCaseInterface(t)
 	h := NewCommentHandler(uc)
 
-	uc.On("CreateComment", mock.Anything, mock.AnythingOfType("*entities.Comment"), "user-1", "blog-1").Return(nil)
+	uc.On("CreateComment", mock.Anything, mock.AnythingOfType("*entities.Comment"), &entities.CommentActor{UserID: "user-1"}, "blog-1").Return(nil)
 
 	r := gin.New()
 	r.Use(func(c *gin.Context) { c.Set("userID", "user-1") })
@@ -78,7 +79,7 @@ func TestUpdateComment_ForbiddenWhenNotOwner(t *testing.T) {
 	h := NewCommentHandler(uc)
 
 	// existing comment owned by someone else
-	uc.On("GetCommentByID", mock.Anything, "c-1").Return(&entities.Comment{UserID: "owner-2"}, nil)
+	uc.On("GetCommentByID", mock.Anything, "c-1", &entities.CommentActor{UserID: "owner-1"}).Return(&entities.Comment{UserID: "owner-2"}, nil)
 
 	r := gin.New()
 	r.Use(func(c *gin.Context) { c.Set("userID", "owner-1") })
@@ -99,8 +100,8 @@ func TestDeleteComment_ForbiddenWhenNotOwner(t *testing.T) {
 	uc := ucMocks.NewCommentUseCaseInterface(t)
 	h := NewCommentHandler(uc)
 
-	// existing comment owned by someone else
-	uc.On("GetCommentByID", mock.Anything, "c-1").Return(&entities.Comment{UserID: "owner-2"}, nil)
+	// comment owned by someone else, on a blog the user does not moderate
+	uc.On("DeleteComment", mock.Anything, "c-1", &entities.CommentActor{UserID: "owner-1"}, "").Return(errors.New("you can only delete your own comments"))
 
 	r := gin.New()
 	r.Use(func(c *gin.Context) { c.Set("userID", "owner-1") })
@@ -111,4 +112,208 @@ func TestDeleteComment_ForbiddenWhenNotOwner(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"error":"You can only delete your own comments"}`, w.Body.String())
+}
+
+func TestDeleteComment_ByModerator(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentUseCaseInterface(t)
+	h := NewCommentHandler(uc)
+
+	uc.On("DeleteComment", mock.Anything, "c-1", &entities.CommentActor{UserID: "admin-1", Role: "admin"}, "spam").Return(nil)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "admin-1"); c.Set("role", "admin") })
+	r.DELETE("/comments/:id", h.DeleteComment)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/comments/c-1?reason=spam", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestModerateComment(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentUseCaseInterface(t)
+	h := NewCommentHandler(uc)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "author") })
+	r.POST("/comments/:id/hide", h.HideComment)
+	r.POST("/comments/:id/pin", h.PinComment)
+	r.POST("/blogs/:id/comments/lock", h.LockComments)
+
+	post := func(path string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+	actor := &entities.CommentActor{UserID: "author"}
+
+	uc.On("HideComment", mock.Anything, "c-1", actor, "abusive").Return(nil).Once()
+	w := post("/comments/c-1/hide", `{"reason":"abusive"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message":"Comment hidden successfully"}`, w.Body.String())
+
+	// the reason is optional
+	uc.On("HideComment", mock.Anything, "c-2", actor, "").Return(errors.New("only the blog author or an admin can moderate its comments")).Once()
+	assert.Equal(t, http.StatusForbidden, post("/comments/c-2/hide", "").Code)
+
+	assert.Equal(t, http.StatusBadRequest, post("/comments/c-1/hide", `{"reason":`).Code)
+
+	uc.On("PinComment", mock.Anything, "c-3", actor, "").Return(errors.New("pinned comment limit reached")).Once()
+	assert.Equal(t, http.StatusBadRequest, post("/comments/c-3/pin", "").Code)
+
+	uc.On("SetCommentsLocked", mock.Anything, "blog-1", actor, true, "").Return(nil).Once()
+	w = post("/blogs/blog-1/comments/lock", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message":"Comments locked successfully"}`, w.Body.String())
+}
+
+func TestGetModerationLog(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentUseCaseInterface(t)
+	h := NewCommentHandler(uc)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "author") })
+	r.GET("/blogs/:id/comments/moderation-log", h.GetModerationLog)
+
+	uc.On("GetModerationLog", mock.Anything, "blog-1", &entities.CommentActor{UserID: "author"}, 10).Return([]*entities.ModerationAction{{Action: "lock", ModeratorID: "author", ModeratorRole: "owner"}}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments/moderation-log?limit=10", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"moderator_role":"owner"`)
+	assert.Contains(t, w.Body.String(), `"count":1`)
+
+	uc.On("GetModerationLog", mock.Anything, "blog-2", &entities.CommentActor{UserID: "author"}, 0).Return(nil, errors.New("blog not found")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-2/comments/moderation-log", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetCommentsByBlog_Pagination(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentUseCaseInterface(t)
+	h := NewCommentHandler(uc)
+	r := gin.New()
+	r.GET("/blogs/:id/comments", h.GetCommentsByBlog)
+
+	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1", &entities.CommentActor{}, "", 10, "abc.def").Return(&entities.CommentPage{Comments: []*entities.Comment{}, NextCursor: "n", PrevCursor: "p"}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments?limit=10&cursor=abc.def", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"comments":[],"count":0,"total_count":0,"next_cursor":"n","prev_cursor":"p"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments?limit=-1", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1", &entities.CommentActor{}, "", 0, "bad").Return(nil, errors.New("invalid cursor")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments?cursor=bad", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1", &entities.CommentActor{}, "most_liked", 0, "").Return(&entities.CommentPage{Comments: []*entities.Comment{}, TotalCount: 4}, nil).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments?sort=most_liked", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"comments":[],"count":0,"total_count":4}`, w.Body.String())
+
+	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1", &entities.CommentActor{}, "loudest", 0, "").Return(nil, errors.New("invalid sort value. Valid values: oldest, newest, most_liked, top")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments?sort=loudest", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the comments of blogs the reader may not see follow the blog: missing or password protected
+	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1", &entities.CommentActor{}, "", 0, "").Return(nil, errors.New("blog not found")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	uc.On("GetCommentsByBlogID", mock.Anything, "blog-1", &entities.CommentActor{AccessToken: "tok"}, "", 0, "").Return(nil, errors.New("this blog is password protected")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments?access_token=tok", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"error":"This blog is password protected","password_required":true}`, w.Body.String())
+}
+
+func TestCreateReply(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentUseCaseInterface(t)
+	h := NewCommentHandler(uc)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "user-1") })
+	r.POST("/comments/:id/replies", h.CreateReply)
+
+	post := func(parentID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/comments/"+parentID+"/replies", bytes.NewReader([]byte(`{"content":"agreed"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	uc.On("CreateReply", mock.Anything, mock.AnythingOfType("*entities.Comment"), &entities.CommentActor{UserID: "user-1"}, "c-1").Return(nil).Once()
+	assert.Equal(t, http.StatusCreated, post("c-1").Code)
+
+	uc.On("CreateReply", mock.Anything, mock.Anything, &entities.CommentActor{UserID: "user-1"}, "c-2").Return(errors.New("comment not found")).Once()
+	assert.Equal(t, http.StatusNotFound, post("c-2").Code)
+
+	uc.On("CreateReply", mock.Anything, mock.Anything, &entities.CommentActor{UserID: "user-1"}, "c-3").Return(errors.New("this thread cannot be nested any deeper")).Once()
+	w := post("c-3")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"this thread cannot be nested any deeper"}`, w.Body.String())
+
+	uc.On("CreateReply", mock.Anything, mock.Anything, &entities.CommentActor{UserID: "user-1"}, "c-4").Return(errors.New("comments are locked on this blog")).Once()
+	assert.Equal(t, http.StatusForbidden, post("c-4").Code)
+}
+
+func TestGetReplies(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentUseCaseInterface(t)
+	h := NewCommentHandler(uc)
+	r := gin.New()
+	r.GET("/comments/:id/replies", h.GetReplies)
+
+	uc.On("GetReplies", mock.Anything, "c-1", &entities.CommentActor{}, "", 5, "").Return(&entities.CommentPage{Comments: []*entities.Comment{}}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/c-1/replies?limit=5", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"comments":[],"count":0,"total_count":0}`, w.Body.String())
+
+	uc.On("GetReplies", mock.Anything, "c-1", &entities.CommentActor{}, "", 0, "bad").Return(nil, errors.New("invalid cursor")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/c-1/replies?cursor=bad", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetCommentRevisions(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentUseCaseInterface(t)
+	h := NewCommentHandler(uc)
+
+	r := gin.New()
+	r.GET("/comments/:id/revisions", h.GetCommentRevisions)
+
+	uc.On("GetCommentRevisions", mock.Anything, "c-1", &entities.CommentActor{}).Return([]*entities.CommentRevision{{Version: 2, Content: "edited"}, {Version: 1, Content: "posted"}}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/c-1/revisions", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"content":"edited"`)
+	assert.Contains(t, w.Body.String(), `"count":2`)
+
+	uc.On("GetCommentRevisions", mock.Anything, "c-2", &entities.CommentActor{}).Return(nil, errors.New("you cannot view the history of this comment")).Once()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/c-2/revisions", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
 }
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+type CommentReactionHandler struct {
+	UseCase interfaces.CommentReactionUseCaseInterface
+}
+
+func NewCommentReactionHandler(uc interfaces.CommentReactionUseCaseInterface) *CommentReactionHandler {
+	return &CommentReactionHandler{UseCase: uc}
+}
+
+// LikeComment handles POST /comments/:id/like
+func (h *CommentReactionHandler) LikeComment(c *gin.Context) {
+	h.react(c, h.UseCase.LikeComment)
+}
+
+// DislikeComment handles POST /comments/:id/dislike
+func (h *CommentReactionHandler) DislikeComment(c *gin.Context) {
+	h.react(c, h.UseCase.DislikeComment)
+}
+
+// react applies a like or dislike and reports the user's reaction afterwards
+func (h *CommentReactionHandler) react(c *gin.Context, action func(ctx context.Context, commentID string, actor *entities.CommentActor) (string, error)) {
+	if _, exists := c.Get("userID"); !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	reaction, err := action(c.Request.Context(), c.Param("id"), commentActor(c))
+	if err != nil {
+		respondReactionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": reactionMessages[reaction], "reaction": reaction})
+}
+
+// reactionMessages describe the user's reaction after a like or dislike
+var reactionMessages = map[string]string{
+	"like":    "Comment liked successfully",
+	"dislike": "Comment disliked successfully",
+	"":        "Reaction removed successfully",
+}
+
+// respondReactionError maps the errors of reacting to a comment to responses
+func respondReactionError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "comment not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+	case "this blog is password protected":
+		respondBlogReadError(c, err)
+	case "cannot react to a deleted comment", "cannot react to a hidden comment":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
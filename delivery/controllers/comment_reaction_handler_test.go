@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	ucMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentReactions(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewCommentReactionUseCaseInterface(t)
+	h := NewCommentReactionHandler(uc)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "user-1") })
+	r.POST("/comments/:id/like", h.LikeComment)
+	r.POST("/comments/:id/dislike", h.DislikeComment)
+
+	post := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w
+	}
+
+	actor := mock.MatchedBy(func(a *entities.CommentActor) bool { return a.UserID == "user-1" })
+	uc.On("LikeComment", mock.Anything, "c-1", actor).Return("like", nil).Once()
+	w := post("/comments/c-1/like")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message":"Comment liked successfully","reaction":"like"}`, w.Body.String())
+
+	uc.On("LikeComment", mock.Anything, "c-1", actor).Return("", nil).Once()
+	w = post("/comments/c-1/like")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"message":"Reaction removed successfully","reaction":""}`, w.Body.String())
+
+	uc.On("DislikeComment", mock.Anything, "c-2", actor).Return("", errors.New("comment not found")).Once()
+	assert.Equal(t, http.StatusNotFound, post("/comments/c-2/dislike").Code)
+
+	uc.On("LikeComment", mock.Anything, "c-3", actor).Return("", errors.New("cannot react to a deleted comment")).Once()
+	assert.Equal(t, http.StatusBadRequest, post("/comments/c-3/like").Code)
+
+	uc.On("DislikeComment", mock.Anything, "c-4", actor).Return("", errors.New("this blog is password protected")).Once()
+	assert.Equal(t, http.StatusUnauthorized, post("/comments/c-4/dislike").Code)
+}
+
+func TestCommentReactions_Unauthorized(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	h := NewCommentReactionHandler(ucMocks.NewCommentReactionUseCaseInterface(t))
+
+	r := gin.New()
+	r.POST("/comments/:id/like", h.LikeComment)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/comments/c-1/like", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeriesHandler struct {
+	UseCase interfaces.SeriesUseCaseInterface
+}
+
+func NewSeriesHandler(uc interfaces.SeriesUseCaseInterface) *SeriesHandler {
+	return &SeriesHandler{UseCase: uc}
+}
+
+// CreateSeries handles POST /series
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var series entities.Series
+	if err := c.ShouldBindJSON(&series); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	userID := c.GetString("userID")
+	if err := h.UseCase.CreateSeries(c.Request.Context(), &series, userID); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(201, series)
+}
+
+// GetSeriesByID handles GET /series/:id
+func (h *SeriesHandler) GetSeriesByID(c *gin.Context) {
+	series, err := h.UseCase.GetSeriesByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(404, gin.H{"error": "Series not found"})
+		return
+	}
+	c.JSON(200, series)
+}
+
+// GetMySeries handles GET /series
+func (h *SeriesHandler) GetMySeries(c *gin.Context) {
+	series, err := h.UseCase.GetSeriesByUserID(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Series retrieved successfully",
+		"data":    series,
+		"count":   len(series),
+	})
+}
+
+// AddBlogToSeries handles POST /series/:id/blogs
+func (h *SeriesHandler) AddBlogToSeries(c *gin.Context) {
+	var req struct {
+		BlogID string `json:"blog_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "blog_id is required"})
+		return
+	}
+
+	series, err := h.UseCase.AddBlogToSeries(c.Request.Context(), c.Param("id"), req.BlogID, c.GetString("userID"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, series)
+}
+
+// RemoveBlogFromSeries handles DELETE /series/:id/blogs/:blogId
+func (h *SeriesHandler) RemoveBlogFromSeries(c *gin.Context) {
+	series, err := h.UseCase.RemoveBlogFromSeries(c.Request.Context(), c.Param("id"), c.Param("blogId"), c.GetString("userID"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, series)
+}
+
+// ReorderSeries handles PUT /series/:id/order
+func (h *SeriesHandler) ReorderSeries(c *gin.Context) {
+	var req struct {
+		BlogIDs []string `json:"blog_ids" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "blog_ids is required"})
+		return
+	}
+
+	series, err := h.UseCase.ReorderSeries(c.Request.Context(), c.Param("id"), req.BlogIDs, c.GetString("userID"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, series)
+}
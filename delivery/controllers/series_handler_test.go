@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	ucMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSeriesRouter(h *SeriesHandler) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", "u1"); c.Next() })
+	r.POST("/series", h.CreateSeries)
+	r.GET("/series/:id", h.GetSeriesByID)
+	r.POST("/series/:id/blogs", h.AddBlogToSeries)
+	r.PUT("/series/:id/order", h.ReorderSeries)
+	return r
+}
+
+func TestCreateSeries_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewSeriesUseCaseInterface(t)
+	r := newSeriesRouter(NewSeriesHandler(uc))
+
+	uc.On("CreateSeries", mock.Anything, mock.AnythingOfType("*entities.Series"), "u1").Return(nil)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/series", strings.NewReader(`{"title":"Go from zero"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestGetSeriesByID_NotFound(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewSeriesUseCaseInterface(t)
+	r := newSeriesRouter(NewSeriesHandler(uc))
+
+	uc.On("GetSeriesByID", mock.Anything, "s1").Return(nil, errors.New("series not found"))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/series/s1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddBlogToSeries_And_Reorder(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewSeriesUseCaseInterface(t)
+	r := newSeriesRouter(NewSeriesHandler(uc))
+
+	// missing blog_id
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/series/s1/blogs", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.On("AddBlogToSeries", mock.Anything, "s1", "b1", "u1").Return(&entities.Series{Title: "Go"}, nil)
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/series/s1/blogs", strings.NewReader(`{"blog_id":"b1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	uc.On("ReorderSeries", mock.Anything, "s1", []string{"b2", "b1"}, "u1").Return(nil, errors.New("blog_ids must contain exactly the blogs of the series"))
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/series/s1/order", strings.NewReader(`{"blog_ids":["b2","b1"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
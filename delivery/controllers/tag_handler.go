@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	UseCase interfaces.TagUseCaseInterface
+}
+
+func NewTagHandler(uc interfaces.TagUseCaseInterface) *TagHandler {
+	return &TagHandler{UseCase: uc}
+}
+
+// ListTags handles GET /tags
+func (h *TagHandler) ListTags(c *gin.Context) {
+	tags, err := h.UseCase.ListTags(c.Request.Context())
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Tags retrieved successfully",
+		"data":    tags,
+		"count":   len(tags),
+	})
+}
+
+// CreateTag handles POST /tags
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var tag entities.Tag
+	if err := c.ShouldBindJSON(&tag); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	created, err := h.UseCase.CreateTag(c.Request.Context(), &tag)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(201, created)
+}
+
+// UpdateTag handles PUT /tags/:slug
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	var update entities.TagUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	tag, err := h.UseCase.UpdateTag(c.Request.Context(), c.Param("slug"), &update)
+	if err != nil {
+		if err.Error() == "tag not found" {
+			c.JSON(404, gin.H{"error": "Tag not found"})
+			return
+		}
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, tag)
+}
+
+// RenameTag handles POST /tags/:slug/rename
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	var req struct {
+		Slug string `json:"slug"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	change, err := h.UseCase.RenameTag(c.Request.Context(), c.Param("slug"), req.Slug)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Tag renamed successfully",
+		"data":    change,
+	})
+}
+
+// MergeTags handles POST /tags/merge
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	var merge entities.TagMerge
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	change, err := h.UseCase.MergeTags(c.Request.Context(), &merge)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"message": "Tags merged successfully",
+		"data":    change,
+	})
+}
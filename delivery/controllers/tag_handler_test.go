@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	ucMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTagRouter(h *TagHandler) *gin.Engine {
+	r := gin.New()
+	r.GET("/tags", h.ListTags)
+	r.PUT("/tags/:slug", h.UpdateTag)
+	r.POST("/tags/:slug/rename", h.RenameTag)
+	r.POST("/tags/merge", h.MergeTags)
+	return r
+}
+
+func TestListTags_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewTagUseCaseInterface(t)
+	r := newTagRouter(NewTagHandler(uc))
+
+	uc.On("ListTags", mock.Anything).Return([]*entities.Tag{{Slug: "go", Name: "Go", PostCount: 7}}, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tags", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"post_count":7`)
+}
+
+func TestUpdateTag_NotFound(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewTagUseCaseInterface(t)
+	r := newTagRouter(NewTagHandler(uc))
+
+	uc.On("UpdateTag", mock.Anything, "nope", mock.AnythingOfType("*entities.TagUpdate")).Return(nil, errors.New("tag not found"))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/tags/nope", strings.NewReader(`{"description":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRenameAndMergeTags_Handler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	uc := ucMocks.NewTagUseCaseInterface(t)
+	r := newTagRouter(NewTagHandler(uc))
+
+	uc.On("RenameTag", mock.Anything, "js", "javascript").Return(&entities.TagChange{Tag: &entities.Tag{Slug: "javascript"}, BlogsUpdated: 3}, nil)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/tags/js/rename", strings.NewReader(`{"slug":"javascript"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"blogs_updated":3`)
+
+	uc.On("MergeTags", mock.Anything, &entities.TagMerge{Sources: []string{"golang"}, Target: "go"}).Return(nil, errors.New(`"golang" is a synonym of "go"`))
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/tags/merge", strings.NewReader(`{"sources":["golang"],"target":"go"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
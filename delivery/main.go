@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/Abenuterefe/a2sv-project/delivery/routers"
 	"github.com/Abenuterefe/a2sv-project/infrastructure/database"
@@ -18,12 +24,21 @@ func main() {
 	}
 	ai.Setup()
 
+	// Cancelled on Ctrl+C or SIGTERM; stops the background jobs and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// CONNECT TO MONGODB DATABASE
 	mongoClient, err := database.ConnectMongoDB()
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	// APPLY ONE-TIME DATA MIGRATIONS
+	if err := database.RunMigrations(ctx, mongoClient); err != nil {
+		log.Fatal("❌ Failed to migrate the database:", err)
+	}
+
 	// CREATE ROUTER
 	r := gin.Default()
 
@@ -31,20 +46,43 @@ func main() {
 	r.Static("/uploads", "./uploads")
 
 	// ROUTES
-	routers.BlogRoutes(r, mongoClient)
+	blogScheduler := routers.BlogRoutes(r, mongoClient)
 	routers.UserRoutes(r, mongoClient)
 	routers.ProfileRoutes(r, mongoClient)
 	routers.AiRoutes(r)
 	routers.CommentRoutes(r, mongoClient)
 	routers.BlogInteractionRoutes(r, mongoClient)
+	routers.SeriesRoutes(r, mongoClient)
+	routers.ReviewRoutes(r, mongoClient)
+	routers.TagRoutes(r, mongoClient)
 
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
+	// START BACKGROUND JOBS
+	blogScheduler.Start(ctx)
+
 	// START SERVER
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("❌ Failed to start server:", err)
+	server := &http.Server{Addr: ":" + port, Handler: r}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("❌ Failed to start server:", err)
+		}
+	}()
+
+	// GRACEFUL SHUTDOWN: finish in-flight requests and the current scheduler run, then disconnect
+	<-ctx.Done()
+	stop()
+	log.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Println("⚠️ server shutdown failed:", err)
+	}
+	blogScheduler.Wait()
+	if err := mongoClient.Disconnect(shutdownCtx); err != nil {
+		log.Println("⚠️ failed to disconnect from MongoDB:", err)
 	}
 }
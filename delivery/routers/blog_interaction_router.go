@@ -4,6 +4,7 @@ import (
 	"github.com/Abenuterefe/a2sv-project/delivery/controllers"
 	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
 	"github.com/Abenuterefe/a2sv-project/infrastructure/middlewares"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/ranking"
 	"github.com/Abenuterefe/a2sv-project/repository"
 	"github.com/Abenuterefe/a2sv-project/usecase"
 
@@ -19,7 +20,8 @@ func BlogInteractionRoutes(r *gin.Engine, client *mongo.Client) {
 	interactionRepo := repository.NewBlogInteractionRepositoryMongo(interactionCollection)
 	blogRepo := repository.NewBlogRepositoryMongo(blogCollection)
 
-	interactionUseCase := usecase.NewBlogInteractionUseCase(interactionRepo, blogRepo)
+	weights := ranking.LoadEngagementWeights(usecase.DefaultEngagementWeights()) // likes, dislikes and views feed the popularity scores
+	interactionUseCase := usecase.NewBlogInteractionUseCase(interactionRepo, blogRepo, weights)
 	interactionHandler := controllers.NewBlogInteractionHandler(interactionUseCase)
 
 	api := r.Group("/api/v1")
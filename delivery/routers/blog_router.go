@@ -3,36 +3,91 @@ package routers
 import (
 	"github.com/Abenuterefe/a2sv-project/delivery/controllers"
 	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/mail"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/markdown"
 	"github.com/Abenuterefe/a2sv-project/infrastructure/middlewares"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/ranking"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/related"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/scheduler"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/storage"
 	"github.com/Abenuterefe/a2sv-project/repository"
 	"github.com/Abenuterefe/a2sv-project/usecase"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// relatedIndex is shared by the blog and tag routes; tag renames and merges rewrite the tags it ranks by
+var relatedIndex = related.NewRelatedBlogIndex()
+
 // BlogRoutes initializes the blog-related routes with authentication and authorization.
-func BlogRoutes(r *gin.Engine, client *mongo.Client) {
+// It returns the background job that applies scheduled publish/unpublish times and purges the trash;
+// the caller starts it and stops it on shutdown.
+func BlogRoutes(r *gin.Engine, client *mongo.Client) *scheduler.BlogScheduler {
 	// Get collections
 	blogCollection := client.Database("g6_starter_projectDb").Collection("blogs")
 	commentCollection := client.Database("g6_starter_projectDb").Collection("comments")
+	interactionCollection := client.Database("g6_starter_projectDb").Collection("blog_interactions")
+	seriesCollection := client.Database("g6_starter_projectDb").Collection("series")
+	db := client.Database("g6_starter_projectDb")
 
 	// Initialize JWT service for authentication
 	jwtService := auth.NewJWTService()
 
+	// Engagement sorts, popularity and trending scores may be tuned through the environment
+	settings := usecase.DefaultBlogSettings()
+	settings.Engagement = ranking.LoadEngagementWeights(settings.Engagement)
+	settings.TrendingGravity = ranking.LoadTrendingGravity(settings.TrendingGravity)
+	settings.TrashRetention = scheduler.LoadTrashRetention(settings.TrashRetention)
+
 	// initialization of repositories, usecase, and handler
 	blogRepo := repository.NewBlogRepositoryMongo(blogCollection)
 	commentRepo := repository.NewCommentRepositoryMongo(commentCollection)
-	blogUseCase := usecase.NewBlogUseCase(blogRepo, commentRepo)
+	interactionRepo := repository.NewBlogInteractionRepositoryMongo(interactionCollection)
+	seriesRepo := repository.NewSeriesRepositoryMongo(seriesCollection)
+	userRepo := repository.NewUserRepository(db)
+	tagRepo := repository.NewTagRepositoryMongo(db.Collection("tags"))
+	reactionRepo := repository.NewCommentReactionRepositoryMongo(db.Collection("comment_reactions"))
+	moderationRepo := repository.NewCommentModerationRepositoryMongo(db.Collection("comment_moderation_log"))
+	reviewRepo := repository.NewBlogReviewRepositoryMongo(db.Collection("blog_reviews"))
+	assetStorage := storage.NewLocalBlogAssetStorage("uploads/blogs")
+	markdownRenderer := markdown.NewMarkdownRenderer()
+	passwordService := auth.NewBcryptPasswordService()
+	accessTokenService := auth.NewBlogAccessTokenService()
+	cursorSigner := auth.NewCursorSigner()
+	blogUseCase := usecase.NewBlogUseCase(usecase.BlogDependencies{
+		Repo:            blogRepo,
+		CommentRepo:     commentRepo,
+		InteractionRepo: interactionRepo,
+		SeriesRepo:      seriesRepo,
+		UserRepo:        userRepo,
+		AssetStorage:    assetStorage,
+		Renderer:        markdownRenderer,
+		PasswordService: passwordService,
+		AccessTokens:    accessTokenService,
+		Cursors:         cursorSigner,
+		Related:         relatedIndex,
+		TagRepo:         tagRepo,
+		ReactionRepo:    reactionRepo,
+		ModerationRepo:  moderationRepo,
+		ReviewRepo:      reviewRepo,
+		MailService:     mail.NewMailService(),
+	}, settings)
 	blogHandler := controllers.NewBlogHandler(blogUseCase)
 
 	// Group routes under /api/v1
 	api := r.Group("/api/v1")
 
 	// Public routes (no authentication required)
-	api.GET("/blogs/:id", blogHandler.GetBlogByID) // Anyone can view a specific blog
+	// Reading a blog accepts an optional token so authors can see their private blogs
+	api.GET("/blogs/:id", middlewares.OptionalAuthMiddleware(jwtService), blogHandler.GetBlogByID) // Anyone can view a specific blog (subject to its visibility)
+	api.GET("/blogs/by-slug/:slug", middlewares.OptionalAuthMiddleware(jwtService), blogHandler.GetBlogBySlug) // Anyone can view a blog by its slug (old slugs redirect)
+	api.GET("/blogs/:id/related", middlewares.OptionalAuthMiddleware(jwtService), blogHandler.GetRelatedBlogs) // What to read next (same visibility rules as the blog)
+	api.POST("/blogs/:id/unlock", blogHandler.UnlockBlog) // Exchange the passphrase of a password-protected blog for an access token
 	api.GET("/blogs/popular", blogHandler.GetPopularBlogs) // Anyone can view popular blogs
+	api.GET("/blogs/trending", blogHandler.GetTrendingBlogs) // Anyone can view trending blogs
 	api.GET("/blogs/filter", blogHandler.FilterBlogs) // Anyone can filter blogs
 	api.GET("/blogs/search", blogHandler.SearchBlogs) // Anyone can search blogs
+	api.GET("/search/suggest", blogHandler.SuggestSearch) // Type-ahead suggestions for the search box
 
 	// Protected routes (authentication required)
 	protected := api.Group("/blogs")
@@ -41,10 +96,30 @@ func BlogRoutes(r *gin.Engine, client *mongo.Client) {
 	// Routes that require authentication
 	protected.POST("", blogHandler.CreateBlog)    // Create blog (authenticated users only)
 	protected.GET("", blogHandler.GetBlogsByUser) // Get user's blogs (authenticated users only)
+	protected.GET("/trash", blogHandler.GetTrashedBlogs) // Get user's trashed blogs (authenticated users only)
+
+	// Routes that require authentication + owner or co-author
+	editorProtected := protected.Group("")
+	editorProtected.Use(middlewares.BlogEditorMiddleware(blogUseCase))
+	editorProtected.PUT("/:id", blogHandler.UpdateBlog)                                        // Update blog (owner and co-authors)
+	editorProtected.GET("/:id/revisions", blogHandler.GetBlogRevisions)                       // List revisions (owner and co-authors)
+	editorProtected.GET("/:id/revisions/diff", blogHandler.DiffBlogRevisions)                 // Diff two revisions (owner and co-authors)
+	editorProtected.GET("/:id/revisions/:version", blogHandler.GetBlogRevision)               // View a revision (owner and co-authors)
+	editorProtected.POST("/:id/revisions/:version/restore", blogHandler.RestoreBlogRevision) // Restore a revision (owner and co-authors)
 
 	// Routes that require authentication + ownership verification
 	ownershipProtected := protected.Group("")
 	ownershipProtected.Use(middlewares.BlogOwnershipMiddleware(blogUseCase))
-	ownershipProtected.PUT("/:id", blogHandler.UpdateBlog)    // Update blog (owner only)
-	ownershipProtected.DELETE("/:id", blogHandler.DeleteBlog) // Delete blog (owner only)
+	ownershipProtected.DELETE("/:id", blogHandler.DeleteBlog) // Move blog to the trash (owner only)
+	ownershipProtected.POST("/:id/restore", blogHandler.RestoreBlog) // Restore blog from the trash (owner only)
+	ownershipProtected.POST("/:id/publish", blogHandler.PublishBlog)     // Publish blog (owner only)
+	ownershipProtected.POST("/:id/unpublish", blogHandler.UnpublishBlog) // Move blog back to draft (owner only)
+	ownershipProtected.POST("/:id/archive", blogHandler.ArchiveBlog)     // Archive blog (owner only)
+	ownershipProtected.PUT("/:id/schedule", blogHandler.ScheduleBlog)          // Schedule publish/unpublish (owner only)
+	ownershipProtected.DELETE("/:id/schedule", blogHandler.CancelBlogSchedule) // Cancel schedule (owner only)
+	ownershipProtected.POST("/:id/coauthors", blogHandler.AddCoAuthor)              // Invite a co-author (owner only)
+	ownershipProtected.DELETE("/:id/coauthors/:userId", blogHandler.RemoveCoAuthor) // Remove a co-author (owner only)
+	ownershipProtected.PUT("/:id/visibility", blogHandler.SetBlogVisibility)         // Change who can read the blog (owner only)
+
+	return scheduler.NewBlogScheduler(blogUseCase)
 }
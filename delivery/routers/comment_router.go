@@ -4,6 +4,8 @@ import (
 	"github.com/Abenuterefe/a2sv-project/delivery/controllers"
 	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
 	"github.com/Abenuterefe/a2sv-project/infrastructure/middlewares"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/moderation"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/ranking"
 	"github.com/Abenuterefe/a2sv-project/repository"
 	"github.com/Abenuterefe/a2sv-project/usecase"
 	"github.com/gin-gonic/gin"
@@ -20,22 +22,45 @@ func CommentRoutes(r *gin.Engine, client *mongo.Client) {
 
 	// initialization of repo, usecase, and handler
 	commentRepo := repository.NewCommentRepositoryMongo(commentCollection)
-	commentUseCase := usecase.NewCommentUseCase(commentRepo)
+	blogRepo := repository.NewBlogRepositoryMongo(client.Database("g6_starter_projectDb").Collection("blogs")) // comment counts feed the popularity scores
+	reactionRepo := repository.NewCommentReactionRepositoryMongo(client.Database("g6_starter_projectDb").Collection("comment_reactions"))
+	moderationRepo := repository.NewCommentModerationRepositoryMongo(client.Database("g6_starter_projectDb").Collection("comment_moderation_log"))
+	settings := usecase.DefaultCommentSettings()
+	settings.Engagement = ranking.LoadEngagementWeights(settings.Engagement)
+	settings.MaxPinnedComments = moderation.LoadMaxPinnedComments(settings.MaxPinnedComments)
+	accessTokens := auth.NewBlogAccessTokenService()
+	commentUseCase := usecase.NewCommentUseCase(commentRepo, blogRepo, auth.NewCursorSigner(), accessTokens, moderationRepo, settings)
 	commentHandler := controllers.NewCommentHandler(commentUseCase)
+	reactionHandler := controllers.NewCommentReactionHandler(usecase.NewCommentReactionUseCase(reactionRepo, commentRepo, blogRepo, accessTokens))
 
 	// Group routes under /api/v1
 	api := r.Group("/api/v1")
 
-	// Public routes (no authentication required)
-	api.GET("/blogs/:id/comments", commentHandler.GetCommentsByBlog) // Anyone can view comments on a blog
-	api.GET("/comments/:id", commentHandler.GetCommentByID)         // Anyone can view a specific comment
+	// Public routes (no authentication required); signed-in moderators also see deleted and hidden comments as written
+	optional := middlewares.OptionalAuthMiddleware(jwtService)
+	api.GET("/blogs/:id/comments", optional, commentHandler.GetCommentsByBlog)       // Anyone can view comments on a blog
+	api.GET("/comments/:id", optional, commentHandler.GetCommentByID)                // Anyone can view a specific comment
+	api.GET("/comments/:id/replies", optional, commentHandler.GetReplies)            // Anyone can expand the replies to a comment
+	api.GET("/comments/:id/revisions", optional, commentHandler.GetCommentRevisions) // Edit history of a comment
 
 	// Protected routes (authentication required)
 	protected := api.Group("")
 	protected.Use(middlewares.AuthMiddleware(jwtService))
 
 	// Routes that require authentication
-	protected.POST("/blogs/:id/comments", commentHandler.CreateComment) // Create comment (authenticated users only)
-	protected.PUT("/comments/:id", commentHandler.UpdateComment)           // Update comment (owner only - checked in handler)
-	protected.DELETE("/comments/:id", commentHandler.DeleteComment)        // Delete comment (owner only - checked in handler)
+	protected.POST("/blogs/:id/comments", commentHandler.CreateComment)     // Create comment (authenticated users only)
+	protected.POST("/comments/:id/replies", commentHandler.CreateReply)     // Reply to a comment (authenticated users only)
+	protected.PUT("/comments/:id", commentHandler.UpdateComment)            // Update comment (owner only - checked in handler)
+	protected.DELETE("/comments/:id", commentHandler.DeleteComment)         // Delete comment (owner, blog author or admin - checked in usecase)
+	protected.POST("/comments/:id/like", reactionHandler.LikeComment)       // Like or un-like a comment
+	protected.POST("/comments/:id/dislike", reactionHandler.DislikeComment) // Dislike or un-dislike a comment
+
+	// Moderation (blog author or admin - checked in usecase)
+	protected.POST("/comments/:id/hide", commentHandler.HideComment)
+	protected.POST("/comments/:id/unhide", commentHandler.UnhideComment)
+	protected.POST("/comments/:id/pin", commentHandler.PinComment)
+	protected.POST("/comments/:id/unpin", commentHandler.UnpinComment)
+	protected.POST("/blogs/:id/comments/lock", commentHandler.LockComments)
+	protected.POST("/blogs/:id/comments/unlock", commentHandler.UnlockComments)
+	protected.GET("/blogs/:id/comments/moderation-log", commentHandler.GetModerationLog)
 }
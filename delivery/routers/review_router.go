@@ -0,0 +1,40 @@
+package routers
+
+import (
+	"github.com/Abenuterefe/a2sv-project/delivery/controllers"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/mail"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/middlewares"
+	"github.com/Abenuterefe/a2sv-project/repository"
+	"github.com/Abenuterefe/a2sv-project/usecase"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReviewRoutes initializes the editorial review routes
+func ReviewRoutes(r *gin.Engine, client *mongo.Client) {
+	db := client.Database("g6_starter_projectDb")
+
+	jwtService := auth.NewJWTService()
+	mailService := mail.NewMailService()
+
+	blogRepo := repository.NewBlogRepositoryMongo(db.Collection("blogs"))
+	reviewRepo := repository.NewBlogReviewRepositoryMongo(db.Collection("blog_reviews"))
+	userRepo := repository.NewUserRepository(db)
+	reviewUseCase := usecase.NewBlogReviewUseCase(blogRepo, reviewRepo, userRepo, mailService)
+	reviewHandler := controllers.NewBlogReviewHandler(reviewUseCase)
+
+	api := r.Group("/api/v1")
+	api.Use(middlewares.AuthMiddleware(jwtService))
+
+	// Author routes (ownership checked in the usecase)
+	api.POST("/blogs/:id/review", reviewHandler.SubmitForReview) // Submit a draft for review (owner only)
+	api.GET("/blogs/:id/reviews", reviewHandler.GetBlogReviews)  // Review history (authors and reviewers)
+
+	// Reviewer routes
+	reviewer := api.Group("/reviews")
+	reviewer.Use(middlewares.ReviewerOnlyMiddleware())
+	reviewer.GET("/queue", reviewHandler.GetReviewQueue)                // Blogs waiting for review
+	reviewer.POST("/:id/approve", reviewHandler.ApproveBlog)            // Approve a submission
+	reviewer.POST("/:id/request-changes", reviewHandler.RequestChanges) // Send a submission back with a comment
+}
@@ -0,0 +1,38 @@
+package routers
+
+import (
+	"github.com/Abenuterefe/a2sv-project/delivery/controllers"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/middlewares"
+	"github.com/Abenuterefe/a2sv-project/repository"
+	"github.com/Abenuterefe/a2sv-project/usecase"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SeriesRoutes initializes the blog series routes
+func SeriesRoutes(r *gin.Engine, client *mongo.Client) {
+	seriesCollection := client.Database("g6_starter_projectDb").Collection("series")
+	blogCollection := client.Database("g6_starter_projectDb").Collection("blogs")
+
+	jwtService := auth.NewJWTService()
+
+	seriesRepo := repository.NewSeriesRepositoryMongo(seriesCollection)
+	blogRepo := repository.NewBlogRepositoryMongo(blogCollection)
+	seriesUseCase := usecase.NewSeriesUseCase(seriesRepo, blogRepo)
+	seriesHandler := controllers.NewSeriesHandler(seriesUseCase)
+
+	api := r.Group("/api/v1")
+
+	// Public routes (no authentication required)
+	api.GET("/series/:id", seriesHandler.GetSeriesByID) // Anyone can view a series and its published parts
+
+	// Protected routes (authentication required, ownership checked in the usecase)
+	protected := api.Group("/series")
+	protected.Use(middlewares.AuthMiddleware(jwtService))
+	protected.POST("", seriesHandler.CreateSeries)                             // Create a series
+	protected.GET("", seriesHandler.GetMySeries)                               // List my series
+	protected.POST("/:id/blogs", seriesHandler.AddBlogToSeries)                // Append a blog (owner only)
+	protected.DELETE("/:id/blogs/:blogId", seriesHandler.RemoveBlogFromSeries) // Remove a blog (owner only)
+	protected.PUT("/:id/order", seriesHandler.ReorderSeries)                   // Reorder the parts (owner only)
+}
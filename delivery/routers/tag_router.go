@@ -0,0 +1,36 @@
+package routers
+
+import (
+	"github.com/Abenuterefe/a2sv-project/delivery/controllers"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/middlewares"
+	"github.com/Abenuterefe/a2sv-project/repository"
+	"github.com/Abenuterefe/a2sv-project/usecase"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TagRoutes initializes the tag taxonomy routes
+func TagRoutes(r *gin.Engine, client *mongo.Client) {
+	db := client.Database("g6_starter_projectDb")
+
+	jwtService := auth.NewJWTService()
+
+	tagRepo := repository.NewTagRepositoryMongo(db.Collection("tags"))
+	blogRepo := repository.NewBlogRepositoryMongo(db.Collection("blogs"))
+	tagUseCase := usecase.NewTagUseCase(tagRepo, blogRepo, relatedIndex)
+	tagHandler := controllers.NewTagHandler(tagUseCase)
+
+	api := r.Group("/api/v1")
+
+	// Public routes (no authentication required)
+	api.GET("/tags", tagHandler.ListTags) // Anyone can list tags with their post counts
+
+	// Admin routes (rewrite the tags of existing blogs)
+	admin := api.Group("/tags")
+	admin.Use(middlewares.AuthMiddleware(jwtService), middlewares.AdminOnlyMiddleware())
+	admin.POST("", tagHandler.CreateTag)              // Define a canonical tag with its synonyms
+	admin.PUT("/:slug", tagHandler.UpdateTag)         // Change the name, description or synonyms
+	admin.POST("/:slug/rename", tagHandler.RenameTag) // Change the slug of a tag
+	admin.POST("/merge", tagHandler.MergeTags)        // Fold tags into another one
+}
@@ -67,5 +67,6 @@ func UserRoutes(r *gin.Engine, mongoClient *mongo.Client) {
 	{
 		adminGroup.PUT("/promote/:id", authCtrl.PromoteUser)
 		adminGroup.PUT("/demote/:id", authCtrl.DemoteUser)
+		adminGroup.PUT("/reviewer/:id", authCtrl.MakeReviewer)
 	}
 }
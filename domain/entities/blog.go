@@ -6,16 +6,56 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+type BlogStatus string
+
+const (
+	BlogStatusDraft     BlogStatus = "draft"
+	BlogStatusScheduled BlogStatus = "scheduled"
+	BlogStatusPublished BlogStatus = "published"
+	BlogStatusArchived  BlogStatus = "archived"
+)
+
+type BlogVisibility string
+
+const (
+	BlogVisibilityPublic   BlogVisibility = "public"   // listed everywhere
+	BlogVisibilityUnlisted BlogVisibility = "unlisted" // reachable by link, never listed
+	BlogVisibilityPrivate  BlogVisibility = "private"  // only visible to its authors
+	BlogVisibilityPassword BlogVisibility = "password" // reachable by link with a passphrase
+)
+
 type Blog struct {
 	ID           primitive.ObjectID    `bson:"_id,omitempty"`
 	UserID       string    `bson:"user_id"`
+	CoAuthors    []string  `bson:"co_authors,omitempty"` // user IDs that may edit (but not delete) the blog
 	Title        string    `bson:"title"`
-	Content      string    `bson:"content"`
+	Slug         string    `bson:"slug"`                // unique, generated from the title
+	OldSlugs     []string  `bson:"old_slugs,omitempty"` // previous slugs, kept so old links keep working
+	Content      string    `bson:"content"`      // Markdown source
+	ContentHTML  string    `bson:"content_html"` // sanitized HTML rendered from Content
+	Excerpt      string    `bson:"excerpt"`      // plain-text preview of Content
+	WordCount    int       `bson:"word_count"`
+	ReadingTime  int       `bson:"reading_time"` // estimated reading time in minutes
 	Tags         []string  `bson:"tags"`
+	Status       BlogStatus `bson:"status"`                 // "draft", "scheduled", "published", "archived"
+	PublishedAt  *time.Time `bson:"published_at,omitempty"` // set the first time the blog is published
+	PublishAt    *time.Time `bson:"publish_at,omitempty"`   // scheduled publication time
+	UnpublishAt  *time.Time `bson:"unpublish_at,omitempty"` // scheduled expiry time
+	Visibility   BlogVisibility `bson:"visibility,omitempty"`      // "public" (default), "unlisted", "private", "password"
+	PasswordHash string         `bson:"password_hash,omitempty" json:"-"` // bcrypt hash of the passphrase of password-protected blogs
+	ReviewStatus ReviewStatus `bson:"review_status,omitempty"` // "submitted", "changes_requested", "approved", "approval_withdrawn"
+	ReviewRound  int        `bson:"review_round,omitempty"`  // number of times the blog was submitted
+	SubmittedAt  *time.Time `bson:"submitted_at,omitempty"`  // last submission for review
 	CreatedAt    time.Time `bson:"created_at"`
 	UpdatedAt    time.Time `bson:"updated_at"`
+	UpdatedBy    string    `bson:"updated_by,omitempty"` // user who made the last edit
+	DeletedAt    *time.Time `bson:"deleted_at,omitempty"` // set while the blog is in the trash
+	PurgeAt      *time.Time `bson:"purge_at,omitempty"`   // when a trashed blog is permanently removed
 	ViewCount    int       `bson:"view_count"`
 	LikeCount    int       `bson:"like_count"`
 	DislikeCount int       `bson:"dislike_count"`
+	CommentCount int       `bson:"comment_count"`
+	PopularityScore float64 `bson:"popularity_score"` // materialized score GET /blogs/popular is sorted by
+	CommentsLocked bool    `bson:"comments_locked,omitempty"` // new comments and replies are refused while set
+	Series       *SeriesNavigation `bson:"-"` // previous/next/table of contents when the blog is part of a series
 }
-
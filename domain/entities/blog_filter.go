@@ -4,20 +4,26 @@ import "time"
 
 // BlogFilter represents the filtering criteria for blog posts
 type BlogFilter struct {
-	Tags           []string   `json:"tags,omitempty" form:"tags"`
-	DateFrom       *time.Time `json:"date_from,omitempty" form:"date_from"`
-	DateTo         *time.Time `json:"date_to,omitempty" form:"date_to"`
-	PopularitySort string     `json:"popularity_sort,omitempty" form:"popularity_sort"` // "views", "likes", "engagement", "dislikes"
-	SortOrder      string     `json:"sort_order,omitempty" form:"sort_order"`           // "asc", "desc"
-	Limit          int        `json:"limit,omitempty" form:"limit"`
-	Skip           int        `json:"skip,omitempty" form:"skip"`
+	Tags           []string    `json:"tags,omitempty" form:"tags"`
+	DateFrom       *time.Time  `json:"date_from,omitempty" form:"date_from"`
+	DateTo         *time.Time  `json:"date_to,omitempty" form:"date_to"`
+	PopularitySort string      `json:"popularity_sort,omitempty" form:"popularity_sort"` // "views", "likes", "engagement", "dislikes"
+	SortOrder      string      `json:"sort_order,omitempty" form:"sort_order"`           // "asc", "desc"
+	Limit          int         `json:"limit,omitempty" form:"limit"`
+	Skip           int         `json:"skip,omitempty" form:"skip"`
+	Cursor         string      `json:"cursor,omitempty" form:"cursor"` // next_cursor or prev_cursor of a previous response; replaces skip
+	Sort           ListSort    `json:"-"`                              // set by the use case
+	Position       *PageCursor `json:"-"`                              // Cursor verified by the use case
 }
 
 // FilterResponse represents the response structure for filtered blogs
 type FilterResponse struct {
-	Blogs      []*Blog `json:"blogs"`
-	Count      int     `json:"count"`
-	TotalCount int64   `json:"total_count,omitempty"`
-	Page       int     `json:"page,omitempty"`
-	Limit      int     `json:"limit,omitempty"`
+	Blogs      []*ScoredBlog `json:"blogs"`
+	Count      int           `json:"count"`
+	TotalCount int64         `json:"total_count,omitempty"`
+	Page       int           `json:"page,omitempty"`
+	Limit      int           `json:"limit,omitempty"`
+	Facets     *SearchFacets `json:"facets,omitempty"` // counts over all matching blogs, not just this page
+	NextCursor string        `json:"next_cursor,omitempty"`
+	PrevCursor string        `json:"prev_cursor,omitempty"`
 }
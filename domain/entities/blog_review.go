@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReviewStatus is the editorial review state of a blog
+type ReviewStatus string
+
+const (
+	ReviewStatusSubmitted         ReviewStatus = "submitted"
+	ReviewStatusChangesRequested  ReviewStatus = "changes_requested"
+	ReviewStatusApproved          ReviewStatus = "approved"
+	ReviewStatusApprovalWithdrawn ReviewStatus = "approval_withdrawn" // edited after the approval; the blog has to be submitted again
+)
+
+// BlogReview records one step of a blog's review: a submission or a reviewer decision with its comment
+type BlogReview struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	BlogID    primitive.ObjectID `bson:"blog_id" json:"blog_id"`
+	Round     int                `bson:"round" json:"round"`     // submission number the step belongs to
+	UserID    string             `bson:"user_id" json:"user_id"` // author (submission) or reviewer (decision)
+	Status    ReviewStatus       `bson:"status" json:"status"`
+	Comment   string             `bson:"comment,omitempty" json:"comment,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BlogRevision is an immutable snapshot of a blog's editable content
+type BlogRevision struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	BlogID    primitive.ObjectID `bson:"blog_id" json:"blog_id"`
+	Version   int                `bson:"version" json:"version"` // 1 for the original content, incremented on every update
+	Title     string             `bson:"title" json:"title"`
+	Content   string             `bson:"content" json:"content"`
+	Tags      []string           `bson:"tags" json:"tags"`
+	EditorID  string             `bson:"editor_id" json:"editor_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// BlogRevisionDiff represents a unified diff between two revisions of a blog
+type BlogRevisionDiff struct {
+	BlogID string `json:"blog_id"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Diff   string `json:"diff"` // unified diff of title, tags and content; empty when identical
+}
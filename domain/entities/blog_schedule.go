@@ -0,0 +1,9 @@
+package entities
+
+import "time"
+
+// BlogSchedule represents a request to publish and/or unpublish a blog at a later time
+type BlogSchedule struct {
+	PublishAt   *time.Time `json:"publish_at,omitempty"`   // when the blog goes live
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"` // when the blog is archived again
+}
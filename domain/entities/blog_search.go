@@ -2,10 +2,17 @@ package entities
 
 // BlogSearch represents the search criteria for blog posts
 type BlogSearch struct {
-	Title  string `json:"title,omitempty" form:"title"`
-	Author string `json:"author,omitempty" form:"author"`
-	Limit  int    `json:"limit,omitempty" form:"limit"`
-	Skip   int    `json:"skip,omitempty" form:"skip"`
+	Query          string       `json:"q,omitempty" form:"q"` // query language, e.g. `tag:go after:2025-01-01 "error handling"`
+	Parsed         *SearchQuery `json:"parsed,omitempty"`     // Query parsed by the use case
+	Title          string       `json:"title,omitempty" form:"title"`
+	Author         string       `json:"author,omitempty" form:"author"`
+	PopularitySort string       `json:"popularity_sort,omitempty" form:"popularity_sort"` // "views", "likes", "engagement", "dislikes"; relevance or newest first when empty
+	SortOrder      string       `json:"sort_order,omitempty" form:"sort_order"`           // "asc", "desc"
+	Limit          int          `json:"limit,omitempty" form:"limit"`
+	Skip           int          `json:"skip,omitempty" form:"skip"`
+	Cursor         string       `json:"cursor,omitempty" form:"cursor"` // next_cursor or prev_cursor of a previous response; replaces skip
+	Sort           ListSort     `json:"-"`                              // set by the use case
+	Position       *PageCursor  `json:"-"`                              // Cursor verified by the use case
 }
 
 // SearchResponse represents the response structure for blog search results
@@ -14,10 +21,23 @@ type SearchResponse struct {
 	Count      int               `json:"count"`
 	TotalCount int64             `json:"total_count,omitempty"`
 	Query      *BlogSearch       `json:"query,omitempty"`
+	Facets     *SearchFacets     `json:"facets,omitempty"` // counts over all matching blogs, not just this page
+	NextCursor string            `json:"next_cursor,omitempty"`
+	PrevCursor string            `json:"prev_cursor,omitempty"`
 }
 
 // BlogWithAuthor represents a blog with author information
 type BlogWithAuthor struct {
-	Blog       `bson:",inline"`
-	AuthorName string `json:"author_name" bson:"author_name"`
+	Blog            `bson:",inline"`
+	AuthorName      string            `json:"author_name" bson:"author_name"`
+	Authors         []string          `json:"authors" bson:"authors"`                                       // usernames of the owner followed by the co-authors
+	Score           float64           `json:"score,omitempty" bson:"score,omitempty"`                       // text relevance, only set for full-text searches
+	EngagementScore *float64          `json:"engagement_score,omitempty" bson:"engagement_score,omitempty"` // only set when sorting by engagement
+	Highlights      *SearchHighlights `json:"highlights,omitempty" bson:"-"`
+}
+
+// SearchHighlights shows where a full-text query matched; matches are wrapped in <mark> and the rest is HTML-escaped
+type SearchHighlights struct {
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"` // a short window of the content around the first match
 }
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// BlogVisibilityRequest changes who can read a blog; Password is required for "password" visibility
+type BlogVisibilityRequest struct {
+	Visibility BlogVisibility `json:"visibility"`
+	Password   string         `json:"password,omitempty"`
+}
+
+// BlogAccess identifies who is reading a blog: the signed-in user (if any) and
+// an access token obtained by unlocking a password-protected blog (if any)
+type BlogAccess struct {
+	UserID      string
+	AccessToken string
+}
+
+// BlogAccessToken is returned when the passphrase of a password-protected blog is accepted
+type BlogAccessToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
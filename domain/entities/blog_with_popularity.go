@@ -9,13 +9,18 @@ import (
 type BlogWithPopularity struct {
 	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 	Title           string             `bson:"title" json:"title"`
+	Slug            string             `bson:"slug" json:"slug,omitempty"`
 	Content         string             `bson:"content" json:"content"`
+	ContentHTML     string             `bson:"content_html" json:"content_html"`
+	Excerpt         string             `bson:"excerpt" json:"excerpt"`
+	WordCount       int                `bson:"word_count" json:"word_count"`
+	ReadingTime     int                `bson:"reading_time" json:"reading_time"`
 	UserID          string             `bson:"user_id" json:"user_id"`
 	LikeCount       int                `bson:"like_count" json:"like_count"`
 	DislikeCount    int                `bson:"dislike_count" json:"dislike_count"`
 	ViewCount       int                `bson:"view_count" json:"view_count"`
-	CommentCount    int                `json:"comment_count"`
-	PopularityScore float64            `json:"popularity_score"`
+	CommentCount    int                `bson:"comment_count" json:"comment_count"`
+	PopularityScore float64            `bson:"popularity_score" json:"popularity_score"`
 	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
 }
@@ -7,10 +7,23 @@ import (
 )
 
 type Comment struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	BlogID    primitive.ObjectID `bson:"blog_id" json:"blog_id"`
-	UserID    string             `bson:"user_id" json:"user_id"`
-	Content   string             `bson:"content" json:"content"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	BlogID        primitive.ObjectID  `bson:"blog_id" json:"blog_id"`
+	ParentID      *primitive.ObjectID `bson:"parent_id,omitempty" json:"parent_id,omitempty"` // nil for top-level comments
+	Depth         int                 `bson:"depth" json:"depth"`                             // 0 for top-level comments
+	ReplyCount    int                 `bson:"reply_count" json:"reply_count"`                 // direct replies only
+	LikeCount     int                 `bson:"like_count" json:"like_count"`
+	DislikeCount  int                 `bson:"dislike_count" json:"dislike_count"`
+	ReactionScore int                 `bson:"reaction_score" json:"reaction_score"`             // likes minus dislikes, for the top sort
+	Hidden        bool                `bson:"hidden,omitempty" json:"hidden,omitempty"`         // hidden by a moderator; only they and the author see the content
+	PinnedAt      *time.Time          `bson:"pinned_at,omitempty" json:"pinned_at,omitempty"`   // set while pinned to the top of the blog's comments
+	Deleted       bool                `bson:"deleted,omitempty" json:"deleted,omitempty"`       // tombstone kept in the thread; only moderators see what it said
+	DeletedAt     *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"` // when the comment was deleted
+	DeletedBy     string              `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"` // its author or the moderator who deleted it
+	Edited        bool                `bson:"edited,omitempty" json:"edited"`                   // the content changed after posting; see the edit history
+	EditedAt      *time.Time          `bson:"edited_at,omitempty" json:"edited_at,omitempty"`   // last change of the content
+	UserID        string              `bson:"user_id" json:"user_id"`
+	Content       string              `bson:"content" json:"content"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time           `bson:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Moderation actions recorded in the moderation log of a blog
+const (
+	ModerationHide   = "hide"
+	ModerationUnhide = "unhide"
+	ModerationDelete = "delete"
+	ModerationPin    = "pin"
+	ModerationUnpin  = "unpin"
+	ModerationLock   = "lock"
+	ModerationUnlock = "unlock"
+)
+
+// CommentActor is the signed-in user (if any) reading or acting on comments
+type CommentActor struct {
+	UserID      string
+	Role        string
+	AccessToken string // unlocks the comments of a password-protected blog, as for reading the blog
+}
+
+// ModerationAction is one entry of the moderation log of a blog: who did what to which comment
+type ModerationAction struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	BlogID        primitive.ObjectID  `bson:"blog_id" json:"blog_id"`
+	CommentID     *primitive.ObjectID `bson:"comment_id,omitempty" json:"comment_id,omitempty"` // nil for lock and unlock
+	Action        string              `bson:"action" json:"action"`
+	ModeratorID   string              `bson:"moderator_id" json:"moderator_id"`
+	ModeratorRole string              `bson:"moderator_role" json:"moderator_role"` // "owner" or "admin"
+	Reason        string              `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// ModerationRequest is the optional body of a moderation action
+type ModerationRequest struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CommentReaction is the reaction of one user to a comment; a user has at most one per comment
+type CommentReaction struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	CommentID primitive.ObjectID `bson:"comment_id" json:"comment_id"`
+	BlogID    primitive.ObjectID `bson:"blog_id" json:"blog_id"` // lets purged blogs take their reactions along
+	UserID    string             `bson:"user_id" json:"user_id"`
+	Type      string             `bson:"type" json:"type"` // "like", "dislike"
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
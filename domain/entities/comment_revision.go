@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CommentRevision is an immutable snapshot of a comment's content
+type CommentRevision struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	CommentID primitive.ObjectID `bson:"comment_id" json:"comment_id"`
+	BlogID    primitive.ObjectID `bson:"blog_id" json:"blog_id"`
+	Version   int                `bson:"version" json:"version"` // 1 for the content as posted, incremented on every edit
+	Content   string             `bson:"content" json:"content"`
+	EditorID  string             `bson:"editor_id" json:"editor_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,54 @@
+package entities
+
+import "time"
+
+// EngagementWeights weigh the interactions of a blog in its engagement score:
+// likes*Likes + dislikes*Dislikes + views*Views + comments*Comments
+type EngagementWeights struct {
+	Likes    float64 `json:"likes"`
+	Dislikes float64 `json:"dislikes"`
+	Views    float64 `json:"views"`
+	Comments float64 `json:"comments"`
+}
+
+// ScoredBlog is a filtered blog with the engagement score it was ranked by (only set when sorting by engagement)
+type ScoredBlog struct {
+	Blog            `bson:",inline"`
+	EngagementScore *float64 `json:"engagement_score,omitempty" bson:"engagement_score,omitempty"`
+}
+
+// CounterChange is a change to the interaction counters of a blog
+type CounterChange struct {
+	Likes    int
+	Dislikes int
+	Views    int
+	Comments int
+}
+
+// Score is the change the counter change makes to an engagement score weighted by w
+func (w EngagementWeights) Score(change CounterChange) float64 {
+	return float64(change.Likes)*w.Likes + float64(change.Dislikes)*w.Dislikes + float64(change.Views)*w.Views + float64(change.Comments)*w.Comments
+}
+
+// RecencyBoost is the bonus a blog created less than Within ago gets in its popularity score
+type RecencyBoost struct {
+	Within time.Duration
+	Boost  float64
+}
+
+// PopularityScoring is how the materialized popularity score of a blog is computed:
+// its engagement score plus the boost of the first recency tier its age falls in
+type PopularityScoring struct {
+	Weights EngagementWeights
+	Recency []RecencyBoost // ordered from the shortest to the longest window
+}
+
+// RecencyBoost returns the boost of a blog of the given age
+func (s PopularityScoring) RecencyBoost(age time.Duration) float64 {
+	for _, tier := range s.Recency {
+		if age <= tier.Within {
+			return tier.Boost
+		}
+	}
+	return 0
+}
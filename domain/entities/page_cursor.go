@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fields lists can be sorted by; _id always breaks ties in the same direction
+const (
+	SortByCreatedAt  = "created_at"
+	SortByViews      = "view_count"
+	SortByLikes      = "like_count"
+	SortByDislikes   = "dislike_count"
+	SortByScore      = "score"            // full-text relevance
+	SortByEngagement = "engagement_score" // weighted interactions, computed per query
+	SortByReactions  = "reaction_score"   // comment likes minus dislikes
+)
+
+// ListSort is the order of a keyset-paginated list
+type ListSort struct {
+	Field   string
+	Desc    bool
+	Weights EngagementWeights // engagement sorts only
+}
+
+// Key identifies the sort inside cursors, e.g. "created_at:desc"
+func (s ListSort) Key() string {
+	if s.Desc {
+		return s.Field + ":desc"
+	}
+	return s.Field + ":asc"
+}
+
+// ByTime reports whether the sort value is a time (otherwise it is a number)
+func (s ListSort) ByTime() bool {
+	return s.Field == SortByCreatedAt
+}
+
+// PageCursor is a position in a sorted list: the sort value and _id of the item a page starts after
+// (or, for the previous page, ends before). Clients only see it as an opaque signed token.
+type PageCursor struct {
+	Sort   string             `json:"s"` // ListSort.Key of the list the cursor was issued for
+	Time   time.Time          `json:"t"`
+	Number float64            `json:"n"`
+	ID     primitive.ObjectID `json:"id"`
+	Before bool               `json:"b,omitempty"`
+}
+
+// PageQuery selects one page of a list: by cursor when Position is set, otherwise by offset
+type PageQuery struct {
+	Limit    int
+	Skip     int
+	Sort     ListSort
+	Position *PageCursor
+}
+
+// BlogPage is one page of a user's blogs
+type BlogPage struct {
+	Blogs      []*Blog `json:"blogs"`
+	Count      int     `json:"count"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	PrevCursor string  `json:"prev_cursor,omitempty"`
+}
+
+// CommentPage is one page of the comments of a blog or the replies to a comment
+type CommentPage struct {
+	Comments   []*Comment `json:"comments"`
+	Count      int        `json:"count"`
+	TotalCount int64      `json:"total_count"`      // comments across all pages
+	Pinned     []*Comment `json:"pinned,omitempty"` // pinned comments, on the first page of a blog's comments only
+	NextCursor string     `json:"next_cursor,omitempty"`
+	PrevCursor string     `json:"prev_cursor,omitempty"`
+}
@@ -0,0 +1,15 @@
+package entities
+
+// RelatedMatch is a blog found similar to another one by the related blogs index
+type RelatedMatch struct {
+	BlogID     string
+	Score      float64
+	SharedTags []string
+}
+
+// RelatedBlog is a blog recommended after reading another one, with how closely it matches
+type RelatedBlog struct {
+	Blog
+	RelatedScore float64  `json:"related_score"`
+	SharedTags   []string `json:"shared_tags"`
+}
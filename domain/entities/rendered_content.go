@@ -0,0 +1,7 @@
+package entities
+
+// RenderedContent is the output of rendering a Markdown document
+type RenderedContent struct {
+	HTML      string // sanitized HTML, safe to embed in a page
+	PlainText string // text content without markup, used for excerpts and word counts
+}
@@ -0,0 +1,15 @@
+package entities
+
+// SearchFacets counts the blogs matching a search or filter, so clients can offer drill-down options
+type SearchFacets struct {
+	Tags    []FacetCount `json:"tags" bson:"tags"`       // most used tags first
+	Authors []FacetCount `json:"authors" bson:"authors"` // most prolific authors first (owners and co-authors)
+	Months  []FacetCount `json:"months" bson:"months"`   // posts per month ("2025-08"), newest first
+}
+
+// FacetCount is the number of matching blogs for one facet value
+type FacetCount struct {
+	Value string `json:"value" bson:"_id"`
+	ID    string `json:"id,omitempty" bson:"id,omitempty"` // user ID of author facets
+	Count int64  `json:"count" bson:"count"`
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// SearchField is the field a search clause applies to
+type SearchField string
+
+const (
+	SearchFieldText     SearchField = "text"     // free text: a word or a "quoted phrase"
+	SearchFieldTag      SearchField = "tag"      // tag:go
+	SearchFieldAuthor   SearchField = "author"   // author:abel (owner or co-author username)
+	SearchFieldTitle    SearchField = "title"    // title:"road map"
+	SearchFieldAfter    SearchField = "after"    // after:2025-01-01 (created on or after)
+	SearchFieldBefore   SearchField = "before"   // before:2025-02-01 (created before)
+	SearchFieldLikes    SearchField = "likes"    // likes:>10
+	SearchFieldDislikes SearchField = "dislikes" // dislikes:<5
+	SearchFieldViews    SearchField = "views"    // views:>=100
+)
+
+// SearchOperator compares numeric fields
+type SearchOperator string
+
+const (
+	SearchOperatorEqual        SearchOperator = "="
+	SearchOperatorGreater      SearchOperator = ">"
+	SearchOperatorGreaterEqual SearchOperator = ">="
+	SearchOperatorLess         SearchOperator = "<"
+	SearchOperatorLessEqual    SearchOperator = "<="
+)
+
+// SearchClause is one condition of a parsed search query; which value is set depends on Field
+type SearchClause struct {
+	Field    SearchField    `json:"field"`
+	Text     string         `json:"text,omitempty"`     // text, tag, author and title clauses
+	Phrase   bool           `json:"phrase,omitempty"`   // quoted text that must match as a whole
+	Operator SearchOperator `json:"operator,omitempty"` // likes, dislikes and views clauses
+	Number   int            `json:"number,omitempty"`
+	Date     *time.Time     `json:"date,omitempty"` // after and before clauses
+}
+
+// SearchQuery is the parsed form of a search query string.
+// All clauses must match, except plain words, of which any may match (ranked by relevance).
+type SearchQuery struct {
+	Clauses []SearchClause `json:"clauses"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+// Suggestion is one type-ahead match for the search box
+type Suggestion struct {
+	Value      string  `json:"value" bson:"value"`
+	ID         string  `json:"id,omitempty" bson:"id,omitempty"`     // blog ID of titles, user ID of authors
+	Slug       string  `json:"slug,omitempty" bson:"slug,omitempty"` // slug of titles
+	Popularity float64 `json:"popularity" bson:"popularity"`
+}
+
+// SearchSuggestions are the titles, tags and authors starting with the typed prefix, most popular first
+type SearchSuggestions struct {
+	Query   string       `json:"query"`
+	Titles  []Suggestion `json:"titles"`
+	Tags    []Suggestion `json:"tags"`
+	Authors []Suggestion `json:"authors"`
+}
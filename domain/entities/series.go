@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Series groups blogs of one author into ordered parts (e.g. a multi-part tutorial)
+type Series struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID      string               `bson:"user_id" json:"user_id"`
+	Title       string               `bson:"title" json:"title"`
+	Description string               `bson:"description" json:"description"`
+	BlogIDs     []primitive.ObjectID `bson:"blog_ids" json:"blog_ids"` // parts in reading order
+	Parts       []SeriesPart         `bson:"-" json:"parts,omitempty"` // published parts, filled when the series is fetched
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// SeriesPart is one published blog of a series
+type SeriesPart struct {
+	BlogID   primitive.ObjectID `json:"blog_id"`
+	Title    string             `json:"title"`
+	Slug     string             `json:"slug"`
+	Position int                `json:"position"` // 1-based
+}
+
+// SeriesNavigation is attached to a blog that belongs to a series
+type SeriesNavigation struct {
+	SeriesID primitive.ObjectID `json:"series_id"`
+	Title    string             `json:"title"`
+	Position int                `json:"position"` // 1-based position of the current blog
+	Total    int                `json:"total"`
+	Previous *SeriesPart        `json:"previous,omitempty"`
+	Next     *SeriesPart        `json:"next,omitempty"`
+	Parts    []SeriesPart       `json:"parts"` // table of contents
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tag is a canonical blog tag. Blogs store the canonical slug; synonyms ("golang", "go-lang")
+// are rewritten to it when blogs are created or updated.
+type Tag struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Slug        string             `bson:"slug" json:"slug"` // unique, lower-case words joined by dashes
+	Name        string             `bson:"name" json:"name"` // display name, e.g. "Go"
+	Description string             `bson:"description" json:"description"`
+	Synonyms    []string           `bson:"synonyms" json:"synonyms"` // normalized slugs that mean this tag
+	PostCount   int64              `bson:"-" json:"post_count"`      // published, public blogs with the tag, filled when listing
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at,omitempty"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at,omitempty"`
+}
+
+// TagUpdate changes the name, description and synonyms of a tag; nil fields are left unchanged
+type TagUpdate struct {
+	Name        *string   `json:"name"`
+	Description *string   `json:"description"`
+	Synonyms    *[]string `json:"synonyms"`
+}
+
+// TagMerge folds the source tags into the target tag
+type TagMerge struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
+
+// TagChange is the result of renaming or merging tags
+type TagChange struct {
+	Tag          *Tag  `json:"tag"`
+	BlogsUpdated int64 `json:"blogs_updated"` // blogs whose tags were rewritten
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// TrendingQuery selects the blogs trending within a rolling window.
+// Each like, dislike, view and comment made since Since counts with its engagement weight,
+// divided by (hours since the interaction + 2)^Gravity so recent activity dominates.
+type TrendingQuery struct {
+	Since   time.Time
+	Now     time.Time
+	Tag     string // only blogs with this tag when set
+	Limit   int
+	Weights EngagementWeights
+	Gravity float64
+}
+
+// TrendingBlog is a blog with its trending score and the number of interactions within the window
+type TrendingBlog struct {
+	Blog          `bson:",inline"`
+	TrendingScore float64 `json:"trending_score" bson:"trending_score"`
+	Interactions  int     `json:"interactions" bson:"interactions"`
+}
@@ -9,8 +9,9 @@ import (
 type Role string
 
 const (
-	RoleUser  Role = "user"
-	RoleAdmin Role = "admin"
+	RoleUser     Role = "user"
+	RoleAdmin    Role = "admin"
+	RoleReviewer Role = "reviewer" // reviews blogs submitted by regular users
 )
 
 type User struct {
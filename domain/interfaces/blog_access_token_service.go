@@ -0,0 +1,9 @@
+package interfaces
+
+import "time"
+
+// BlogAccessTokenService issues short-lived tokens that unlock a single password-protected blog
+type BlogAccessTokenService interface {
+	CreateBlogAccessToken(blogID string) (string, time.Time, error)
+	VerifyBlogAccessToken(tokenStr string, blogID string) error
+}
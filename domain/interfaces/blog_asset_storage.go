@@ -0,0 +1,7 @@
+package interfaces
+
+// BlogAssetStorage manages files uploaded for a blog
+type BlogAssetStorage interface {
+	// DeleteBlogAssets removes every uploaded file that belongs to the blog
+	DeleteBlogAssets(blogID string) error
+}
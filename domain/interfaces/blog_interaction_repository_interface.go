@@ -11,4 +11,7 @@ type BlogInteractionRepositoryInterface interface {
 	RemoveInteraction(ctx context.Context, blogID string, userID string, interactionType string) error
 	HasInteraction(ctx context.Context, blogID string, userID string, interactionType string) (bool, error)
 	HasRecentView(ctx context.Context, blogID string, userID string, ipAddress string, userAgent string) (bool, error)
+	DeleteInteractionsByBlogID(ctx context.Context, blogID string) error
+	// GetTrendingBlogs ranks the listed blogs by their decayed engagement within the query window
+	GetTrendingBlogs(ctx context.Context, query *entities.TrendingQuery) ([]*entities.TrendingBlog, error)
 }
@@ -3,24 +3,91 @@ package interfaces
 import (
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ErrSlugTaken is returned by CreateBlog and UpdateBlog when another blog got the same slug first
+var ErrSlugTaken = errors.New("slug is already taken")
+
 // BlogRepositoryInterface defines the contract for blog repository operations
 type BlogRepositoryInterface interface {
 	CreateBlog(ctx context.Context, blog *entities.Blog) error
-	GetBlogsByUserID(ctx context.Context, userID string, page int64, limit int64) ([]*entities.Blog, error)
+	// Get paginated blogs of a user; publishedOnly hides drafts and archived blogs
+	GetBlogsByUserID(ctx context.Context, userID string, query *entities.PageQuery, publishedOnly bool) ([]*entities.Blog, error)
 	// Get a single blog by its ID
 	GetBlogByID(ctx context.Context, id string) (*entities.Blog, error)
-	// Update an existing blog
+	// Get several blogs by their IDs (missing blogs are skipped, order is not preserved)
+	GetBlogsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Blog, error)
+	// Count the listed blogs per tag, most used first
+	GetTagCounts(ctx context.Context) ([]entities.FacetCount, error)
+	// Rewrite the tags of every blog using one of from to the tag to; returns the number of blogs changed
+	ReplaceTags(ctx context.Context, from []string, to string) (int64, error)
+	// Get every tag used by any blog (drafts and trashed blogs included)
+	GetDistinctTags(ctx context.Context) ([]string, error)
+	// Get the title, content and tags of every listed blog (for the related blogs index)
+	GetListedBlogs(ctx context.Context) ([]*entities.Blog, error)
+	// Get a single blog by its current or a previous slug
+	GetBlogBySlug(ctx context.Context, slug string) (*entities.Blog, error)
+	// Check whether a slug is already taken (current or previous slugs)
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	// Update an existing blog (also stores the new content as a revision)
 	UpdateBlog(ctx context.Context, blog *entities.Blog) error
-	// Delete a blog by its ID
+	// List the revisions of a blog, newest first
+	GetBlogRevisions(ctx context.Context, blogID string) ([]*entities.BlogRevision, error)
+	// Get a single revision of a blog by version number
+	GetBlogRevision(ctx context.Context, blogID string, version int) (*entities.BlogRevision, error)
+	// Change the lifecycle status of a blog (publishedAt is only written when non-nil)
+	UpdateBlogStatus(ctx context.Context, blogID string, status entities.BlogStatus, publishedAt *time.Time) error
+	// Store the publish/unpublish schedule of a blog (nil times are cleared)
+	UpdateBlogSchedule(ctx context.Context, blogID string, status entities.BlogStatus, publishAt *time.Time, unpublishAt *time.Time) error
+	// Publish scheduled blogs whose publish time has passed
+	PublishDueBlogs(ctx context.Context, now time.Time) (int64, error)
+	// Archive blogs whose unpublish time has passed
+	UnpublishExpiredBlogs(ctx context.Context, now time.Time) (int64, error)
+	// Set the review state of a blog; submittedAt is only stored when not nil
+	UpdateBlogReviewStatus(ctx context.Context, blogID string, status entities.ReviewStatus, round int, submittedAt *time.Time) error
+	// Get blogs in a review state, oldest submission first
+	GetBlogsByReviewStatus(ctx context.Context, status entities.ReviewStatus, page int64, limit int64) ([]*entities.Blog, error)
+	// Replace the co-author list of a blog
+	UpdateBlogCoAuthors(ctx context.Context, blogID string, coAuthors []string) error
+	// Change the visibility (and passphrase hash) of a blog
+	UpdateBlogVisibility(ctx context.Context, blogID string, visibility entities.BlogVisibility, passwordHash string) error
+	// Move a blog to the trash until purgeAt
+	TrashBlog(ctx context.Context, id string, deletedAt time.Time, purgeAt time.Time) error
+	// Take a blog out of the trash
+	RestoreBlog(ctx context.Context, id string) error
+	// Get the trashed blogs of a user
+	GetTrashedBlogsByUserID(ctx context.Context, userID string) ([]*entities.Blog, error)
+	// Get trashed blogs whose retention period has ended
+	GetExpiredTrashedBlogs(ctx context.Context, now time.Time) ([]*entities.Blog, error)
+	// Permanently delete a blog and its revisions by its ID
 	DeleteBlog(ctx context.Context, id string) error
-	// Update blog interaction counters (likes, dislikes, views)
-	UpdateBlogCounters(ctx context.Context, blogID string, likeChange int, dislikeChange int, viewChange int) error
-	// Get all blogs for popularity calculation
-	GetAllBlogs(ctx context.Context) ([]*entities.Blog, error)
+	// Update blog interaction counters (likes, dislikes, views, comments) and move the popularity score by scoreChange
+	UpdateBlogCounters(ctx context.Context, blogID string, change entities.CounterChange, scoreChange float64) error
+	// SetCommentsLocked locks or unlocks the comments of a blog
+	SetCommentsLocked(ctx context.Context, blogID string, locked bool) error
+	// ReservePinnedSlot counts one more pinned comment on a blog unless it already has limit of them;
+	// it reports whether the slot was reserved
+	ReservePinnedSlot(ctx context.Context, blogID string, limit int) (bool, error)
+	// ReleasePinnedSlot counts one pinned comment less on a blog
+	ReleasePinnedSlot(ctx context.Context, blogID string) error
+	// Recompute the comment counts and popularity scores of all blogs as of now
+	RecomputePopularityScores(ctx context.Context, scoring entities.PopularityScoring, now time.Time) error
+	// Get the listed blogs with the highest popularity scores
+	GetPopularBlogs(ctx context.Context, limit int64) ([]*entities.BlogWithPopularity, error)
 	// Filter blogs based on criteria
-	FilterBlogs(ctx context.Context, filter *entities.BlogFilter) ([]*entities.Blog, int64, error)
+	FilterBlogs(ctx context.Context, filter *entities.BlogFilter) ([]*entities.ScoredBlog, int64, error)
 	// Search blogs based on title and/or author
 	SearchBlogs(ctx context.Context, search *entities.BlogSearch) ([]*entities.BlogWithAuthor, int64, error)
+	// Count the blogs matching a filter per tag, author and month
+	GetFilterFacets(ctx context.Context, filter *entities.BlogFilter) (*entities.SearchFacets, error)
+	// Count the blogs matching a search per tag, author and month
+	GetSearchFacets(ctx context.Context, search *entities.BlogSearch) (*entities.SearchFacets, error)
+	// Type-ahead: titles, tags and author usernames starting with a prefix, most popular first
+	SuggestTitles(ctx context.Context, prefix string, limit int) ([]entities.Suggestion, error)
+	SuggestTags(ctx context.Context, prefix string, limit int) ([]entities.Suggestion, error)
+	SuggestAuthors(ctx context.Context, prefix string, limit int) ([]entities.Suggestion, error)
 }
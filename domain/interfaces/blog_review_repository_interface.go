@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// BlogReviewRepositoryInterface defines the contract for the review history of blogs
+type BlogReviewRepositoryInterface interface {
+	CreateReview(ctx context.Context, review *entities.BlogReview) error
+	// Get the review history of a blog, oldest first
+	GetReviewsByBlogID(ctx context.Context, blogID string) ([]*entities.BlogReview, error)
+	// Permanently delete the review history of a blog
+	DeleteReviewsByBlogID(ctx context.Context, blogID string) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// BlogReviewUseCaseInterface defines the editorial review workflow
+type BlogReviewUseCaseInterface interface {
+	// Submit a draft for review (owner only)
+	SubmitForReview(ctx context.Context, blogID string, userID string) (*entities.Blog, error)
+	// Ask the author for changes; a comment is required (reviewer)
+	RequestChanges(ctx context.Context, blogID string, reviewerID string, comment string) (*entities.Blog, error)
+	// Approve a submission so the author can publish it (reviewer)
+	ApproveBlog(ctx context.Context, blogID string, reviewerID string, comment string) (*entities.Blog, error)
+	// Get blogs waiting for review, oldest submission first
+	GetReviewQueue(ctx context.Context, page int64, limit int64) ([]*entities.Blog, error)
+	// Get the review history of a blog (its authors and reviewers only)
+	GetBlogReviews(ctx context.Context, blogID string, userID string) ([]*entities.BlogReview, error)
+}
@@ -4,23 +4,71 @@ package interfaces
 import (
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	"context"
+	"time"
 )
 
 // BlogUseCaseInterface defines the contract for blog use case operations
 // This interface should be used in the usecase implementation
 type BlogUseCaseInterface interface {
 	CreateBlog(ctx context.Context, blog *entities.Blog, userID string) error
-	GetBlogsByUserID(ctx context.Context, userID string, page int64, limit int64) ([]*entities.Blog, error)
-	// Get a single blog by its ID
-	GetBlogByID(ctx context.Context, id string) (*entities.Blog, error)
-	// Update an existing blog (fields must include ID)
-	UpdateBlog(ctx context.Context, blog *entities.Blog) error
-	// Delete a blog by its ID
+	// Get paginated blogs of a user; drafts are only included when viewerID is the owner
+	GetBlogsByUserID(ctx context.Context, userID string, viewerID string, page int64, limit int64, cursor string) (*entities.BlogPage, error)
+	// Get a single published blog by its ID (public read path); access decides whether private and password-protected blogs can be read
+	GetBlogByID(ctx context.Context, id string, access *entities.BlogAccess) (*entities.Blog, error)
+	// Get a single published blog by its current or a previous slug
+	GetBlogBySlug(ctx context.Context, slug string, access *entities.BlogAccess) (*entities.Blog, error)
+	// Get a single blog by its ID regardless of status (callers must check ownership)
+	GetBlogByIDForOwner(ctx context.Context, id string) (*entities.Blog, error)
+	// Update an existing blog (fields must include ID); editorID is recorded in the revision history
+	UpdateBlog(ctx context.Context, blog *entities.Blog, editorID string) error
+	// List the revisions of a blog, newest first
+	GetBlogRevisions(ctx context.Context, blogID string) ([]*entities.BlogRevision, error)
+	// Get a single revision of a blog
+	GetBlogRevision(ctx context.Context, blogID string, version int) (*entities.BlogRevision, error)
+	// Unified diff between two revisions of a blog
+	DiffBlogRevisions(ctx context.Context, blogID string, from int, to int) (*entities.BlogRevisionDiff, error)
+	// Restore an old revision as the current content (recorded as a new revision)
+	RestoreBlogRevision(ctx context.Context, blogID string, version int, editorID string) (*entities.Blog, error)
+	// Publish a draft or archived blog
+	PublishBlog(ctx context.Context, id string) (*entities.Blog, error)
+	// Move a published blog back to draft
+	UnpublishBlog(ctx context.Context, id string) (*entities.Blog, error)
+	// Archive a blog so it is no longer publicly visible
+	ArchiveBlog(ctx context.Context, id string) (*entities.Blog, error)
+	// Schedule a blog to be published and/or unpublished later
+	ScheduleBlog(ctx context.Context, id string, schedule *entities.BlogSchedule) (*entities.Blog, error)
+	// Remove any pending publish/unpublish schedule
+	CancelBlogSchedule(ctx context.Context, id string) (*entities.Blog, error)
+	// Apply due schedules; returns how many blogs were published and unpublished
+	ApplyBlogSchedules(ctx context.Context, now time.Time) (int64, int64, error)
+	// Add a co-author, identified by user ID or email
+	AddCoAuthor(ctx context.Context, blogID string, identifier string) (*entities.Blog, error)
+	// Remove a co-author by user ID
+	RemoveCoAuthor(ctx context.Context, blogID string, userID string) (*entities.Blog, error)
+	// Make a blog public, unlisted, private or password-protected
+	SetBlogVisibility(ctx context.Context, id string, request *entities.BlogVisibilityRequest) (*entities.Blog, error)
+	// Exchange the passphrase of a password-protected blog for a short-lived access token
+	UnlockBlog(ctx context.Context, id string, password string) (*entities.BlogAccessToken, error)
+	// Move a blog to the trash
 	DeleteBlog(ctx context.Context, id string) error
+	// List the trashed blogs of a user
+	GetTrashedBlogs(ctx context.Context, userID string) ([]*entities.Blog, error)
+	// Restore a trashed blog
+	RestoreBlog(ctx context.Context, id string) (*entities.Blog, error)
+	// Permanently remove trashed blogs whose retention has ended, with their comments, interactions, revisions and assets
+	PurgeTrashedBlogs(ctx context.Context, now time.Time) (int64, error)
+	// Recompute the materialized popularity scores, refreshing the recency boosts
+	RecomputePopularityScores(ctx context.Context, now time.Time) error
 	// Get popular blogs with popularity scores
 	GetPopularBlogs(ctx context.Context, limit int64) ([]*entities.BlogWithPopularity, error)
+	// Get the listed blogs most similar to a readable blog by tags and content
+	GetRelatedBlogs(ctx context.Context, id string, access *entities.BlogAccess, limit int) ([]*entities.RelatedBlog, error)
+	// Get the blogs trending within a window ("24h", "7d", "30d"), optionally only those with a tag
+	GetTrendingBlogs(ctx context.Context, window string, tag string, limit int) ([]*entities.TrendingBlog, error)
 	// Filter blogs based on criteria
 	FilterBlogs(ctx context.Context, filter *entities.BlogFilter) (*entities.FilterResponse, error)
 	// Search blogs based on title and/or author
 	SearchBlogs(ctx context.Context, search *entities.BlogSearch) (*entities.SearchResponse, error)
+	// Type-ahead suggestions (titles, tags, authors) for a search prefix
+	SuggestSearch(ctx context.Context, query string, limit int) (*entities.SearchSuggestions, error)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// CommentModerationRepositoryInterface defines the contract for the moderation log of blog comments
+type CommentModerationRepositoryInterface interface {
+	RecordAction(ctx context.Context, action *entities.ModerationAction) error
+	// GetActionsByBlogID returns the latest moderation actions on a blog, newest first
+	GetActionsByBlogID(ctx context.Context, blogID string, limit int) ([]*entities.ModerationAction, error)
+	// DeleteActionsByBlogID permanently removes the moderation log of a blog
+	DeleteActionsByBlogID(ctx context.Context, blogID string) error
+}
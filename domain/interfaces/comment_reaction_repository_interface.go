@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// CommentReactionRepositoryInterface defines the contract for comment reaction repository operations
+type CommentReactionRepositoryInterface interface {
+	// AddReaction reports whether the reaction was stored (false when the user already had one)
+	AddReaction(ctx context.Context, reaction *entities.CommentReaction) (bool, error)
+	// RemoveReaction reports whether the user's reaction of that type was removed (false when it was already gone)
+	RemoveReaction(ctx context.Context, commentID string, userID string, reactionType string) (bool, error)
+	// GetReaction returns the type of the user's reaction to a comment, or "" when there is none
+	GetReaction(ctx context.Context, commentID string, userID string) (string, error)
+	DeleteReactionsByBlogID(ctx context.Context, blogID string) error
+}
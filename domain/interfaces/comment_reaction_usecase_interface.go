@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// CommentReactionUseCaseInterface defines the contract for comment reaction use case operations
+type CommentReactionUseCaseInterface interface {
+	// LikeComment and DislikeComment return the user's reaction afterwards: "like", "dislike" or "" when it was taken back
+	LikeComment(ctx context.Context, commentID string, actor *entities.CommentActor) (string, error)
+	DislikeComment(ctx context.Context, commentID string, actor *entities.CommentActor) (string, error)
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 )
@@ -9,9 +10,26 @@ import (
 // CommentRepositoryInterface defines the contract for comment repository operations
 type CommentRepositoryInterface interface {
 	CreateComment(ctx context.Context, comment *entities.Comment) error
-	GetCommentsByBlogID(ctx context.Context, blogID string) ([]*entities.Comment, error)
+	GetCommentsByBlogID(ctx context.Context, blogID string, query *entities.PageQuery) ([]*entities.Comment, int64, error)
+	GetReplies(ctx context.Context, parentID string, query *entities.PageQuery) ([]*entities.Comment, int64, error)
 	GetCommentByID(ctx context.Context, id string) (*entities.Comment, error)
+	// UpdateComment saves the edited content of a comment and stores it as a revision
 	UpdateComment(ctx context.Context, comment *entities.Comment) error
-	DeleteComment(ctx context.Context, id string) error
+	// GetCommentRevisions lists the revisions of a comment, newest first
+	GetCommentRevisions(ctx context.Context, commentID string) ([]*entities.CommentRevision, error)
+	// MarkCommentDeleted turns a comment into a tombstone that keeps its place in the thread;
+	// it reports whether the comment lost its pin
+	MarkCommentDeleted(ctx context.Context, id string, deletedBy string, deletedAt time.Time) (bool, error)
+	UpdateReplyCount(ctx context.Context, id string, change int) error
+	// SetHidden hides or shows a comment; it reports whether the comment lost its pin
+	SetHidden(ctx context.Context, id string, hidden bool) (bool, error)
+	// SetPinned pins a visible comment at pinnedAt, or unpins it when pinnedAt is nil;
+	// it reports whether the pin changed
+	SetPinned(ctx context.Context, id string, pinnedAt *time.Time) (bool, error)
+	// GetPinnedComments returns the pinned comments of a blog, first pinned first
+	GetPinnedComments(ctx context.Context, blogID string) ([]*entities.Comment, error)
+	// UpdateReactionCounts moves the like and dislike counts of a comment and its reaction score along
+	UpdateReactionCounts(ctx context.Context, id string, change entities.CounterChange) error
 	GetCommentCountByBlogID(ctx context.Context, blogID string) (int64, error)
+	DeleteCommentsByBlogID(ctx context.Context, blogID string) error
 }
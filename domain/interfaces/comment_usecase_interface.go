@@ -8,9 +8,22 @@ import (
 
 // CommentUseCaseInterface defines the contract for comment use case operations
 type CommentUseCaseInterface interface {
-	CreateComment(ctx context.Context, comment *entities.Comment, userID string, blogID string) error
-	GetCommentsByBlogID(ctx context.Context, blogID string) ([]*entities.Comment, error)
-	GetCommentByID(ctx context.Context, id string) (*entities.Comment, error)
+	CreateComment(ctx context.Context, comment *entities.Comment, author *entities.CommentActor, blogID string) error
+	CreateReply(ctx context.Context, reply *entities.Comment, author *entities.CommentActor, parentID string) error
+	GetCommentsByBlogID(ctx context.Context, blogID string, viewer *entities.CommentActor, sort string, limit int, cursor string) (*entities.CommentPage, error)
+	GetReplies(ctx context.Context, parentID string, viewer *entities.CommentActor, sort string, limit int, cursor string) (*entities.CommentPage, error)
+	GetCommentByID(ctx context.Context, id string, viewer *entities.CommentActor) (*entities.Comment, error)
 	UpdateComment(ctx context.Context, comment *entities.Comment) error
-	DeleteComment(ctx context.Context, id string) error
+	// GetCommentRevisions lists the edit history of a comment, newest first
+	GetCommentRevisions(ctx context.Context, id string, viewer *entities.CommentActor) ([]*entities.CommentRevision, error)
+	// DeleteComment turns a comment into a tombstone on behalf of its author or a moderator of the blog
+	DeleteComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error
+
+	// Moderation by the author of the blog or an admin; every change is recorded in the moderation log
+	HideComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error
+	UnhideComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error
+	PinComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error
+	UnpinComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error
+	SetCommentsLocked(ctx context.Context, blogID string, actor *entities.CommentActor, locked bool, reason string) error
+	GetModerationLog(ctx context.Context, blogID string, actor *entities.CommentActor, limit int) ([]*entities.ModerationAction, error)
 }
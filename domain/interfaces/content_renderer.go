@@ -0,0 +1,8 @@
+package interfaces
+
+import "github.com/Abenuterefe/a2sv-project/domain/entities"
+
+// ContentRenderer turns user-written Markdown into sanitized HTML
+type ContentRenderer interface {
+	Render(markdown string) *entities.RenderedContent
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "github.com/Abenuterefe/a2sv-project/domain/entities"
+
+// CursorSigner turns page cursors into opaque tokens that clients cannot forge or alter
+type CursorSigner interface {
+	Encode(cursor *entities.PageCursor) (string, error)
+	Decode(token string) (*entities.PageCursor, error)
+}
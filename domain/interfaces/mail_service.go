@@ -3,4 +3,5 @@ package interfaces
 type MailService interface{
 	SendVerificationEmail(to, token string) error
 	SendPasswordResetEmail(to string, resetLink string) error
+	SendBlogReviewEmail(to string, blogTitle string, status string, comment string) error
 }
\ No newline at end of file
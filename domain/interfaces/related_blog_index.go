@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// RelatedBlogIndex ranks listed blogs by their similarity to a blog and caches the results.
+// The index is kept in memory; it is rebuilt from the stored blogs when Stale and updated as blogs change.
+type RelatedBlogIndex interface {
+	// Stale reports whether the index must be rebuilt (never built, invalidated or too old)
+	Stale(now time.Time) bool
+	// Rebuild replaces the indexed blogs
+	Rebuild(blogs []*entities.Blog, now time.Time)
+	// Upsert adds or replaces a listed blog
+	Upsert(blog *entities.Blog)
+	// Remove drops a blog that is no longer listed
+	Remove(blogID string)
+	// Invalidate forces a rebuild before the next lookup
+	Invalidate()
+	// Related returns up to limit indexed blogs most similar to blog, best match first, never blog itself
+	Related(blog *entities.Blog, limit int) []entities.RelatedMatch
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// ErrBlogInSeries is returned by UpdateSeriesBlogs when one of the blogs got added to another series first
+var ErrBlogInSeries = errors.New("blog already belongs to a series")
+
+// SeriesRepositoryInterface defines the contract for series repository operations
+type SeriesRepositoryInterface interface {
+	CreateSeries(ctx context.Context, series *entities.Series) error
+	GetSeriesByID(ctx context.Context, id string) (*entities.Series, error)
+	GetSeriesByUserID(ctx context.Context, userID string) ([]*entities.Series, error)
+	// Get the series a blog belongs to
+	GetSeriesByBlogID(ctx context.Context, blogID string) (*entities.Series, error)
+	// Store the ordered blog IDs of a series (ErrBlogInSeries when a blog is in another series)
+	UpdateSeriesBlogs(ctx context.Context, series *entities.Series) error
+	// Remove a blog from every series it belongs to
+	RemoveBlogFromSeries(ctx context.Context, blogID string) error
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// SeriesUseCaseInterface defines the contract for series use case operations
+type SeriesUseCaseInterface interface {
+	CreateSeries(ctx context.Context, series *entities.Series, userID string) error
+	// Get a series with its published parts
+	GetSeriesByID(ctx context.Context, id string) (*entities.Series, error)
+	GetSeriesByUserID(ctx context.Context, userID string) ([]*entities.Series, error)
+	// Append one of the user's blogs to the end of the series
+	AddBlogToSeries(ctx context.Context, seriesID string, blogID string, userID string) (*entities.Series, error)
+	RemoveBlogFromSeries(ctx context.Context, seriesID string, blogID string, userID string) (*entities.Series, error)
+	// Reorder the series; blogIDs must contain exactly the blogs already in the series
+	ReorderSeries(ctx context.Context, seriesID string, blogIDs []string, userID string) (*entities.Series, error)
+}
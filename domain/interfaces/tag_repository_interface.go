@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// TagRepositoryInterface defines the contract for tag repository operations
+type TagRepositoryInterface interface {
+	CreateTag(ctx context.Context, tag *entities.Tag) error
+	// Get all tags ordered by slug
+	GetTags(ctx context.Context) ([]*entities.Tag, error)
+	// Get the tag with the given slug (mongo.ErrNoDocuments if none)
+	GetTagBySlug(ctx context.Context, slug string) (*entities.Tag, error)
+	// Get the tags whose slug or one of whose synonyms is in slugs
+	FindTags(ctx context.Context, slugs []string) ([]*entities.Tag, error)
+	// Store the slug, name, description and synonyms of a tag
+	UpdateTag(ctx context.Context, tag *entities.Tag) error
+	DeleteTags(ctx context.Context, slugs []string) error
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// TagUseCaseInterface defines the contract for tag use case operations
+type TagUseCaseInterface interface {
+	// List the defined and used tags with their post counts, most used first
+	ListTags(ctx context.Context) ([]*entities.Tag, error)
+	// Define a canonical tag; blogs using its synonyms are rewritten to it
+	CreateTag(ctx context.Context, tag *entities.Tag) (*entities.Tag, error)
+	// Change the name, description or synonyms of a tag
+	UpdateTag(ctx context.Context, slug string, update *entities.TagUpdate) (*entities.Tag, error)
+	// Give a tag a new slug; the old slug becomes a synonym and blogs are rewritten
+	RenameTag(ctx context.Context, slug string, newSlug string) (*entities.TagChange, error)
+	// Fold tags into a target tag; their slugs become synonyms of the target and blogs are rewritten
+	MergeTags(ctx context.Context, merge *entities.TagMerge) (*entities.TagChange, error)
+	// Rewrite tags stored before tags were normalized to their canonical form; returns the number of blogs changed
+	NormalizeStoredTags(ctx context.Context) (int64, error)
+}
@@ -12,6 +12,7 @@ type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	FindByID(ctx context.Context, id primitive.ObjectID)(*entities.User, error)
+	FindByRole(ctx context.Context, role entities.Role) ([]*entities.User, error)
 	UpdateUsername(ctx context.Context, userID primitive.ObjectID, username string) error
 	// Store, access, delete jwt token to the user
 	StoreToken(ctx context.Context, token *entities.Token) error
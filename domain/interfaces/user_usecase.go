@@ -14,6 +14,7 @@ type UserUsecase interface {
 	ResendVerificationEmail(ctx context.Context, email string) error
 	PromoteUser(ctx context.Context, userID string) error
 	DemoteUser(ctx context.Context, userID string) error
+	MakeReviewer(ctx context.Context, userID string) error
 	Logout(ctx context.Context, userID string) error
 	GoogleOAuthLogin(ctx context.Context, code string) (*entities.Token, error)
 	RequestPasswordReset(ctx context.Context, email string) error 
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// blogAccessAudience keeps blog access tokens from being accepted as login tokens (JWTService.VerifyToken
+// rejects tokens with an audience) and login tokens from unlocking blogs
+const blogAccessAudience = "blog-access"
+
+// BlogAccessTokenTTL is how long an unlocked password-protected blog stays readable
+var BlogAccessTokenTTL = time.Minute * 30
+
+type BlogAccessTokenService struct{}
+
+func NewBlogAccessTokenService() *BlogAccessTokenService {
+	return &BlogAccessTokenService{}
+}
+
+// CreateBlogAccessToken signs a token scoped to a single blog
+func (s *BlogAccessTokenService) CreateBlogAccessToken(blogID string) (string, time.Time, error) {
+	expiresAt := time.Now().Add(BlogAccessTokenTTL)
+	claims := jwt.RegisteredClaims{
+		Subject:   blogID,
+		Audience:  jwt.ClaimStrings{blogAccessAudience},
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+	return signed, expiresAt, err
+}
+
+// VerifyBlogAccessToken checks that the token is valid, unexpired and issued for blogID
+func (s *BlogAccessTokenService) VerifyBlogAccessToken(tokenStr string, blogID string) error {
+	var claims jwt.RegisteredClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("ACCESS_SECRET")), nil
+	}, jwt.WithAudience(blogAccessAudience), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+	if err != nil || !token.Valid || claims.Subject != blogID {
+		return errors.New("invalid or expired blog access token")
+	}
+	return nil
+}
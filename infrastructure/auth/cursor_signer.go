@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// cursorContext keeps cursor signatures from being valid for anything else signed with the same secret
+const cursorContext = "page-cursor:"
+
+type CursorSigner struct{}
+
+func NewCursorSigner() *CursorSigner {
+	return &CursorSigner{}
+}
+
+// Encode serializes the cursor and appends an HMAC-SHA256 signature: <payload>.<signature>, both base64url
+func (s *CursorSigner) Encode(cursor *entities.PageCursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// Decode verifies the signature of a token produced by Encode and returns its cursor
+func (s *CursorSigner) Decode(token string) (*entities.PageCursor, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, errors.New("invalid cursor")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return nil, errors.New("invalid cursor")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var cursor entities.PageCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+func (s *CursorSigner) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("ACCESS_SECRET")))
+	mac.Write([]byte(cursorContext + encoded))
+	return mac.Sum(nil)
+}
@@ -60,7 +60,7 @@ func (s *JWTService) VerifyToken(tokenStr string, isAccess bool) (*entities.JWTC
 
 	token, err := jwt.ParseWithClaims(tokenStr, &entities.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(secret), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil || !token.Valid {
 		return nil, errors.New("invalid or expired token")
@@ -71,5 +71,11 @@ func (s *JWTService) VerifyToken(tokenStr string, isAccess bool) (*entities.JWTC
 		return nil, errors.New("could not parse claims")
 	}
 
+	// Login tokens carry no audience; scoped tokens signed with the same secret,
+	// such as blog access tokens, must not pass for a login
+	if len(claims.Audience) > 0 || claims.UserID == "" {
+		return nil, errors.New("invalid or expired token")
+	}
+
 	return claims, nil
 }
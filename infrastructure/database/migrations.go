@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/infrastructure/related"
+	"github.com/Abenuterefe/a2sv-project/repository"
+	"github.com/Abenuterefe/a2sv-project/usecase"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migration is a one-time change to stored data. Once it succeeded it is recorded in the
+// migrations collection and never runs again; it must be safe to run twice in case two
+// instances start at the same time.
+type Migration struct {
+	Name string
+	Run  func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrations are applied in order; append new ones at the end and never rename applied ones
+var migrations = []Migration{
+	{Name: "comment-reaction-counters", Run: backfillCommentCounters},
+	{Name: "canonical-blog-tags", Run: normalizeStoredTags},
+}
+
+// RunMigrations applies the migrations that have not been applied to the database yet
+func RunMigrations(ctx context.Context, client *mongo.Client) error {
+	db := client.Database("g6_starter_projectDb")
+	applied := db.Collection("migrations")
+	for _, migration := range migrations {
+		count, err := applied.CountDocuments(ctx, bson.M{"_id": migration.Name})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := migration.Run(ctx, db); err != nil {
+			return fmt.Errorf("migration %s: %w", migration.Name, err)
+		}
+		_, err = applied.InsertOne(ctx, bson.M{"_id": migration.Name, "applied_at": time.Now()})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		log.Printf("migration %s applied", migration.Name)
+	}
+	return nil
+}
+
+// backfillCommentCounters gives comments written before reaction counters existed counts of zero,
+// so keyset pages sorted by likes or reactions do not skip them
+func backfillCommentCounters(ctx context.Context, db *mongo.Database) error {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"like_count": bson.M{"$exists": false}},
+		bson.M{"dislike_count": bson.M{"$exists": false}},
+		bson.M{"reaction_score": bson.M{"$exists": false}},
+	}}
+	update := bson.A{bson.M{"$set": bson.M{
+		"like_count":     bson.M{"$ifNull": bson.A{"$like_count", 0}},
+		"dislike_count":  bson.M{"$ifNull": bson.A{"$dislike_count", 0}},
+		"reaction_score": bson.M{"$subtract": bson.A{bson.M{"$ifNull": bson.A{"$like_count", 0}}, bson.M{"$ifNull": bson.A{"$dislike_count", 0}}}},
+	}}}
+	_, err := db.Collection("comments").UpdateMany(ctx, filter, update)
+	return err
+}
+
+// normalizeStoredTags rewrites the tags of blogs stored before tags were normalized to canonical tags.
+// It runs before the routes are set up, so a fresh related blogs index is enough.
+func normalizeStoredTags(ctx context.Context, db *mongo.Database) error {
+	tags := usecase.NewTagUseCase(
+		repository.NewTagRepositoryMongo(db.Collection("tags")),
+		repository.NewBlogRepositoryMongo(db.Collection("blogs")),
+		related.NewRelatedBlogIndex(),
+	)
+	updated, err := tags.NormalizeStoredTags(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("tag backfill: %d blogs normalized", updated)
+	return nil
+}
@@ -2,6 +2,7 @@ package mail
 
 import (
 	"fmt"
+	"html"
 	"os"
 	"time"
 
@@ -165,3 +166,64 @@ func (s *MailService) SendPasswordResetEmail(to string, resetLink string) error
 	d := gomail.NewDialer(s.SMTPHost, s.SMTPPort, s.From, s.Password)
 	return d.DialAndSend(m)
 }
+
+// function to send blog review notifications (submission, changes requested, approval, withdrawn approval)
+func (s *MailService) SendBlogReviewEmail(to string, blogTitle string, status string, comment string) error {
+	headlines := map[string]string{
+		"submitted":          "A blog is waiting for your review",
+		"changes_requested":  "Changes were requested on your blog",
+		"approved":           "Your blog was approved",
+		"approval_withdrawn": "Your blog was edited after its approval and needs a new review",
+	}
+	headline, ok := headlines[status]
+	if !ok {
+		headline = "Blog review update"
+	}
+
+	commentBlock := ""
+	if comment != "" {
+		commentBlock = fmt.Sprintf(`<p><strong>Reviewer comment:</strong></p>
+                <blockquote style="margin:0; padding:10px 15px; background-color:#f4f4f4; border-left:4px solid #4CAF50;">%s</blockquote>`, html.EscapeString(comment))
+	}
+
+	body := fmt.Sprintf(`
+<html>
+  <body style="margin:0; padding:0; font-family: Arial, sans-serif; background-color:#f4f4f4;">
+    <table width="100%%" border="0" cellspacing="0" cellpadding="0" style="padding: 20px;">
+      <tr>
+        <td align="center">
+          <table width="600" style="background-color:#ffffff; border-radius:8px; overflow:hidden; box-shadow:0 2px 8px rgba(0,0,0,0.1);">
+            <tr>
+              <td style="background-color:#4CAF50; padding:20px; text-align:center; color:white; font-size:24px; font-weight:bold;">
+                %s
+              </td>
+            </tr>
+            <tr>
+              <td style="padding: 30px; color:#333333; font-size:16px; line-height:1.5;">
+                <p>Hello,</p>
+                <p>Blog: <strong>%s</strong></p>
+                %s
+              </td>
+            </tr>
+            <tr>
+              <td style="background-color:#f4f4f4; padding:15px; text-align:center; color:#888888; font-size:12px;">
+                &copy; %d Backend team Group 2. All rights reserved.
+              </td>
+            </tr>
+          </table>
+        </td>
+      </tr>
+    </table>
+  </body>
+</html>
+`, headline, html.EscapeString(blogTitle), commentBlock, time.Now().Year())
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", s.From)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", headline)
+	m.SetBody("text/html", body)
+
+	d := gomail.NewDialer(s.SMTPHost, s.SMTPPort, s.From, s.Password)
+	return d.DialAndSend(m)
+}
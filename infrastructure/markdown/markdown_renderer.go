@@ -0,0 +1,482 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// MarkdownRenderer renders a safe subset of Markdown (headings, paragraphs, emphasis,
+// code, lists, blockquotes, links, images and rules) to sanitized HTML.
+// Raw HTML in the source is always escaped, never passed through.
+type MarkdownRenderer struct{}
+
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{}
+}
+
+var (
+	headingRe     = regexp.MustCompile(`^(#{1,6})(?:\s+(.*?))?\s*#*\s*$`)
+	ruleRe        = regexp.MustCompile(`^(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	unorderedRe   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	orderedRe     = regexp.MustCompile(`^(\d{1,9})[.)]\s+(.*)$`)
+	fenceRe       = regexp.MustCompile("^(```+|~~~+)\\s*([\\w+-]*)")
+	autolinkRe    = regexp.MustCompile(`^<((?:https?://|mailto:)[^<>\s]+)>`)
+	languageClass = regexp.MustCompile(`^[\w+-]+$`)
+)
+
+// Nesting limits keep rendering linear in the length of the source;
+// markup nested deeper than this is rendered as text
+const (
+	maxQuoteDepth  = 8
+	maxInlineDepth = 8
+)
+
+// Render converts Markdown to sanitized HTML and extracts its plain text
+func (r *MarkdownRenderer) Render(source string) *entities.RenderedContent {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	rendered := Sanitize(renderBlocks(strings.Split(source, "\n"), 0))
+	return &entities.RenderedContent{
+		HTML:      rendered,
+		PlainText: PlainText(rendered),
+	}
+}
+
+// renderBlocks renders block-level elements at the given blockquote depth
+func renderBlocks(lines []string, depth int) string {
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case trimmed == "":
+			i++
+
+		case fenceRe.MatchString(trimmed):
+			match := fenceRe.FindStringSubmatch(trimmed)
+			fence, language := match[1], match[2]
+			i++
+			var code []string
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // closing fence
+			b.WriteString("<pre><code")
+			if language != "" && languageClass.MatchString(language) {
+				b.WriteString(` class="language-` + language + `"`)
+			}
+			b.WriteString(">")
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			b.WriteString("</code></pre>\n")
+
+		case headingRe.MatchString(trimmed):
+			match := headingRe.FindStringSubmatch(trimmed)
+			tag := "h" + string(rune('0'+len(match[1])))
+			b.WriteString("<" + tag + ">" + renderInline(match[2]) + "</" + tag + ">\n")
+			i++
+
+		case ruleRe.MatchString(trimmed):
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(trimmed, ">") && depth < maxQuoteDepth:
+			var quoted []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				line := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(line, " "))
+				i++
+			}
+			b.WriteString("<blockquote>\n" + renderBlocks(quoted, depth+1) + "</blockquote>\n")
+
+		case unorderedRe.MatchString(trimmed) || orderedRe.MatchString(trimmed):
+			i = renderList(&b, lines, i)
+
+		default:
+			var paragraph []string
+			for i < len(lines) {
+				line := lines[i]
+				t := strings.TrimSpace(line)
+				if t == "" || (len(paragraph) > 0 && startsBlock(t)) {
+					break
+				}
+				paragraph = append(paragraph, line)
+				i++
+			}
+			b.WriteString("<p>" + renderParagraph(paragraph) + "</p>\n")
+		}
+	}
+	return b.String()
+}
+
+// renderList renders consecutive items of one list and returns the index after it
+func renderList(b *strings.Builder, lines []string, i int) int {
+	ordered := orderedRe.MatchString(strings.TrimSpace(lines[i]))
+	if ordered {
+		start := orderedRe.FindStringSubmatch(strings.TrimSpace(lines[i]))[1]
+		if start != "1" {
+			b.WriteString(`<ol start="` + strings.TrimLeft(start, "0") + `">` + "\n")
+		} else {
+			b.WriteString("<ol>\n")
+		}
+	} else {
+		b.WriteString("<ul>\n")
+	}
+
+	var item []string
+	flush := func() {
+		if item != nil {
+			b.WriteString("<li>" + renderParagraph(item) + "</li>\n")
+		}
+		item = nil
+	}
+
+	for i < len(lines) {
+		t := strings.TrimSpace(lines[i])
+		if t == "" {
+			// A blank line ends the list unless another item of the same kind follows
+			if i+1 < len(lines) && isListItem(strings.TrimSpace(lines[i+1]), ordered) {
+				i++
+				continue
+			}
+			break
+		}
+		if isListItem(t, ordered) {
+			flush()
+			if ordered {
+				item = []string{orderedRe.FindStringSubmatch(t)[2]}
+			} else {
+				item = []string{unorderedRe.FindStringSubmatch(t)[1]}
+			}
+			i++
+			continue
+		}
+		if startsBlock(t) {
+			break
+		}
+		// Continuation line of the current item
+		item = append(item, lines[i])
+		i++
+	}
+	flush()
+
+	if ordered {
+		b.WriteString("</ol>\n")
+	} else {
+		b.WriteString("</ul>\n")
+	}
+	return i
+}
+
+func isListItem(line string, ordered bool) bool {
+	if ruleRe.MatchString(line) {
+		return false
+	}
+	if ordered {
+		return orderedRe.MatchString(line)
+	}
+	return unorderedRe.MatchString(line)
+}
+
+// startsBlock reports whether a line interrupts a paragraph
+func startsBlock(line string) bool {
+	return fenceRe.MatchString(line) || headingRe.MatchString(line) || ruleRe.MatchString(line) ||
+		strings.HasPrefix(line, ">") || unorderedRe.MatchString(line) || orderedRe.MatchString(line)
+}
+
+// renderParagraph renders the inline content of several lines;
+// a line ending in two spaces or a backslash becomes a hard line break
+func renderParagraph(lines []string) string {
+	parts := make([]string, 0, len(lines))
+	for idx, line := range lines {
+		line = strings.TrimLeft(line, " \t")
+		hardBreak := idx < len(lines)-1 && (strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\"))
+		line = strings.TrimRight(line, " \t")
+		if hardBreak {
+			line = strings.TrimSuffix(line, "\\")
+		}
+		rendered := renderInline(line)
+		if hardBreak {
+			rendered += "<br>"
+		}
+		parts = append(parts, rendered)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// renderInline renders emphasis, code spans, links, images and autolinks
+func renderInline(text string) string {
+	return newInlineScanner(text).render(0)
+}
+
+// inlineScanner renders one run of inline text. It remembers the results of its
+// searches for closing delimiters, so openers without a match (e.g. "_a _a _a")
+// do not rescan the rest of the text and rendering stays linear.
+type inlineScanner struct {
+	text        string
+	brackets    []int            // index of the "]" matching the "[" at each index, -1 if none
+	parens      []int            // index of the ")" matching the "(" at each index, -1 if none
+	closers     map[string][]int // per delimiter, 1 + index of the closer found from each index, -1 if none
+	unclosedRun map[int]bool     // backtick run lengths that have no closing run
+}
+
+func newInlineScanner(text string) *inlineScanner {
+	return &inlineScanner{text: text, closers: map[string][]int{}, unclosedRun: map[int]bool{}}
+}
+
+func (s *inlineScanner) render(depth int) string {
+	text := s.text
+	if depth > maxInlineDepth {
+		return html.EscapeString(text)
+	}
+	// nested renders inner text (a label or emphasized text) one level deeper
+	nested := func(inner string) string {
+		return newInlineScanner(inner).render(depth + 1)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!~<>|\"'", text[i+1]) >= 0:
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+
+		case c == '`':
+			run := len(rest) - len(strings.TrimLeft(rest, "`"))
+			delimiter := rest[:run]
+			end := -1
+			if !s.unclosedRun[run] {
+				end = strings.Index(rest[run:], delimiter)
+			}
+			if end < 0 {
+				// No later run of this length closes it either
+				s.unclosedRun[run] = true
+				b.WriteString(delimiter)
+				i += run
+				continue
+			}
+			code := rest[run : run+end]
+			if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+				code = code[1 : len(code)-1]
+			}
+			b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			i += run + end + run
+
+		case c == '!' && strings.HasPrefix(rest, "!["):
+			label, url, title, n, ok := s.parseLink(i + 1)
+			if !ok || !SafeURL(url) {
+				b.WriteString("!")
+				i++
+				continue
+			}
+			b.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(PlainText(nested(label))) + `"`)
+			if title != "" {
+				b.WriteString(` title="` + html.EscapeString(title) + `"`)
+			}
+			b.WriteString(">")
+			i += 1 + n
+
+		case c == '[':
+			label, url, title, n, ok := s.parseLink(i)
+			if !ok {
+				b.WriteString("[")
+				i++
+				continue
+			}
+			if !SafeURL(url) {
+				// Drop dangerous links but keep their text
+				b.WriteString(nested(label))
+				i += n
+				continue
+			}
+			b.WriteString(`<a href="` + html.EscapeString(url) + `"`)
+			if title != "" {
+				b.WriteString(` title="` + html.EscapeString(title) + `"`)
+			}
+			b.WriteString(">" + nested(label) + "</a>")
+			i += n
+
+		case c == '<' && autolinkRe.MatchString(rest):
+			match := autolinkRe.FindStringSubmatch(rest)
+			url := html.EscapeString(match[1])
+			b.WriteString(`<a href="` + url + `">` + url + "</a>")
+			i += len(match[0])
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n, ok := s.delimited(i, rest[:2]); ok {
+				b.WriteString("<strong>" + nested(inner) + "</strong>")
+				i += n
+				continue
+			}
+			b.WriteString(rest[:2])
+			i += 2
+
+		case strings.HasPrefix(rest, "~~"):
+			if inner, n, ok := s.delimited(i, "~~"); ok {
+				b.WriteString("<del>" + nested(inner) + "</del>")
+				i += n
+				continue
+			}
+			b.WriteString("~~")
+			i += 2
+
+		case c == '*' || c == '_':
+			if inner, n, ok := s.delimited(i, rest[:1]); ok {
+				b.WriteString("<em>" + nested(inner) + "</em>")
+				i += n
+				continue
+			}
+			b.WriteByte(c)
+			i++
+
+		default:
+			// Multi-byte UTF-8 sequences never contain ASCII bytes, so byte-wise escaping is safe
+			switch c {
+			case '&':
+				b.WriteString("&amp;")
+			case '<':
+				b.WriteString("&lt;")
+			case '>':
+				b.WriteString("&gt;")
+			case '"':
+				b.WriteString("&#34;")
+			case '\'':
+				b.WriteString("&#39;")
+			default:
+				b.WriteByte(c)
+			}
+			i++
+		}
+	}
+	return b.String()
+}
+
+// delimited finds the text between an opening delimiter at text[start:] and its closing match.
+// It returns the inner text and the total length consumed.
+func (s *inlineScanner) delimited(start int, delimiter string) (string, int, bool) {
+	text := s.text
+	// "_" only opens emphasis at a word boundary (snake_case stays as is)
+	if delimiter[0] == '_' && start > 0 && isWordChar(text[start-1]) {
+		return "", 0, false
+	}
+	open := start + len(delimiter)
+	if open >= len(text) || text[open] == ' ' {
+		return "", 0, false
+	}
+	j := s.closer(open+1, delimiter)
+	if j < 0 {
+		return "", 0, false
+	}
+	return text[open:j], j + len(delimiter) - start, true
+}
+
+// closer returns the index of the first closing delimiter found scanning from index from, or -1.
+// Whether an index closes depends only on the index itself, so the result is cached for every
+// index the scan passes and each index is scanned at most once per delimiter.
+func (s *inlineScanner) closer(from int, delimiter string) int {
+	text := s.text
+	cache := s.closers[delimiter]
+	if cache == nil {
+		cache = make([]int, len(text)+1)
+		s.closers[delimiter] = cache
+	}
+
+	var visited []int
+	found := -1
+	for j := from; j+len(delimiter) <= len(text); {
+		if cached := cache[j]; cached != 0 {
+			found = cached - 1
+			if cached < 0 {
+				found = -1
+			}
+			break
+		}
+		visited = append(visited, j)
+		if text[j:j+len(delimiter)] != delimiter || text[j-1] == ' ' || text[j-1] == '\\' {
+			j++
+			continue
+		}
+		// A single delimiter must not be part of a double one (e.g. "*a **b** c*")
+		if len(delimiter) == 1 && j+1 < len(text) && text[j+1] == delimiter[0] {
+			j += 2
+			continue
+		}
+		if delimiter[0] == '_' && j+len(delimiter) < len(text) && isWordChar(text[j+len(delimiter)]) {
+			j++
+			continue
+		}
+		found = j
+		break
+	}
+
+	cached := -1
+	if found >= 0 {
+		cached = found + 1
+	}
+	for _, j := range visited {
+		cache[j] = cached
+	}
+	return found
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// parseLink parses `[label](url "title")` at text[start:]; n is the length consumed
+func (s *inlineScanner) parseLink(start int) (label string, url string, title string, n int, ok bool) {
+	text := s.text
+	if s.brackets == nil {
+		s.brackets = matchPairs(text, '[', ']', true)
+		s.parens = matchPairs(text, '(', ')', false)
+	}
+	closeLabel := s.brackets[start]
+	if closeLabel < 0 || closeLabel+1 >= len(text) || text[closeLabel+1] != '(' {
+		return "", "", "", 0, false
+	}
+	// Destinations may contain balanced parentheses, e.g. wiki links
+	closeDest := s.parens[closeLabel+1]
+	if closeDest < 0 {
+		return "", "", "", 0, false
+	}
+	destination := strings.TrimSpace(text[closeLabel+2 : closeDest])
+	if space := strings.IndexAny(destination, " \t"); space >= 0 {
+		title = strings.TrimSpace(destination[space:])
+		destination = destination[:space]
+		if len(title) >= 2 && (title[0] == '"' || title[0] == '\'') && title[len(title)-1] == title[0] {
+			title = title[1 : len(title)-1]
+		} else {
+			return "", "", "", 0, false
+		}
+	}
+	destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+	return text[start+1 : closeLabel], destination, title, closeDest + 1 - start, true
+}
+
+// matchPairs matches opening and closing characters in one pass and returns, for the index
+// of each opening character, the index of its closing match (-1 if it has none)
+func matchPairs(text string, open byte, close byte, escapes bool) []int {
+	matches := make([]int, len(text))
+	var stack []int
+	for j := 0; j < len(text); j++ {
+		matches[j] = -1
+		switch text[j] {
+		case '\\':
+			if escapes && j+1 < len(text) {
+				j++
+				matches[j] = -1
+			}
+		case open:
+			stack = append(stack, j)
+		case close:
+			if len(stack) > 0 {
+				matches[stack[len(stack)-1]] = j
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return matches
+}
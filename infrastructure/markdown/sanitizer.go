@@ -0,0 +1,158 @@
+package markdown
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedTags lists the HTML elements (and their attributes) that may appear in rendered content
+var allowedTags = map[string][]string{
+	"p": nil, "br": nil, "hr": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"strong": nil, "em": nil, "del": nil,
+	"code": {"class"}, "pre": nil, "blockquote": nil,
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"a":   {"href", "title"},
+	"img": {"src", "alt", "title"},
+}
+
+// droppedContent lists elements whose content is removed together with the tag
+var droppedContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "textarea": true, "title": true, "template": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// blockTags separate words when converting HTML to plain text
+var blockTags = map[string]bool{
+	"p": true, "br": true, "hr": true, "li": true, "pre": true, "blockquote": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+var (
+	codeClassRe = regexp.MustCompile(`^language-[\w+-]+$`)
+	digitsRe    = regexp.MustCompile(`^\d{1,9}$`)
+)
+
+// Sanitize keeps only allow-listed elements and attributes; everything else is stripped
+// (text of unknown elements is kept, content of script-like elements is dropped)
+func Sanitize(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	var b strings.Builder
+	skipDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			return b.String()
+
+		case html.TextToken:
+			if skipDepth == 0 {
+				b.WriteString(html.EscapeString(string(tokenizer.Text())))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if droppedContent[token.Data] {
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+			allowedAttrs, ok := allowedTags[token.Data]
+			if skipDepth > 0 || !ok {
+				continue
+			}
+
+			b.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if !contains(allowedAttrs, attr.Key) || !safeAttribute(attr.Key, attr.Val) {
+					continue
+				}
+				b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			if token.Data == "a" {
+				b.WriteString(` rel="nofollow noopener noreferrer"`)
+			}
+			b.WriteString(">")
+
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if droppedContent[token.Data] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if _, ok := allowedTags[token.Data]; ok && skipDepth == 0 && !voidTags[token.Data] {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+		// Comments and doctypes are always dropped
+	}
+}
+
+// PlainText extracts the text content of an HTML fragment with collapsed whitespace
+func PlainText(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	var b strings.Builder
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.Join(strings.Fields(b.String()), " ")
+		case html.TextToken:
+			b.Write(tokenizer.Text())
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if blockTags[string(name)] {
+				b.WriteString(" ")
+			}
+		}
+	}
+}
+
+// SafeURL allows http, https and mailto links as well as relative URLs
+func SafeURL(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return false
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "":
+		// Relative URLs must not smuggle a scheme (e.g. "javascript&#58;") past the parser
+		return !strings.ContainsAny(raw, "\x00\t\n\r") && !strings.Contains(strings.ToLower(raw), "script:")
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+func safeAttribute(key string, value string) bool {
+	switch key {
+	case "href", "src":
+		return SafeURL(value)
+	case "class":
+		return codeClassRe.MatchString(value)
+	case "start":
+		return digitsRe.MatchString(value)
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -42,4 +42,19 @@ func AuthMiddleware(authService interfaces.AuthService) gin.HandlerFunc {
 		c.Set("role", claims.Role)
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// OptionalAuthMiddleware identifies the user when a valid Bearer token is sent,
+// but lets anonymous requests through (for public routes that show more to signed-in users)
+func OptionalAuthMiddleware(authService interfaces.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := authService.VerifyToken(parts[1], true); err == nil {
+				c.Set("userID", claims.UserID)
+				c.Set("role", claims.Role)
+			}
+		}
+		c.Next()
+	}
+}
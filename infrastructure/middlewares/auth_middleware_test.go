@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddleware_RejectsBlogAccessTokens(t *testing.T) {
+	t.Setenv("ACCESS_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+	jwtService := auth.NewJWTService()
+
+	r := gin.New()
+	r.POST("/blogs", AuthMiddleware(jwtService), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"user": c.GetString("userID")})
+	})
+	r.GET("/blogs/:id", OptionalAuthMiddleware(jwtService), func(c *gin.Context) {
+		_, signedIn := c.Get("userID")
+		c.JSON(http.StatusOK, gin.H{"signed_in": signedIn})
+	})
+	send := func(method string, path string, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// a token unlocking a password-protected blog is signed with the same secret but is no login
+	accessToken, _, err := auth.NewBlogAccessTokenService().CreateBlogAccessToken("507f1f77bcf86cd799439011")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/blogs", accessToken).Code)
+	assert.JSONEq(t, `{"signed_in":false}`, send(http.MethodGet, "/blogs/507f1f77bcf86cd799439011", accessToken).Body.String())
+
+	loginToken, err := jwtService.CreateAccessToken("user-1", "user")
+	assert.NoError(t, err)
+	w := send(http.MethodPost, "/blogs", loginToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"user":"user-1"}`, w.Body.String())
+	assert.JSONEq(t, `{"signed_in":true}`, send(http.MethodGet, "/blogs/507f1f77bcf86cd799439011", loginToken).Body.String())
+}
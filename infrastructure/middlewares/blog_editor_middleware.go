@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// BlogEditorMiddleware checks if the authenticated user is the owner or a co-author of the blog
+// Co-authors may edit a blog but owner-only actions (like deleting) use BlogOwnershipMiddleware
+func BlogEditorMiddleware(blogUseCase interfaces.BlogUseCaseInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			c.Abort()
+			return
+		}
+
+		blogID := c.Param("id")
+		if blogID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+			c.Abort()
+			return
+		}
+
+		// Fetch the blog to check authorship (drafts and archived blogs included)
+		blog, err := blogUseCase.GetBlogByIDForOwner(c.Request.Context(), blogID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+			c.Abort()
+			return
+		}
+
+		userIDStr := userID.(string)
+		if blog.UserID == userIDStr {
+			c.Next()
+			return
+		}
+		for _, coAuthor := range blog.CoAuthors {
+			if coAuthor == userIDStr {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only modify blogs you author"})
+		c.Abort()
+	}
+}
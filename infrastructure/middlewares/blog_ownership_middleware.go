@@ -26,8 +26,8 @@ func BlogOwnershipMiddleware(blogUseCase interfaces.BlogUseCaseInterface) gin.Ha
 			return
 		}
 
-		// Fetch the blog to check ownership
-		blog, err := blogUseCase.GetBlogByID(c.Request.Context(), blogID)
+		// Fetch the blog to check ownership (drafts and archived blogs included)
+		blog, err := blogUseCase.GetBlogByIDForOwner(c.Request.Context(), blogID)
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
 			c.Abort()
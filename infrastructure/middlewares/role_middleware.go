@@ -19,3 +19,16 @@ func AdminOnlyMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// Reviewer middleware restricts access to reviewers only
+func ReviewerOnlyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := c.Get("role")
+		if !exists || role.(string) != string(entities.RoleReviewer) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Reviewer access only"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
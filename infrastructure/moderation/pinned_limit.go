@@ -0,0 +1,22 @@
+package moderation
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// LoadMaxPinnedComments returns how many comments a blog can pin as set with COMMENT_MAX_PINNED (must be positive),
+// or limit when it is unset or invalid
+func LoadMaxPinnedComments(limit int) int {
+	value := os.Getenv("COMMENT_MAX_PINNED")
+	if value == "" {
+		return limit
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("⚠️ invalid COMMENT_MAX_PINNED, using default of %d", limit)
+		return limit
+	}
+	return parsed
+}
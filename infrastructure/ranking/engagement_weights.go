@@ -0,0 +1,31 @@
+package ranking
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// LoadEngagementWeights overrides weights with ENGAGEMENT_WEIGHT_LIKES, ENGAGEMENT_WEIGHT_DISLIKES,
+// ENGAGEMENT_WEIGHT_VIEWS and ENGAGEMENT_WEIGHT_COMMENTS; unset or invalid values keep the given weight
+func LoadEngagementWeights(weights entities.EngagementWeights) entities.EngagementWeights {
+	for name, weight := range map[string]*float64{
+		"ENGAGEMENT_WEIGHT_LIKES":    &weights.Likes,
+		"ENGAGEMENT_WEIGHT_DISLIKES": &weights.Dislikes,
+		"ENGAGEMENT_WEIGHT_VIEWS":    &weights.Views,
+		"ENGAGEMENT_WEIGHT_COMMENTS": &weights.Comments,
+	} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			*weight = parsed
+		} else {
+			log.Printf("⚠️ invalid %s, using default of %g", name, *weight)
+		}
+	}
+	return weights
+}
@@ -0,0 +1,22 @@
+package ranking
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// LoadTrendingGravity returns the decay of trending scores set with TRENDING_GRAVITY (must be positive),
+// or gravity when it is unset or invalid
+func LoadTrendingGravity(gravity float64) float64 {
+	value := os.Getenv("TRENDING_GRAVITY")
+	if value == "" {
+		return gravity
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 {
+		log.Printf("⚠️ invalid TRENDING_GRAVITY, using default of %g", gravity)
+		return gravity
+	}
+	return parsed
+}
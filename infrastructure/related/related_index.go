@@ -0,0 +1,245 @@
+package related
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+)
+
+const (
+	tagWeight     = 0.4 // share of the score given by tag overlap (Jaccard similarity of the tags)
+	contentWeight = 0.6 // share of the score given by TF-IDF cosine similarity of the title and content
+	titleBoost    = 3   // a title word counts as this many content words
+	minTermLength = 3   // shorter words are ignored
+	cachedMatches = 50  // matches kept per blog in the cache
+	maxIndexAge   = 15 * time.Minute
+)
+
+// stopWords are common English words that say nothing about the topic of a blog
+var stopWords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`the and for are but not you all any can had her was one our out has him his how its
+		may new now old see two way who did get let put say she too use that with have this will your from they know want
+		been good much some time very when come here just like long make many more only over such take than them well
+		were what where which while would there their about after again also because before being between both could
+		does doing down each even every into most other should still then these those through under until upon
+		http https www com`) {
+		stopWords[word] = true
+	}
+}
+
+// document is an indexed blog
+type document struct {
+	tags   map[string]bool
+	terms  map[string]int     // term frequencies of the title and content
+	vector map[string]float64 // unit-length TF-IDF vector, recomputed when the document frequencies change
+}
+
+// cachedResult are the matches of a blog as of its last update
+type cachedResult struct {
+	updatedAt time.Time
+	matches   []entities.RelatedMatch
+}
+
+// blogIndex is an in-memory TF-IDF index of the listed blogs
+type blogIndex struct {
+	mu      sync.Mutex
+	docs    map[string]*document
+	df      map[string]int // number of documents containing each term
+	dirty   bool           // document vectors are out of date
+	builtAt time.Time
+	cache   map[string]cachedResult
+}
+
+// NewRelatedBlogIndex creates an empty index; it is stale until rebuilt and is rebuilt again every 15 minutes
+// so changes made through other instances show up
+func NewRelatedBlogIndex() interfaces.RelatedBlogIndex {
+	return &blogIndex{docs: map[string]*document{}, df: map[string]int{}, cache: map[string]cachedResult{}}
+}
+
+func (x *blogIndex) Stale(now time.Time) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.builtAt.IsZero() || now.Sub(x.builtAt) > maxIndexAge
+}
+
+func (x *blogIndex) Rebuild(blogs []*entities.Blog, now time.Time) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.docs, x.df = map[string]*document{}, map[string]int{}
+	for _, blog := range blogs {
+		x.add(blog)
+	}
+	x.builtAt = now
+	x.changed()
+}
+
+func (x *blogIndex) Upsert(blog *entities.Blog) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(blog.ID.Hex())
+	x.add(blog)
+	x.changed()
+}
+
+func (x *blogIndex) Remove(blogID string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(blogID)
+	x.changed()
+}
+
+func (x *blogIndex) Invalidate() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.builtAt = time.Time{}
+}
+
+func (x *blogIndex) Related(blog *entities.Blog, limit int) []entities.RelatedMatch {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	id := blog.ID.Hex()
+	cached, ok := x.cache[id]
+	if !ok || !cached.updatedAt.Equal(blog.UpdatedAt) {
+		cached = cachedResult{updatedAt: blog.UpdatedAt, matches: x.match(id, newDocument(blog))}
+		x.cache[id] = cached
+	}
+	if limit > len(cached.matches) {
+		limit = len(cached.matches)
+	}
+	return cached.matches[:limit]
+}
+
+// match scores every other indexed blog against doc
+func (x *blogIndex) match(id string, doc *document) []entities.RelatedMatch {
+	if x.dirty {
+		for _, indexed := range x.docs {
+			indexed.vector = x.weigh(indexed.terms)
+		}
+		x.dirty = false
+	}
+	vector := x.weigh(doc.terms)
+
+	matches := []entities.RelatedMatch{}
+	for otherID, other := range x.docs {
+		if otherID == id {
+			continue
+		}
+		shared := []string{}
+		for tag := range doc.tags {
+			if other.tags[tag] {
+				shared = append(shared, tag)
+			}
+		}
+		tagScore := 0.0
+		if union := len(doc.tags) + len(other.tags) - len(shared); union > 0 {
+			tagScore = float64(len(shared)) / float64(union)
+		}
+		contentScore := 0.0
+		for term, weight := range vector {
+			contentScore += weight * other.vector[term]
+		}
+
+		score := tagWeight*tagScore + contentWeight*contentScore
+		if score <= 0 {
+			continue
+		}
+		sort.Strings(shared)
+		matches = append(matches, entities.RelatedMatch{BlogID: otherID, Score: math.Round(score*1000) / 1000, SharedTags: shared})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].BlogID > matches[j].BlogID
+	})
+	if len(matches) > cachedMatches {
+		matches = matches[:cachedMatches]
+	}
+	return matches
+}
+
+// weigh turns term frequencies into a unit-length TF-IDF vector
+func (x *blogIndex) weigh(terms map[string]int) map[string]float64 {
+	vector := make(map[string]float64, len(terms))
+	n := float64(len(x.docs))
+	var norm float64
+	for term, count := range terms {
+		idf := math.Log((n+1)/float64(x.df[term]+1)) + 1
+		weight := (1 + math.Log(float64(count))) * idf
+		vector[term] = weight
+		norm += weight * weight
+	}
+	norm = math.Sqrt(norm)
+	for term := range vector {
+		vector[term] /= norm
+	}
+	return vector
+}
+
+func (x *blogIndex) add(blog *entities.Blog) {
+	doc := newDocument(blog)
+	x.docs[blog.ID.Hex()] = doc
+	for term := range doc.terms {
+		x.df[term]++
+	}
+}
+
+func (x *blogIndex) remove(id string) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		if x.df[term]--; x.df[term] == 0 {
+			delete(x.df, term)
+		}
+	}
+	delete(x.docs, id)
+}
+
+// changed drops the cached results and marks the vectors out of date
+func (x *blogIndex) changed() {
+	x.dirty = true
+	x.cache = map[string]cachedResult{}
+}
+
+func newDocument(blog *entities.Blog) *document {
+	doc := &document{tags: map[string]bool{}, terms: map[string]int{}}
+	for _, tag := range blog.Tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			doc.tags[tag] = true
+		}
+	}
+	for _, term := range tokenize(blog.Title) {
+		doc.terms[term] += titleBoost
+	}
+	for _, term := range tokenize(blog.Content) {
+		doc.terms[term]++
+	}
+	return doc
+}
+
+// tokenize splits text into lower-case words, dropping short words, numbers and stop words
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) < minTermLength || stopWords[word] || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
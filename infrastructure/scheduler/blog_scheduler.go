@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+)
+
+// BlogScheduler periodically publishes and unpublishes blogs whose scheduled time has passed,
+// purges trashed blogs whose retention period has ended and recomputes the popularity scores
+type BlogScheduler struct {
+	UseCase            interfaces.BlogUseCaseInterface
+	Interval           time.Duration
+	PopularityInterval time.Duration
+
+	done chan struct{} // closed when the loop started by Start has returned
+}
+
+// LoadTrashRetention returns the trash retention period set with BLOG_TRASH_RETENTION, or retention when it is unset or invalid
+func LoadTrashRetention(retention time.Duration) time.Duration {
+	value := os.Getenv("BLOG_TRASH_RETENTION")
+	if value == "" {
+		return retention
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("⚠️ invalid BLOG_TRASH_RETENTION, using default of %s", retention)
+		return retention
+	}
+	return parsed
+}
+
+// NewBlogScheduler creates a scheduler; the tick interval is read from BLOG_SCHEDULER_INTERVAL (default 1m)
+// and the popularity recompute interval from BLOG_POPULARITY_INTERVAL (default 1h)
+func NewBlogScheduler(uc interfaces.BlogUseCaseInterface) *BlogScheduler {
+	interval := time.Minute
+	if value := os.Getenv("BLOG_SCHEDULER_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Println("⚠️ invalid BLOG_SCHEDULER_INTERVAL, using default of 1m")
+		}
+	}
+
+	popularityInterval := time.Hour
+	if value := os.Getenv("BLOG_POPULARITY_INTERVAL"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			popularityInterval = parsed
+		} else {
+			log.Println("⚠️ invalid BLOG_POPULARITY_INTERVAL, using default of 1h")
+		}
+	}
+	return &BlogScheduler{UseCase: uc, Interval: interval, PopularityInterval: popularityInterval}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled; Wait blocks until it has stopped
+func (s *BlogScheduler) Start(ctx context.Context) {
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+		popularityTicker := time.NewTicker(s.PopularityInterval)
+		defer popularityTicker.Stop()
+
+		// Catch up on anything that became due while the server was down
+		s.runOnce(ctx)
+		s.recomputePopularity(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runOnce(ctx)
+			case <-popularityTicker.C:
+				s.recomputePopularity(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the scheduler started by Start has finished its current run and stopped
+func (s *BlogScheduler) Wait() {
+	if s.done != nil {
+		<-s.done
+	}
+}
+
+func (s *BlogScheduler) runOnce(ctx context.Context) {
+	runCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	published, unpublished, err := s.UseCase.ApplyBlogSchedules(runCtx, time.Now())
+	if err != nil {
+		log.Println("❌ blog scheduler failed:", err)
+	} else if published > 0 || unpublished > 0 {
+		log.Printf("blog scheduler: %d published, %d unpublished", published, unpublished)
+	}
+
+	purged, err := s.UseCase.PurgeTrashedBlogs(runCtx, time.Now())
+	if err != nil {
+		log.Println("❌ trash purge failed:", err)
+	} else if purged > 0 {
+		log.Printf("blog scheduler: %d trashed blogs purged", purged)
+	}
+}
+
+// recomputePopularity refreshes the popularity scores so recency boosts expire on time
+func (s *BlogScheduler) recomputePopularity(ctx context.Context) {
+	runCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	if err := s.UseCase.RecomputePopularityScores(runCtx, time.Now()); err != nil {
+		log.Println("❌ popularity recompute failed:", err)
+	}
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// localBlogAssetStorage keeps the files of each blog in <basePath>/<blogID>
+type localBlogAssetStorage struct {
+	basePath string
+}
+
+func NewLocalBlogAssetStorage(basePath string) interfaces.BlogAssetStorage {
+	return &localBlogAssetStorage{basePath: basePath}
+}
+
+func (s *localBlogAssetStorage) DeleteBlogAssets(blogID string) error {
+	// Only well-formed IDs, so the path can never escape the base directory
+	if !primitive.IsValidObjectID(blogID) {
+		return errors.New("invalid blog ID")
+	}
+	if err := os.RemoveAll(filepath.Join(s.basePath, blogID)); err != nil {
+		return errors.New("failed to delete blog assets")
+	}
+	return nil
+}
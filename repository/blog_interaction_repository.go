@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
@@ -16,8 +17,25 @@ type blogInteractionRepository struct {
 	collection *mongo.Collection
 }
 
+// interactionIndexes are created when the repository starts
+var interactionIndexes = []mongo.IndexModel{
+	// Trending scans the interactions of a rolling window
+	{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index().SetName("interaction_created_at")},
+}
+
 func NewBlogInteractionRepositoryMongo(collection *mongo.Collection) interfaces.BlogInteractionRepositoryInterface {
-	return &blogInteractionRepository{collection: collection}
+	r := &blogInteractionRepository{collection: collection}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes creates the indexes the queries rely on; creating an existing index is a no-op
+func (r *blogInteractionRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := r.collection.Indexes().CreateMany(ctx, interactionIndexes); err != nil {
+		log.Println("⚠️ failed to create interaction indexes:", err)
+	}
 }
 
 func (r *blogInteractionRepository) AddInteraction(ctx context.Context, interaction *entities.BlogInteraction) error {
@@ -87,3 +105,85 @@ func (r *blogInteractionRepository) HasRecentView(ctx context.Context, blogID st
 	count, err := r.collection.CountDocuments(ctx, filter)
 	return count > 0, err
 }
+
+// DeleteInteractionsByBlogID removes all likes, dislikes and views of a blog
+func (r *blogInteractionRepository) DeleteInteractionsByBlogID(ctx context.Context, blogID string) error {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"blog_id": blogObjID})
+	return err
+}
+
+// GetTrendingBlogs scores the likes, dislikes, views and comments made within the window per blog,
+// decaying each by its age, and returns the listed blogs with the highest positive scores
+func (r *blogInteractionRepository) GetTrendingBlogs(ctx context.Context, query *entities.TrendingQuery) ([]*entities.TrendingBlog, error) {
+	weights := query.Weights
+	weight := bson.M{"$switch": bson.M{
+		"branches": bson.A{
+			bson.M{"case": bson.M{"$eq": bson.A{"$type", "like"}}, "then": weights.Likes},
+			bson.M{"case": bson.M{"$eq": bson.A{"$type", "dislike"}}, "then": weights.Dislikes},
+			bson.M{"case": bson.M{"$eq": bson.A{"$type", "view"}}, "then": weights.Views},
+			bson.M{"case": bson.M{"$eq": bson.A{"$type", "comment"}}, "then": weights.Comments},
+		},
+		"default": 0,
+	}}
+	ageHours := bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{query.Now, "$created_at"}}, float64(time.Hour / time.Millisecond)}}
+	decay := bson.M{"$pow": bson.A{bson.M{"$add": bson.A{bson.M{"$max": bson.A{ageHours, 0}}, 2}}, query.Gravity}}
+
+	blogMatch := listedFilter()
+	if query.Tag != "" {
+		blogMatch["tags"] = query.Tag
+	}
+
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"created_at": bson.M{"$gte": query.Since},
+			"type":       bson.M{"$in": bson.A{"like", "dislike", "view"}},
+		}},
+		// Comments count as interactions too
+		{"$unionWith": bson.M{
+			"coll": "comments",
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"created_at": bson.M{"$gte": query.Since}, "deleted": bson.M{"$ne": true}, "hidden": bson.M{"$ne": true}}},
+				bson.M{"$project": bson.M{"blog_id": 1, "created_at": 1, "type": bson.M{"$literal": "comment"}}},
+			},
+		}},
+		{"$group": bson.M{
+			"_id":            "$blog_id",
+			"trending_score": bson.M{"$sum": bson.M{"$divide": bson.A{weight, decay}}},
+			"interactions":   bson.M{"$sum": 1},
+		}},
+		{"$match": bson.M{"trending_score": bson.M{"$gt": 0}}},
+		{"$sort": bson.D{{Key: "trending_score", Value: -1}, {Key: "_id", Value: -1}}},
+		// Blogs are looked up in score order until enough listed ones are found
+		{"$lookup": bson.M{
+			"from": "blogs",
+			"let":  bson.M{"blog_id": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$blog_id"}}}},
+				bson.M{"$match": blogMatch},
+			},
+			"as": "blog",
+		}},
+		{"$unwind": "$blog"},
+		{"$limit": query.Limit},
+		{"$replaceRoot": bson.M{"newRoot": bson.M{"$mergeObjects": bson.A{
+			"$blog",
+			bson.M{"trending_score": "$trending_score", "interactions": "$interactions"},
+		}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	blogs := []*entities.TrendingBlog{}
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+	return blogs, nil
+}
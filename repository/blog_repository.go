@@ -2,6 +2,11 @@ package repository
 
 import (
 	"context"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
@@ -13,26 +18,166 @@ import (
 )
 
 type blogRepository struct {
-	collection *mongo.Collection
+	collection         *mongo.Collection
+	revisionCollection *mongo.Collection
 }
 
+// blogIndexes are created when the repository starts
+var blogIndexes = []mongo.IndexModel{
+	// Full-text search; titles weigh the most, then tags, then the content
+	{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "tags", Value: "text"}, {Key: "content", Value: "text"}},
+		Options: options.Index().
+			SetName("blog_text_search").
+			SetWeights(bson.D{{Key: "title", Value: 10}, {Key: "tags", Value: 5}, {Key: "content", Value: 1}}).
+			SetDefaultLanguage("english"),
+	},
+	// Keyset pagination of the list sorts (each index also serves the reverse order)
+	{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("blog_created_at_keyset")},
+	{Keys: bson.D{{Key: "view_count", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("blog_views_keyset")},
+	{Keys: bson.D{{Key: "like_count", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("blog_likes_keyset")},
+	{Keys: bson.D{{Key: "dislike_count", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("blog_dislikes_keyset")},
+	// Slugs identify blogs in URLs; the index stops concurrent creates and renames from sharing one.
+	// Blogs stored before slugs existed have none and are left out.
+	{
+		Keys:    bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().SetName("blog_slug").SetUnique(true).SetPartialFilterExpression(bson.M{"slug": bson.M{"$gt": ""}}),
+	},
+	// Old links redirect through the previous slugs
+	{Keys: bson.D{{Key: "old_slugs", Value: 1}}, Options: options.Index().SetName("blog_old_slugs")},
+	// Blogs of an author, as owner or co-author (author pages and author suggestions)
+	{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("blog_user")},
+	{Keys: bson.D{{Key: "co_authors", Value: 1}}, Options: options.Index().SetName("blog_co_authors")},
+	// Popular blogs are read straight off the materialized score
+	{Keys: bson.D{{Key: "popularity_score", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("blog_popularity")},
+	// Case-insensitive prefix lookups for search suggestions
+	{
+		Keys:    bson.D{{Key: "title", Value: 1}},
+		Options: options.Index().SetName("blog_title_prefix").SetCollation(suggestCollation),
+	},
+	{
+		Keys:    bson.D{{Key: "tags", Value: 1}},
+		Options: options.Index().SetName("blog_tags_prefix").SetCollation(suggestCollation),
+	},
+}
+
+// blogRevisionIndexes are created on the revision history when the repository starts
+var blogRevisionIndexes = []mongo.IndexModel{
+	// One snapshot per version of a blog, listed newest first; concurrent edits cannot share a version
+	{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "version", Value: -1}}, Options: options.Index().SetName("blog_revision_version").SetUnique(true)},
+}
+
+// userIndexes are created on the user collection for the author suggestions
+var userIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().SetName("user_username_prefix").SetCollation(suggestCollation),
+	},
+}
+
+// suggestCollation compares strings ignoring case, so prefix ranges can use the prefix indexes.
+// Queries must use the same collation as the index to be served by it.
+var suggestCollation = &options.Collation{Locale: "en", Strength: 2}
+
 func NewBlogRepositoryMongo(collection *mongo.Collection) interfaces.BlogRepositoryInterface {
-	return &blogRepository{collection: collection}
+	r := &blogRepository{
+		collection:         collection,
+		revisionCollection: collection.Database().Collection("blog_revisions"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes creates the indexes the queries rely on; creating an existing index is a no-op
+func (r *blogRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := r.collection.Indexes().CreateMany(ctx, blogIndexes); err != nil {
+		log.Println("⚠️ failed to create blog indexes:", err)
+	}
+	if _, err := r.revisionCollection.Indexes().CreateMany(ctx, blogRevisionIndexes); err != nil {
+		log.Println("⚠️ failed to create blog revision indexes:", err)
+	}
+	if _, err := r.userCollection().Indexes().CreateMany(ctx, userIndexes); err != nil {
+		log.Println("⚠️ failed to create user indexes:", err)
+	}
+}
+
+// userCollection is the collection authors are looked up in
+func (r *blogRepository) userCollection() *mongo.Collection {
+	return r.collection.Database().Collection("user")
 }
 
 func (r *blogRepository) CreateBlog(ctx context.Context, blog *entities.Blog) error {
-	_, err := r.collection.InsertOne(ctx, blog)
-	return err
+	if _, err := r.collection.InsertOne(ctx, blog); err != nil {
+		// The slug is the only unique field besides the generated ID
+		if mongo.IsDuplicateKeyError(err) {
+			return interfaces.ErrSlugTaken
+		}
+		return err
+	}
+	// The original content is revision 1
+	if err := r.insertRevision(ctx, blog, 1, blog.UserID, blog.CreatedAt); err != nil {
+		// A blog without its first revision could never be restored to it, so it is not kept either
+		if _, deleteErr := r.collection.DeleteOne(ctx, bson.M{"_id": blog.ID}); deleteErr != nil {
+			log.Println("⚠️ failed to remove a blog whose first revision was not saved:", deleteErr)
+		}
+		return err
+	}
+	return nil
 }
 
-// GetBlogsByUserID retrieves paginated blogs for a user
-func (r *blogRepository) GetBlogsByUserID(ctx context.Context, userID string, page int64, limit int64) ([]*entities.Blog, error) {
-	filter := bson.M{"user_id": userID}
-	if page < 1 {
-		page = 1
+// publishedFilter matches blogs that are visible to the public right now.
+// Blogs created before the status field existed have no status and are treated as published.
+// Scheduled blogs become visible as soon as publish_at passes and expired blogs disappear as
+// soon as unpublish_at passes, even if the scheduler has not flipped their status yet.
+// Trashed blogs are never visible.
+func publishedFilter() bson.M {
+	now := time.Now()
+	return bson.M{
+		"deleted_at": nil,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"status": bson.M{"$in": bson.A{entities.BlogStatusPublished, nil}}},
+				bson.M{"status": entities.BlogStatusScheduled, "publish_at": bson.M{"$lte": now}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"unpublish_at": nil},
+				bson.M{"unpublish_at": bson.M{"$gt": now}},
+			}},
+		},
+	}
+}
+
+// listedFilter matches published blogs that may appear in public listings.
+// Unlisted, private and password-protected blogs are only reachable by link.
+func listedFilter() bson.M {
+	filter := publishedFilter()
+	filter["visibility"] = bson.M{"$in": bson.A{entities.BlogVisibilityPublic, nil}}
+	return filter
+}
+
+// GetBlogsByUserID retrieves one page of the blogs a user owns or co-authors
+// When publishedOnly is false, drafts and archived blogs are included (author view)
+// Trashed blogs are listed separately by GetTrashedBlogsByUserID
+func (r *blogRepository) GetBlogsByUserID(ctx context.Context, userID string, query *entities.PageQuery, publishedOnly bool) ([]*entities.Blog, error) {
+	authorFilter := bson.M{"$or": bson.A{
+		bson.M{"user_id": userID},
+		bson.M{"co_authors": userID},
+	}}
+	filter := bson.M{"deleted_at": nil}
+	if publishedOnly {
+		filter = listedFilter()
+	}
+	filter["$and"] = append(bson.A{authorFilter}, andConditions(filter)...)
+
+	sortStage, past := keysetSort(query.Sort, query.Position)
+	opts := options.Find().SetSort(sortStage).SetLimit(int64(query.Limit))
+	if past != nil {
+		filter["$and"] = append(andConditions(filter), past)
+	} else if query.Skip > 0 {
+		opts.SetSkip(int64(query.Skip))
 	}
-	skip := (page - 1) * limit
-	opts := options.Find().SetSkip(skip).SetLimit(limit)
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
@@ -46,9 +191,18 @@ func (r *blogRepository) GetBlogsByUserID(ctx context.Context, userID string, pa
 		}
 		blogs = append(blogs, &blog)
 	}
+	reversePage(blogs, query.Position)
 	return blogs, cursor.Err()
 }
 
+// andConditions returns the $and conditions of a filter (none if it has no $and)
+func andConditions(filter bson.M) bson.A {
+	if conditions, ok := filter["$and"].(bson.A); ok {
+		return conditions
+	}
+	return nil
+}
+
 // GetBlogByID retrieves a single blog by its ID
 func (r *blogRepository) GetBlogByID(ctx context.Context, id string) (*entities.Blog, error) {
 	oid, err := primitive.ObjectIDFromHex(id)
@@ -63,47 +217,465 @@ func (r *blogRepository) GetBlogByID(ctx context.Context, id string) (*entities.
 	return &blog, nil
 }
 
-// UpdateBlog replaces an existing blog (matched by ID)
+// GetBlogsByIDs retrieves the blogs with the given IDs
+func (r *blogRepository) GetBlogsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*entities.Blog, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.findBlogs(ctx, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+// GetListedBlogs retrieves the title, content and tags of every blog that may appear in public listings
+func (r *blogRepository) GetListedBlogs(ctx context.Context) ([]*entities.Blog, error) {
+	projection := bson.M{"title": 1, "content": 1, "tags": 1, "user_id": 1, "updated_at": 1}
+	return r.findBlogs(ctx, listedFilter(), options.Find().SetProjection(projection))
+}
+
+// GetTagCounts counts the listed blogs per tag, most used first
+func (r *blogRepository) GetTagCounts(ctx context.Context) ([]entities.FacetCount, error) {
+	pipeline := []bson.M{
+		{"$match": listedFilter()},
+		{"$unwind": "$tags"},
+		{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := []entities.FacetCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ReplaceTags rewrites the tags of every blog (drafts and trashed blogs included) that uses one of from
+// to the tag to, keeping the order of the tags and dropping duplicates. It returns the number of blogs changed.
+func (r *blogRepository) ReplaceTags(ctx context.Context, from []string, to string) (int64, error) {
+	if len(from) == 0 {
+		return 0, nil
+	}
+	renamed := bson.M{"$map": bson.M{
+		"input": "$tags",
+		"in":    bson.M{"$cond": bson.A{bson.M{"$in": bson.A{"$$this", from}}, to, "$$this"}},
+	}}
+	deduplicated := bson.M{"$reduce": bson.M{
+		"input":        renamed,
+		"initialValue": bson.A{},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{"$$this", "$$value"}},
+			"$$value",
+			bson.M{"$concatArrays": bson.A{"$$value", bson.A{"$$this"}}},
+		}},
+	}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{"tags": deduplicated}}}}
+	result, err := r.collection.UpdateMany(ctx, bson.M{"tags": bson.M{"$in": from}}, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// GetDistinctTags returns every tag used by any blog (drafts and trashed blogs included)
+func (r *blogRepository) GetDistinctTags(ctx context.Context) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "tags", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(values))
+	for _, value := range values {
+		if tag, ok := value.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// GetBlogBySlug retrieves a blog by its current slug or one of its previous slugs
+func (r *blogRepository) GetBlogBySlug(ctx context.Context, slug string) (*entities.Blog, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"slug": slug},
+			bson.M{"old_slugs": slug},
+		},
+	}
+	var blog entities.Blog
+	if err := r.collection.FindOne(ctx, filter).Decode(&blog); err != nil {
+		return nil, err
+	}
+	return &blog, nil
+}
+
+// SlugExists reports whether a slug is used (now or previously) by any blog
+func (r *blogRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"slug": slug},
+			bson.M{"old_slugs": slug},
+		},
+	}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// UpdateBlog saves the editable fields of an existing blog (matched by ID) and stores the new content as a revision.
+// The revision is stored first: the unique (blog_id, version) index gives concurrent edits distinct versions,
+// and the blog only changes once its revision exists.
+// Counters, status, ownership and the other fields with endpoints of their own keep their stored values.
 func (r *blogRepository) UpdateBlog(ctx context.Context, blog *entities.Blog) error {
-	filter := bson.M{"_id": blog.ID}
-	_, err := r.collection.ReplaceOne(ctx, filter, blog)
+	var version int
+	for attempt := 1; ; attempt++ {
+		var err error
+		version, err = r.insertNextRevision(ctx, blog)
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt == maxRevisionAttempts {
+			return err
+		}
+	}
+
+	set := bson.M{
+		"title":        blog.Title,
+		"slug":         blog.Slug,
+		"old_slugs":    blog.OldSlugs,
+		"content":      blog.Content,
+		"content_html": blog.ContentHTML,
+		"excerpt":      blog.Excerpt,
+		"word_count":   blog.WordCount,
+		"reading_time": blog.ReadingTime,
+		"tags":         blog.Tags,
+		"updated_at":   blog.UpdatedAt,
+		"updated_by":   blog.UpdatedBy,
+	}
+	update := bson.M{"$set": set}
+	// Editing an approved draft withdraws the approval (ReviewStatusApprovalWithdrawn)
+	if blog.ReviewStatus == "" {
+		update["$unset"] = bson.M{"review_status": ""}
+	} else {
+		set["review_status"] = blog.ReviewStatus
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": blog.ID}, update); err != nil {
+		// The edit was not saved, so neither is its revision
+		if _, deleteErr := r.revisionCollection.DeleteOne(ctx, bson.M{"blog_id": blog.ID, "version": version}); deleteErr != nil {
+			log.Println("⚠️ failed to remove the revision of a failed blog update:", deleteErr)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return interfaces.ErrSlugTaken
+		}
+		return err
+	}
+	return nil
+}
+
+// insertNextRevision stores the blog's content under the next free version and returns that version;
+// it fails with a duplicate key error when a concurrent edit took that version first
+func (r *blogRepository) insertNextRevision(ctx context.Context, blog *entities.Blog) (int, error) {
+	latest, err := r.latestRevisionVersion(ctx, blog.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Blogs created before revisions existed: keep their current content as revision 1
+	if latest == 0 {
+		var previous entities.Blog
+		if err := r.collection.FindOne(ctx, bson.M{"_id": blog.ID}).Decode(&previous); err != nil {
+			return 0, err
+		}
+		if err := r.insertRevision(ctx, &previous, 1, previous.UserID, previous.UpdatedAt); err != nil {
+			return 0, err
+		}
+		latest = 1
+	}
+
+	editorID := blog.UpdatedBy
+	if editorID == "" {
+		editorID = blog.UserID
+	}
+	return latest + 1, r.insertRevision(ctx, blog, latest+1, editorID, blog.UpdatedAt)
+}
+
+// GetBlogRevisions lists all revisions of a blog, newest first
+func (r *blogRepository) GetBlogRevisions(ctx context.Context, blogID string) ([]*entities.BlogRevision, error) {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := r.revisionCollection.Find(ctx, bson.M{"blog_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var revisions []*entities.BlogRevision
+	for cursor.Next(ctx) {
+		var revision entities.BlogRevision
+		if err := cursor.Decode(&revision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, &revision)
+	}
+	return revisions, cursor.Err()
+}
+
+// GetBlogRevision retrieves a single revision of a blog by version number
+func (r *blogRepository) GetBlogRevision(ctx context.Context, blogID string, version int) (*entities.BlogRevision, error) {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	var revision entities.BlogRevision
+	filter := bson.M{"blog_id": oid, "version": version}
+	if err := r.revisionCollection.FindOne(ctx, filter).Decode(&revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// latestRevisionVersion returns the highest stored revision number of a blog (0 if none)
+func (r *blogRepository) latestRevisionVersion(ctx context.Context, blogID primitive.ObjectID) (int, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	var revision entities.BlogRevision
+	err := r.revisionCollection.FindOne(ctx, bson.M{"blog_id": blogID}, opts).Decode(&revision)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return revision.Version, nil
+}
+
+// insertRevision stores an immutable snapshot of the blog's editable content
+func (r *blogRepository) insertRevision(ctx context.Context, blog *entities.Blog, version int, editorID string, createdAt time.Time) error {
+	revision := &entities.BlogRevision{
+		ID:        primitive.NewObjectID(),
+		BlogID:    blog.ID,
+		Version:   version,
+		Title:     blog.Title,
+		Content:   blog.Content,
+		Tags:      blog.Tags,
+		EditorID:  editorID,
+		CreatedAt: createdAt,
+	}
+	_, err := r.revisionCollection.InsertOne(ctx, revision)
 	return err
 }
 
-// DeleteBlog deletes a blog by its ID
-func (r *blogRepository) DeleteBlog(ctx context.Context, id string) error {
-	oid, err := primitive.ObjectIDFromHex(id)
+// UpdateBlogStatus sets the lifecycle status (and publish timestamp) of a blog
+func (r *blogRepository) UpdateBlogStatus(ctx context.Context, blogID string, status entities.BlogStatus, publishedAt *time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
 		return err
 	}
-	filter := bson.M{"_id": oid}
-	_, err = r.collection.DeleteOne(ctx, filter)
+
+	// Trashed blogs keep their status until they are restored
+	filter := bson.M{"_id": oid, "deleted_at": nil}
+	set := bson.M{
+		"status":     status,
+		"updated_at": time.Now(),
+	}
+	if publishedAt != nil {
+		set["published_at"] = *publishedAt
+	}
+
+	// A manual status change cancels any pending scheduled publication
+	update := bson.M{
+		"$set":   set,
+		"$unset": bson.M{"publish_at": ""},
+	}
+	_, err = r.collection.UpdateOne(ctx, filter, update)
 	return err
 }
 
-// UpdateBlogCounters increments/decrements the interaction counters for a blog
-func (r *blogRepository) UpdateBlogCounters(ctx context.Context, blogID string, likeChange int, dislikeChange int, viewChange int) error {
+// UpdateBlogSchedule stores the publish/unpublish schedule of a blog
+// Nil times are removed from the document
+func (r *blogRepository) UpdateBlogSchedule(ctx context.Context, blogID string, status entities.BlogStatus, publishAt *time.Time, unpublishAt *time.Time) error {
 	oid, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
 		return err
 	}
 
-	filter := bson.M{"_id": oid}
+	set := bson.M{
+		"status":     status,
+		"updated_at": time.Now(),
+	}
+	unset := bson.M{}
+	if publishAt != nil {
+		set["publish_at"] = *publishAt
+	} else {
+		unset["publish_at"] = ""
+	}
+	if unpublishAt != nil {
+		set["unpublish_at"] = *unpublishAt
+	} else {
+		unset["unpublish_at"] = ""
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid, "deleted_at": nil}, update)
+	return err
+}
+
+// PublishDueBlogs publishes every scheduled blog whose publish_at has passed; trashed blogs wait until they are restored
+func (r *blogRepository) PublishDueBlogs(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{
+		"status":     entities.BlogStatusScheduled,
+		"publish_at": bson.M{"$lte": now},
+		"deleted_at": nil,
+	}
+	// Pipeline update so published_at can be copied from publish_at
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"status":       entities.BlogStatusPublished,
+			"published_at": bson.M{"$ifNull": bson.A{"$published_at", "$publish_at"}},
+			"updated_at":   now,
+		}},
+		bson.M{"$unset": "publish_at"},
+	}
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// UnpublishExpiredBlogs archives every blog whose unpublish_at has passed.
+// Trashed blogs keep their schedule until they are restored.
+func (r *blogRepository) UnpublishExpiredBlogs(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{
+		"status":       bson.M{"$in": bson.A{entities.BlogStatusPublished, entities.BlogStatusScheduled, nil}},
+		"unpublish_at": bson.M{"$lte": now},
+		"deleted_at":   nil,
+	}
 	update := bson.M{
-		"$inc": bson.M{
-			"like_count":    likeChange,
-			"dislike_count": dislikeChange,
-			"view_count":    viewChange,
+		"$set": bson.M{
+			"status":     entities.BlogStatusArchived,
+			"updated_at": now,
 		},
+		"$unset": bson.M{"publish_at": "", "unpublish_at": ""},
+	}
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
 	}
+	return result.ModifiedCount, nil
+}
 
-	_, err = r.collection.UpdateOne(ctx, filter, update)
+// UpdateBlogReviewStatus stores the review state of a blog
+func (r *blogRepository) UpdateBlogReviewStatus(ctx context.Context, blogID string, status entities.ReviewStatus, round int, submittedAt *time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	set := bson.M{
+		"review_status": status,
+		"review_round":  round,
+		"updated_at":    time.Now(),
+	}
+	if submittedAt != nil {
+		set["submitted_at"] = *submittedAt
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": set})
 	return err
 }
 
-// GetAllBlogs retrieves all blogs for popularity calculation
-func (r *blogRepository) GetAllBlogs(ctx context.Context) ([]*entities.Blog, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
+// GetBlogsByReviewStatus lists blogs in a review state, oldest submission first
+func (r *blogRepository) GetBlogsByReviewStatus(ctx context.Context, status entities.ReviewStatus, page int64, limit int64) ([]*entities.Blog, error) {
+	if page < 1 {
+		page = 1
+	}
+	filter := bson.M{"review_status": status, "deleted_at": nil}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "submitted_at", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	return r.findBlogs(ctx, filter, opts)
+}
+
+// UpdateBlogCoAuthors replaces the co-author list of a blog
+func (r *blogRepository) UpdateBlogCoAuthors(ctx context.Context, blogID string, coAuthors []string) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"co_authors": coAuthors, "updated_at": time.Now()}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
+
+// UpdateBlogVisibility changes who can read a blog; the password hash is removed unless one is given
+func (r *blogRepository) UpdateBlogVisibility(ctx context.Context, blogID string, visibility entities.BlogVisibility, passwordHash string) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"visibility": visibility, "updated_at": time.Now()}}
+	if passwordHash != "" {
+		update["$set"].(bson.M)["password_hash"] = passwordHash
+	} else {
+		update["$unset"] = bson.M{"password_hash": ""}
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
+
+// TrashBlog marks a blog as deleted; it is permanently removed after purgeAt
+func (r *blogRepository) TrashBlog(ctx context.Context, id string, deletedAt time.Time, purgeAt time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": oid, "deleted_at": nil}
+	update := bson.M{"$set": bson.M{"deleted_at": deletedAt, "purge_at": purgeAt}}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RestoreBlog takes a blog out of the trash
+func (r *blogRepository) RestoreBlog(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": "", "purge_at": ""},
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
+
+// GetTrashedBlogsByUserID lists a user's trashed blogs, most recently deleted first
+func (r *blogRepository) GetTrashedBlogsByUserID(ctx context.Context, userID string) ([]*entities.Blog, error) {
+	filter := bson.M{"user_id": userID, "deleted_at": bson.M{"$ne": nil}}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+	return r.findBlogs(ctx, filter, opts)
+}
+
+// GetExpiredTrashedBlogs lists trashed blogs whose purge time has passed
+func (r *blogRepository) GetExpiredTrashedBlogs(ctx context.Context, now time.Time) ([]*entities.Blog, error) {
+	filter := bson.M{"deleted_at": bson.M{"$ne": nil}, "purge_at": bson.M{"$lte": now}}
+	return r.findBlogs(ctx, filter)
+}
+
+func (r *blogRepository) findBlogs(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*entities.Blog, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -120,10 +692,143 @@ func (r *blogRepository) GetAllBlogs(ctx context.Context) ([]*entities.Blog, err
 	return blogs, cursor.Err()
 }
 
-// FilterBlogs filters blogs based on provided criteria
-func (r *blogRepository) FilterBlogs(ctx context.Context, filter *entities.BlogFilter) ([]*entities.Blog, int64, error) {
-	// Build MongoDB filter
-	mongoFilter := bson.M{}
+// DeleteBlog permanently deletes a blog and its revisions by its ID
+func (r *blogRepository) DeleteBlog(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	if _, err := r.revisionCollection.DeleteMany(ctx, bson.M{"blog_id": oid}); err != nil {
+		return err
+	}
+	filter := bson.M{"_id": oid}
+	_, err = r.collection.DeleteOne(ctx, filter)
+	return err
+}
+
+// UpdateBlogCounters increments/decrements the interaction counters for a blog,
+// moving its materialized popularity score along in the same update
+func (r *blogRepository) UpdateBlogCounters(ctx context.Context, blogID string, change entities.CounterChange, scoreChange float64) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": oid}
+	update := bson.M{
+		"$inc": bson.M{
+			"like_count":       change.Likes,
+			"dislike_count":    change.Dislikes,
+			"view_count":       change.Views,
+			"comment_count":    change.Comments,
+			"popularity_score": scoreChange,
+		},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// SetCommentsLocked locks or unlocks the comments of a blog
+func (r *blogRepository) SetCommentsLocked(ctx context.Context, blogID string, locked bool) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"comments_locked": locked}})
+	return err
+}
+
+// ReservePinnedSlot counts one more pinned comment on a blog unless it already has limit of them.
+// The limit is checked in the update filter, so concurrent pins cannot exceed it.
+func (r *blogRepository) ReservePinnedSlot(ctx context.Context, blogID string, limit int) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.M{
+		"_id": oid,
+		"$or": []bson.M{
+			{"pinned_comment_count": bson.M{"$lt": limit}},
+			{"pinned_comment_count": bson.M{"$exists": false}},
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"pinned_comment_count": 1}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ReleasePinnedSlot counts one pinned comment less on a blog
+func (r *blogRepository) ReleasePinnedSlot(ctx context.Context, blogID string) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": oid, "pinned_comment_count": bson.M{"$gt": 0}}
+	_, err = r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"pinned_comment_count": -1}})
+	return err
+}
+
+// RecomputePopularityScores recounts the comments of every blog and rewrites its popularity score,
+// so recency boosts expire and any drift of the incremental updates is corrected.
+// The scores are computed and written back by the server in one aggregation.
+func (r *blogRepository) RecomputePopularityScores(ctx context.Context, scoring entities.PopularityScoring, now time.Time) error {
+	pipeline := []bson.M{
+		{"$match": bson.M{"deleted_at": nil}},
+		commentStatsLookup(),
+		{"$set": bson.M{"comment_count": bson.M{"$ifNull": bson.A{bson.M{"$first": "$comment_stats.comments"}, 0}}}},
+		{"$set": bson.M{"popularity_score": bson.M{"$add": bson.A{
+			weightedScore(scoring.Weights, "$comment_count"),
+			recencyBoost(scoring.Recency, now),
+		}}}},
+		{"$project": bson.M{"comment_count": 1, "popularity_score": 1}},
+		{"$merge": bson.M{"into": r.collection.Name(), "on": "_id", "whenMatched": "merge", "whenNotMatched": "discard"}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+// recencyBoost is the expression of the boost of the first recency tier the created_at of a blog falls in
+func recencyBoost(tiers []entities.RecencyBoost, now time.Time) interface{} {
+	if len(tiers) == 0 {
+		return 0
+	}
+	branches := bson.A{}
+	for _, tier := range tiers {
+		branches = append(branches, bson.M{
+			"case": bson.M{"$gte": bson.A{"$created_at", now.Add(-tier.Within)}},
+			"then": tier.Boost,
+		})
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": 0}}
+}
+
+// GetPopularBlogs returns the listed blogs with the highest materialized popularity scores
+func (r *blogRepository) GetPopularBlogs(ctx context.Context, limit int64) ([]*entities.BlogWithPopularity, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "popularity_score", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, listedFilter(), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	blogs := []*entities.BlogWithPopularity{}
+	if err := cursor.All(ctx, &blogs); err != nil {
+		return nil, err
+	}
+	return blogs, nil
+}
+
+// filterQuery builds the MongoDB filter of a BlogFilter (only published, public blogs are listed)
+func filterQuery(filter *entities.BlogFilter) bson.M {
+	mongoFilter := listedFilter()
 
 	// Filter by tags
 	if len(filter.Tags) > 0 {
@@ -141,35 +846,12 @@ func (r *blogRepository) FilterBlogs(ctx context.Context, filter *entities.BlogF
 		}
 		mongoFilter["created_at"] = dateFilter
 	}
+	return mongoFilter
+}
 
-	// Build sort options
-	sortOptions := bson.D{}
-	if filter.PopularitySort != "" {
-		sortField := "view_count"
-		switch filter.PopularitySort {
-		case "likes":
-			sortField = "like_count"
-		case "dislikes":
-			sortField = "dislike_count"
-		case "engagement":
-			// For engagement, we'll use a combination (likes - dislikes + views)
-			// MongoDB doesn't directly support computed sort, so we'll sort by likes first
-			sortField = "like_count"
-		case "views":
-			sortField = "view_count"
-		}
-
-		sortOrder := -1 // desc by default
-		if filter.SortOrder == "asc" {
-			sortOrder = 1
-		}
-		sortOptions = append(sortOptions, bson.E{Key: sortField, Value: sortOrder})
-	}
-
-	// Default sort by created_at if no popularity sort
-	if len(sortOptions) == 0 {
-		sortOptions = append(sortOptions, bson.E{Key: "created_at", Value: -1})
-	}
+// FilterBlogs filters blogs based on provided criteria
+func (r *blogRepository) FilterBlogs(ctx context.Context, filter *entities.BlogFilter) ([]*entities.ScoredBlog, int64, error) {
+	mongoFilter := filterQuery(filter)
 
 	// Get total count for pagination info
 	totalCount, err := r.collection.CountDocuments(ctx, mongoFilter)
@@ -177,60 +859,361 @@ func (r *blogRepository) FilterBlogs(ctx context.Context, filter *entities.BlogF
 		return nil, 0, err
 	}
 
-	// Build find options
-	findOptions := options.Find().SetSort(sortOptions)
-	if filter.Limit > 0 {
-		findOptions.SetLimit(int64(filter.Limit))
+	// Engagement scores are computed before sorting; a cursor selects the blogs past it instead of skipping
+	pipeline := []bson.M{{"$match": mongoFilter}}
+	if filter.Sort.Field == entities.SortByEngagement {
+		pipeline = append(pipeline, engagementStages(filter.Sort.Weights)...)
+	}
+	sortStage, past := keysetSort(filter.Sort, filter.Position)
+	if past != nil {
+		pipeline = append(pipeline, bson.M{"$match": past})
 	}
-	if filter.Skip > 0 {
-		findOptions.SetSkip(int64(filter.Skip))
+	pipeline = append(pipeline, bson.M{"$sort": sortStage})
+	if past == nil && filter.Skip > 0 {
+		pipeline = append(pipeline, bson.M{"$skip": filter.Skip})
+	}
+	if filter.Limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": filter.Limit})
 	}
 
-	cursor, err := r.collection.Find(ctx, mongoFilter, findOptions)
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer cursor.Close(ctx)
 
-	var blogs []*entities.Blog
+	var blogs []*entities.ScoredBlog
 	for cursor.Next(ctx) {
-		var blog entities.Blog
+		var blog entities.ScoredBlog
 		if err := cursor.Decode(&blog); err != nil {
 			return nil, 0, err
 		}
 		blogs = append(blogs, &blog)
 	}
+	reversePage(blogs, filter.Position)
 
 	return blogs, totalCount, cursor.Err()
 }
 
-// SearchBlogs searches for blogs based on title and/or author name
-func (r *blogRepository) SearchBlogs(ctx context.Context, search *entities.BlogSearch) ([]*entities.BlogWithAuthor, int64, error) {
+// engagementStages count the comments of each blog and compute its engagement score:
+// the weighted sum of its likes, dislikes, views and comments
+func engagementStages(weights entities.EngagementWeights) []bson.M {
+	return []bson.M{
+		commentStatsLookup(),
+		{"$addFields": bson.M{"engagement_score": weightedScore(weights, bson.M{"$first": "$comment_stats.comments"})}},
+		{"$project": bson.M{"comment_stats": 0}},
+	}
+}
+
+// commentStatsLookup counts the comments of each blog into comment_stats.comments; deleted placeholders and hidden comments do not count
+func commentStatsLookup() bson.M {
+	return bson.M{"$lookup": bson.M{
+		"from": "comments",
+		"let":  bson.M{"blog_id": "$_id"},
+		"pipeline": bson.A{
+			bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$blog_id", "$$blog_id"}}, "deleted": bson.M{"$ne": true}, "hidden": bson.M{"$ne": true}}},
+			bson.M{"$count": "comments"},
+		},
+		"as": "comment_stats",
+	}}
+}
+
+// weightedScore is the expression of the engagement score of a blog with the given comment count
+func weightedScore(weights entities.EngagementWeights, comments interface{}) bson.M {
+	weighted := func(count interface{}, weight float64) bson.M {
+		return bson.M{"$multiply": bson.A{bson.M{"$ifNull": bson.A{count, 0}}, weight}}
+	}
+	return bson.M{"$add": bson.A{
+		weighted("$like_count", weights.Likes),
+		weighted("$dislike_count", weights.Dislikes),
+		weighted("$view_count", weights.Views),
+		weighted(comments, weights.Comments),
+	}}
+}
+
+// facetLimit is the number of tags and authors returned in facets
+const facetLimit = 10
+
+// facetMonths is the number of most recent months returned in facets
+const facetMonths = 24
+
+// blogFacetStages are the $facet branches counting the matched blogs per tag, author and month
+func blogFacetStages() bson.M {
+	byCountThenValue := bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}
+	return bson.M{
+		"tags": bson.A{
+			bson.M{"$unwind": "$tags"},
+			bson.M{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": byCountThenValue},
+			bson.M{"$limit": facetLimit},
+		},
+		// Owners and co-authors both count as authors of a blog
+		"authors": bson.A{
+			bson.M{"$project": bson.M{"author_ids": bson.M{"$setUnion": bson.A{
+				bson.A{"$user_id"},
+				bson.M{"$ifNull": bson.A{"$co_authors", bson.A{}}},
+			}}}},
+			bson.M{"$unwind": "$author_ids"},
+			bson.M{"$group": bson.M{"_id": "$author_ids", "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": byCountThenValue},
+			bson.M{"$limit": facetLimit},
+			bson.M{"$lookup": bson.M{
+				"from": "user",
+				"let":  bson.M{"author_id": "$_id"},
+				"pipeline": bson.A{
+					bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{bson.M{"$toString": "$_id"}, "$$author_id"}}}},
+					bson.M{"$project": bson.M{"username": 1}},
+				},
+				"as": "user",
+			}},
+			bson.M{"$project": bson.M{
+				"_id":   bson.M{"$ifNull": bson.A{bson.M{"$first": "$user.username"}, "Unknown Author"}},
+				"id":    "$_id",
+				"count": 1,
+			}},
+		},
+		"months": bson.A{
+			bson.M{"$group": bson.M{
+				"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$created_at"}},
+				"count": bson.M{"$sum": 1},
+			}},
+			bson.M{"$sort": bson.D{{Key: "_id", Value: -1}}},
+			bson.M{"$limit": facetMonths},
+		},
+	}
+}
+
+// aggregateFacets counts the blogs selected by the pipeline per tag, author and month
+func (r *blogRepository) aggregateFacets(ctx context.Context, pipeline []bson.M) (*entities.SearchFacets, error) {
+	stages := append(append([]bson.M{}, pipeline...), bson.M{"$facet": blogFacetStages()})
+	cursor, err := r.collection.Aggregate(ctx, stages)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	facets := &entities.SearchFacets{}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(facets); err != nil {
+			return nil, err
+		}
+	}
+	return facets, cursor.Err()
+}
+
+// GetFilterFacets counts the blogs matching a filter per tag, author and month
+func (r *blogRepository) GetFilterFacets(ctx context.Context, filter *entities.BlogFilter) (*entities.SearchFacets, error) {
+	return r.aggregateFacets(ctx, []bson.M{{"$match": filterQuery(filter)}})
+}
+
+// GetSearchFacets counts the blogs matching a search per tag, author and month
+func (r *blogRepository) GetSearchFacets(ctx context.Context, search *entities.BlogSearch) (*entities.SearchFacets, error) {
+	pipeline := searchPipeline(search)
+	return r.aggregateFacets(ctx, pipeline)
+}
+
+// prefixRange matches strings starting with prefix under suggestCollation;
+// U+FFFF sorts after every other character, so it closes the range
+func prefixRange(prefix string) bson.M {
+	return bson.M{"$gte": prefix, "$lt": prefix + "\uffff"}
+}
+
+// suggestCandidates caps the usernames considered for author suggestions before ranking
+const suggestCandidates = 100
+
+// aggregateSuggestions runs a suggestion pipeline with the collation of the prefix indexes
+func aggregateSuggestions(ctx context.Context, collection *mongo.Collection, pipeline []bson.M) ([]entities.Suggestion, error) {
+	cursor, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetCollation(suggestCollation))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	suggestions := []entities.Suggestion{}
+	if err := cursor.All(ctx, &suggestions); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+// SuggestTitles returns listed blogs whose title starts with prefix (case-insensitive),
+// ranked by their stored popularity score
+func (r *blogRepository) SuggestTitles(ctx context.Context, prefix string, limit int) ([]entities.Suggestion, error) {
+	match := listedFilter()
+	match["title"] = prefixRange(prefix)
+	return aggregateSuggestions(ctx, r.collection, []bson.M{
+		{"$match": match},
+		{"$addFields": bson.M{"popularity": bson.M{"$ifNull": bson.A{"$popularity_score", 0}}}},
+		{"$sort": bson.D{{Key: "popularity", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$limit": limit},
+		{"$project": bson.M{"_id": 0, "value": "$title", "id": bson.M{"$toString": "$_id"}, "slug": 1, "popularity": 1}},
+	})
+}
+
+// SuggestTags returns tags of listed blogs starting with prefix (case-insensitive), ranked by the number of blogs using them
+func (r *blogRepository) SuggestTags(ctx context.Context, prefix string, limit int) ([]entities.Suggestion, error) {
+	match := listedFilter()
+	match["tags"] = prefixRange(prefix)
+	return aggregateSuggestions(ctx, r.collection, []bson.M{
+		{"$match": match},
+		{"$unwind": "$tags"},
+		{"$match": bson.M{"tags": prefixRange(prefix)}},
+		{"$group": bson.M{"_id": "$tags", "popularity": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "popularity", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$limit": limit},
+		{"$project": bson.M{"_id": 0, "value": "$_id", "popularity": 1}},
+	})
+}
+
+// SuggestAuthors returns users whose username starts with prefix (case-insensitive) and who own or
+// co-author listed blogs, ranked by the number of those blogs.
+// The candidates come off the username prefix index and their blogs are counted in a single
+// aggregation over the user_id and co_authors indexes, instead of one lookup per candidate.
+func (r *blogRepository) SuggestAuthors(ctx context.Context, prefix string, limit int) ([]entities.Suggestion, error) {
+	opts := options.Find().
+		SetCollation(suggestCollation).
+		SetSort(bson.D{{Key: "username", Value: 1}}).
+		SetLimit(suggestCandidates).
+		SetProjection(bson.M{"username": 1})
+	cursor, err := r.userCollection().Find(ctx, bson.M{"username": prefixRange(prefix)}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []struct {
+		ID       primitive.ObjectID `bson:"_id"`
+		Username string             `bson:"username"`
+	}
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return []entities.Suggestion{}, nil
+	}
+
+	ids := make(bson.A, 0, len(candidates))
+	usernames := make(map[string]string, len(candidates))
+	for _, candidate := range candidates {
+		id := candidate.ID.Hex()
+		ids = append(ids, id)
+		usernames[id] = candidate.Username
+	}
+
+	match := listedFilter()
+	match["$and"] = append(andConditions(match), bson.M{"$or": bson.A{
+		bson.M{"user_id": bson.M{"$in": ids}},
+		bson.M{"co_authors": bson.M{"$in": ids}},
+	}})
+	countCursor, err := r.collection.Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$project": bson.M{"authors": bson.M{"$setUnion": bson.A{bson.A{"$user_id"}, bson.M{"$ifNull": bson.A{"$co_authors", bson.A{}}}}}}},
+		{"$unwind": "$authors"},
+		{"$match": bson.M{"authors": bson.M{"$in": ids}}},
+		{"$group": bson.M{"_id": "$authors", "blogs": bson.M{"$sum": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var counts []struct {
+		ID    string  `bson:"_id"`
+		Blogs float64 `bson:"blogs"`
+	}
+	if err := countCursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]entities.Suggestion, 0, len(counts))
+	for _, count := range counts {
+		suggestions = append(suggestions, entities.Suggestion{Value: usernames[count.ID], ID: count.ID, Popularity: count.Blogs})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Popularity != suggestions[j].Popularity {
+			return suggestions[i].Popularity > suggestions[j].Popularity
+		}
+		return strings.ToLower(suggestions[i].Value) < strings.ToLower(suggestions[j].Value)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// searchQueryConditions translates a parsed search query into a $text search string and match conditions.
+// Author clauses can only be checked after the user lookup, so they are returned separately.
+func searchQueryConditions(query *entities.SearchQuery) (string, bson.A, bson.A) {
+	comparisons := map[entities.SearchOperator]string{
+		entities.SearchOperatorEqual:        "$eq",
+		entities.SearchOperatorGreater:      "$gt",
+		entities.SearchOperatorGreaterEqual: "$gte",
+		entities.SearchOperatorLess:         "$lt",
+		entities.SearchOperatorLessEqual:    "$lte",
+	}
+	counters := map[entities.SearchField]string{
+		entities.SearchFieldLikes:    "like_count",
+		entities.SearchFieldDislikes: "dislike_count",
+		entities.SearchFieldViews:    "view_count",
+	}
+
+	var textTerms []string
+	conditions, authorConditions := bson.A{}, bson.A{}
+	for _, clause := range query.Clauses {
+		switch clause.Field {
+		case entities.SearchFieldText:
+			// Text clauses only hold letters, digits and spaces (see the use case parser)
+			if clause.Phrase {
+				textTerms = append(textTerms, `"`+clause.Text+`"`)
+			} else {
+				textTerms = append(textTerms, clause.Text)
+			}
+		case entities.SearchFieldTag:
+			conditions = append(conditions, bson.M{"tags": clause.Text})
+		case entities.SearchFieldTitle:
+			conditions = append(conditions, bson.M{"title": bson.M{"$regex": regexp.QuoteMeta(clause.Text), "$options": "i"}})
+		case entities.SearchFieldAfter:
+			conditions = append(conditions, bson.M{"created_at": bson.M{"$gte": *clause.Date}})
+		case entities.SearchFieldBefore:
+			conditions = append(conditions, bson.M{"created_at": bson.M{"$lt": *clause.Date}})
+		case entities.SearchFieldLikes, entities.SearchFieldDislikes, entities.SearchFieldViews:
+			conditions = append(conditions, bson.M{counters[clause.Field]: bson.M{comparisons[clause.Operator]: clause.Number}})
+		case entities.SearchFieldAuthor:
+			authorConditions = append(authorConditions, bson.M{"authors": bson.M{"$regex": regexp.QuoteMeta(clause.Text), "$options": "i"}})
+		}
+	}
+	return strings.Join(textTerms, " "), conditions, authorConditions
+}
+
+// searchPipeline builds the aggregation stages that select the blogs of a search, with their authors looked up.
+func searchPipeline(search *entities.BlogSearch) []bson.M {
 	// Build aggregation pipeline for searching with author lookup
 	pipeline := []bson.M{}
 	
-	// Match stage - build search criteria
-	matchStage := bson.M{}
-	searchConditions := []bson.M{}
-	
-	// Search by title (case-insensitive partial match)
+	// Match stage - build search criteria (only published, public blogs are searchable)
+	matchStage := listedFilter()
+
+	// Query language clauses; full-text search over title, content and tags must be part of the first stage
+	textSearch, authorConditions := "", bson.A{}
+	if search.Parsed != nil {
+		var conditions bson.A
+		textSearch, conditions, authorConditions = searchQueryConditions(search.Parsed)
+		matchStage["$and"] = append(andConditions(matchStage), conditions...)
+	}
+	if textSearch != "" {
+		matchStage["$text"] = bson.M{"$search": textSearch}
+	}
+
+	// Search by title (case-insensitive partial match; the input is matched literally)
 	if search.Title != "" {
-		searchConditions = append(searchConditions, bson.M{
+		matchStage["$and"] = append(andConditions(matchStage), bson.M{
 			"title": bson.M{
-				"$regex":   search.Title,
+				"$regex":   regexp.QuoteMeta(search.Title),
 				"$options": "i", // case-insensitive
 			},
 		})
 	}
-	
+
 	// Search by author requires user lookup, so we'll add author filter after lookup
-	if len(searchConditions) > 0 {
-		if len(searchConditions) == 1 {
-			matchStage = searchConditions[0]
-		} else {
-			matchStage["$and"] = searchConditions
-		}
-		pipeline = append(pipeline, bson.M{"$match": matchStage})
+	pipeline = append(pipeline, bson.M{"$match": matchStage})
+	if textSearch != "" {
+		pipeline = append(pipeline, bson.M{"$addFields": bson.M{"score": bson.M{"$meta": "textScore"}}})
 	}
 	
 	// Convert string user_id to ObjectID for lookup compatibility
@@ -282,19 +1265,64 @@ func (r *blogRepository) SearchBlogs(ctx context.Context, search *entities.BlogS
 			},
 		},
 	})
-	
-	// Filter by author name if specified (after lookup)
-	if search.Author != "" {
-		pipeline = append(pipeline, bson.M{
-			"$match": bson.M{
-				"author_name": bson.M{
-					"$regex":   search.Author,
-					"$options": "i", // case-insensitive
+
+	// Convert valid co-author IDs to ObjectIDs and look up their usernames
+	pipeline = append(pipeline, bson.M{
+		"$addFields": bson.M{
+			"co_author_ids": bson.M{
+				"$map": bson.M{
+					"input": bson.M{
+						"$filter": bson.M{
+							"input": bson.M{"$ifNull": []interface{}{"$co_authors", bson.A{}}},
+							"cond":  bson.M{"$eq": []interface{}{bson.M{"$strLenCP": "$$this"}, 24}},
+						},
+					},
+					"in": bson.M{"$toObjectId": "$$this"},
 				},
 			},
+		},
+	})
+	pipeline = append(pipeline, bson.M{
+		"$lookup": bson.M{
+			"from":         "user",
+			"localField":   "co_author_ids",
+			"foreignField": "_id",
+			"as":           "co_author_docs",
+		},
+	})
+
+	// authors lists the owner first, then the co-authors
+	pipeline = append(pipeline, bson.M{
+		"$addFields": bson.M{
+			"authors": bson.M{
+				"$concatArrays": bson.A{
+					bson.A{"$author_name"},
+					"$co_author_docs.username",
+				},
+			},
+		},
+	})
+
+	// Filter by author name if specified (after lookup); any author matches
+	if search.Author != "" {
+		authorConditions = append(authorConditions, bson.M{
+			"authors": bson.M{
+				"$regex":   regexp.QuoteMeta(search.Author),
+				"$options": "i", // case-insensitive
+			},
 		})
 	}
-	
+	if len(authorConditions) > 0 {
+		pipeline = append(pipeline, bson.M{"$match": bson.M{"$and": authorConditions}})
+	}
+
+	return pipeline
+}
+
+// SearchBlogs searches for blogs based on title and/or author name
+func (r *blogRepository) SearchBlogs(ctx context.Context, search *entities.BlogSearch) ([]*entities.BlogWithAuthor, int64, error) {
+	pipeline := searchPipeline(search)
+
 	// Count total documents (before skip/limit)
 	countPipeline := append(pipeline, bson.M{"$count": "total"})
 	countCursor, err := r.collection.Aggregate(ctx, countPipeline)
@@ -315,8 +1343,19 @@ func (r *blogRepository) SearchBlogs(ctx context.Context, search *entities.BlogS
 		}
 	}
 	
+	// Sort chosen by the use case (popularity, relevance or newest first); _id keeps pages stable.
+	// A cursor selects the blogs past it instead of skipping.
+	if search.Sort.Field == entities.SortByEngagement {
+		pipeline = append(pipeline, engagementStages(search.Sort.Weights)...)
+	}
+	sortStage, past := keysetSort(search.Sort, search.Position)
+	if past != nil {
+		pipeline = append(pipeline, bson.M{"$match": past})
+	}
+	pipeline = append(pipeline, bson.M{"$sort": sortStage})
+
 	// Add pagination
-	if search.Skip > 0 {
+	if past == nil && search.Skip > 0 {
 		pipeline = append(pipeline, bson.M{"$skip": search.Skip})
 	}
 	if search.Limit > 0 {
@@ -328,6 +1367,8 @@ func (r *blogRepository) SearchBlogs(ctx context.Context, search *entities.BlogS
 		"$project": bson.M{
 			"author":     0, // Remove author object (we only need author_name)
 			"user_id_obj": 0, // Remove temporary ObjectID field
+			"co_author_ids":  0,
+			"co_author_docs": 0,
 		},
 	})
 	
@@ -346,6 +1387,7 @@ func (r *blogRepository) SearchBlogs(ctx context.Context, search *entities.BlogS
 		}
 		results = append(results, &result)
 	}
+	reversePage(results, search.Position)
 	
 	return results, totalCount, cursor.Err()
 }
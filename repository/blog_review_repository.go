@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type blogReviewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewBlogReviewRepositoryMongo(collection *mongo.Collection) interfaces.BlogReviewRepositoryInterface {
+	return &blogReviewRepository{collection: collection}
+}
+
+func (r *blogReviewRepository) CreateReview(ctx context.Context, review *entities.BlogReview) error {
+	_, err := r.collection.InsertOne(ctx, review)
+	return err
+}
+
+// GetReviewsByBlogID retrieves the review history of a blog, oldest first
+func (r *blogReviewRepository) GetReviewsByBlogID(ctx context.Context, blogID string) ([]*entities.BlogReview, error) {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"blog_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []*entities.BlogReview
+	for cursor.Next(ctx) {
+		var review entities.BlogReview
+		if err := cursor.Decode(&review); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, &review)
+	}
+	return reviews, cursor.Err()
+}
+
+// DeleteReviewsByBlogID permanently removes the review history of a blog
+func (r *blogReviewRepository) DeleteReviewsByBlogID(ctx context.Context, blogID string) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"blog_id": oid})
+	return err
+}
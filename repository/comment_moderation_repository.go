@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commentModerationRepository struct {
+	collection *mongo.Collection
+}
+
+// moderationIndexes are created when the repository starts
+var moderationIndexes = []mongo.IndexModel{
+	// The moderation log of a blog, newest first
+	{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("moderation_blog_created_at")},
+}
+
+func NewCommentModerationRepositoryMongo(collection *mongo.Collection) interfaces.CommentModerationRepositoryInterface {
+	r := &commentModerationRepository{collection: collection}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes creates the indexes the queries rely on; creating an existing index is a no-op
+func (r *commentModerationRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := r.collection.Indexes().CreateMany(ctx, moderationIndexes); err != nil {
+		log.Println("⚠️ failed to create moderation indexes:", err)
+	}
+}
+
+// RecordAction appends an action to the moderation log
+func (r *commentModerationRepository) RecordAction(ctx context.Context, action *entities.ModerationAction) error {
+	_, err := r.collection.InsertOne(ctx, action)
+	return err
+}
+
+// GetActionsByBlogID returns the latest moderation actions on a blog, newest first
+func (r *commentModerationRepository) GetActionsByBlogID(ctx context.Context, blogID string, limit int) ([]*entities.ModerationAction, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{"blog_id": blogObjID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	actions := []*entities.ModerationAction{}
+	if err := cursor.All(ctx, &actions); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// DeleteActionsByBlogID permanently removes the moderation log of a blog
+func (r *commentModerationRepository) DeleteActionsByBlogID(ctx context.Context, blogID string) error {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"blog_id": blogObjID})
+	return err
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commentReactionRepository struct {
+	collection *mongo.Collection
+}
+
+// commentReactionIndexes are created when the repository starts
+var commentReactionIndexes = []mongo.IndexModel{
+	// One reaction per user and comment
+	{Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetName("comment_reaction_user").SetUnique(true)},
+	// Purging a blog removes the reactions to its comments
+	{Keys: bson.D{{Key: "blog_id", Value: 1}}, Options: options.Index().SetName("comment_reaction_blog")},
+}
+
+func NewCommentReactionRepositoryMongo(collection *mongo.Collection) interfaces.CommentReactionRepositoryInterface {
+	r := &commentReactionRepository{collection: collection}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes creates the indexes the queries rely on; creating an existing index is a no-op
+func (r *commentReactionRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := r.collection.Indexes().CreateMany(ctx, commentReactionIndexes); err != nil {
+		log.Println("⚠️ failed to create comment reaction indexes:", err)
+	}
+}
+
+// AddReaction stores a reaction unless the user already has one on the comment and reports whether it was stored
+func (r *commentReactionRepository) AddReaction(ctx context.Context, reaction *entities.CommentReaction) (bool, error) {
+	filter := bson.M{"comment_id": reaction.CommentID, "user_id": reaction.UserID}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": reaction}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent upsert of the same user inserted first
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// RemoveReaction removes the user's reaction of the given type to a comment and reports whether it was removed
+func (r *commentReactionRepository) RemoveReaction(ctx context.Context, commentID string, userID string, reactionType string) (bool, error) {
+	commentObjID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return false, err
+	}
+	result, err := r.collection.DeleteOne(ctx, bson.M{"comment_id": commentObjID, "user_id": userID, "type": reactionType})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// GetReaction returns the type of the user's reaction to a comment, or "" when there is none
+func (r *commentReactionRepository) GetReaction(ctx context.Context, commentID string, userID string) (string, error) {
+	commentObjID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return "", err
+	}
+	var reaction entities.CommentReaction
+	err = r.collection.FindOne(ctx, bson.M{"comment_id": commentObjID, "user_id": userID}).Decode(&reaction)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return reaction.Type, nil
+}
+
+// DeleteReactionsByBlogID removes all reactions to the comments of a blog
+func (r *commentReactionRepository) DeleteReactionsByBlogID(ctx context.Context, blogID string) error {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"blog_id": blogObjID})
+	return err
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"log"
+	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
@@ -9,33 +11,108 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type commentRepository struct {
-	collection *mongo.Collection
+	collection         *mongo.Collection
+	revisionCollection *mongo.Collection
+}
+
+// commentIndexes are created when the repository starts
+var commentIndexes = []mongo.IndexModel{
+	// Keyset pagination of the top-level comments of a blog
+	{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("comment_blog_thread_keyset")},
+	// Keyset pagination of the replies to a comment
+	{Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}, Options: options.Index().SetName("comment_replies_keyset")},
+	// Most liked first, for top-level comments and for replies
+	{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "like_count", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("comment_blog_likes")},
+	{Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "like_count", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("comment_replies_likes")},
+	// Top comments first, for top-level comments and for replies
+	{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "reaction_score", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("comment_blog_top")},
+	{Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "reaction_score", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("comment_replies_top")},
+	// Pinned comments of a blog
+	{Keys: bson.D{{Key: "blog_id", Value: 1}, {Key: "pinned_at", Value: 1}}, Options: options.Index().SetName("comment_blog_pinned").SetSparse(true)},
+	// Trending scans the comments of a rolling window
+	{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index().SetName("comment_created_at")},
+}
+
+// commentRevisionIndexes are created on the edit history when the repository starts
+var commentRevisionIndexes = []mongo.IndexModel{
+	// One snapshot per version of a comment, listed newest first
+	{Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "version", Value: -1}}, Options: options.Index().SetName("comment_revision_version").SetUnique(true)},
+	// Purging the comments of a blog
+	{Keys: bson.D{{Key: "blog_id", Value: 1}}, Options: options.Index().SetName("comment_revision_blog")},
 }
 
 func NewCommentRepositoryMongo(collection *mongo.Collection) interfaces.CommentRepositoryInterface {
-	return &commentRepository{collection: collection}
+	r := &commentRepository{
+		collection:         collection,
+		revisionCollection: collection.Database().Collection("comment_revisions"),
+	}
+	r.ensureIndexes()
+	return r
 }
 
+// ensureIndexes creates the indexes the queries rely on; creating an existing index is a no-op
+func (r *commentRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := r.collection.Indexes().CreateMany(ctx, commentIndexes); err != nil {
+		log.Println("⚠️ failed to create comment indexes:", err)
+	}
+	if _, err := r.revisionCollection.Indexes().CreateMany(ctx, commentRevisionIndexes); err != nil {
+		log.Println("⚠️ failed to create comment revision indexes:", err)
+	}
+}
+
+// CreateComment stores a new comment and its content as revision 1
 func (r *commentRepository) CreateComment(ctx context.Context, comment *entities.Comment) error {
-	_, err := r.collection.InsertOne(ctx, comment)
-	return err
+	if _, err := r.collection.InsertOne(ctx, comment); err != nil {
+		return err
+	}
+	return r.insertRevision(ctx, comment, 1, comment.CreatedAt)
 }
 
-// GetCommentsByBlogID retrieves all comments for a specific blog
-func (r *commentRepository) GetCommentsByBlogID(ctx context.Context, blogID string) ([]*entities.Comment, error) {
+// GetCommentsByBlogID retrieves one page of the top-level comments of a specific blog and their total count
+func (r *commentRepository) GetCommentsByBlogID(ctx context.Context, blogID string, query *entities.PageQuery) ([]*entities.Comment, int64, error) {
 	// Convert string blogID to ObjectID
 	objID, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
+	// parent_id is absent on top-level comments, which a nil match covers.
+	// Pinned comments are listed separately (GetPinnedComments), so they are not repeated here,
+	// but the total counts them.
+	topLevel := bson.M{"blog_id": objID, "parent_id": nil}
+	return r.findPage(ctx, topLevel, bson.M{"blog_id": objID, "parent_id": nil, "pinned_at": nil}, query)
+}
 
-	filter := bson.M{"blog_id": objID}
-	cursor, err := r.collection.Find(ctx, filter)
+// GetReplies retrieves one page of the direct replies to a comment and their total count
+func (r *commentRepository) GetReplies(ctx context.Context, parentID string, query *entities.PageQuery) ([]*entities.Comment, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(parentID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	return r.findPage(ctx, bson.M{"parent_id": objID}, bson.M{"parent_id": objID}, query)
+}
+
+// findPage retrieves the comments matching filter that come after the position of query,
+// along with the number of comments matching countFilter on all pages
+func (r *commentRepository) findPage(ctx context.Context, countFilter bson.M, filter bson.M, query *entities.PageQuery) ([]*entities.Comment, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, countFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sortStage, past := keysetSort(query.Sort, query.Position)
+	if past != nil {
+		filter["$and"] = bson.A{past}
+	}
+	opts := options.Find().SetSort(sortStage).SetLimit(int64(query.Limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer cursor.Close(ctx)
 
@@ -43,11 +120,12 @@ func (r *commentRepository) GetCommentsByBlogID(ctx context.Context, blogID stri
 	for cursor.Next(ctx) {
 		var comment entities.Comment
 		if err := cursor.Decode(&comment); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		comments = append(comments, &comment)
 	}
-	return comments, cursor.Err()
+	reversePage(comments, query.Position)
+	return comments, total, cursor.Err()
 }
 
 // GetCommentByID retrieves a single comment by its ID
@@ -64,30 +142,236 @@ func (r *commentRepository) GetCommentByID(ctx context.Context, id string) (*ent
 	return &comment, nil
 }
 
-// UpdateComment replaces an existing comment (matched by ID)
+// maxRevisionAttempts bounds the retries when concurrent edits race for the same revision number
+const maxRevisionAttempts = 5
+
+// UpdateComment saves the edited content of an existing comment (matched by ID) and stores it as a revision.
+// The revision is stored first: the unique (comment_id, version) index gives concurrent edits distinct
+// versions, and the content only changes once its revision exists, so no edit is missing from the history.
+// Counters, visibility and pins have their own updates so concurrent changes to them are not lost.
 func (r *commentRepository) UpdateComment(ctx context.Context, comment *entities.Comment) error {
+	for attempt := 1; ; attempt++ {
+		err := r.insertNextRevision(ctx, comment)
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt == maxRevisionAttempts {
+			return err
+		}
+	}
+
 	filter := bson.M{"_id": comment.ID}
-	_, err := r.collection.ReplaceOne(ctx, filter, comment)
+	update := bson.M{"$set": bson.M{
+		"content":    comment.Content,
+		"edited":     comment.Edited,
+		"edited_at":  comment.EditedAt,
+		"updated_at": comment.UpdatedAt,
+	}}
+	_, err := r.collection.UpdateOne(ctx, filter, update)
 	return err
 }
 
-// DeleteComment removes a comment by its ID
-func (r *commentRepository) DeleteComment(ctx context.Context, id string) error {
+// insertNextRevision stores the comment's content under the next free version;
+// it fails with a duplicate key error when a concurrent edit took that version first
+func (r *commentRepository) insertNextRevision(ctx context.Context, comment *entities.Comment) error {
+	latest, err := r.latestRevisionVersion(ctx, comment.ID)
+	if err != nil {
+		return err
+	}
+
+	// Comments posted before edit history existed: keep their current content as revision 1
+	if latest == 0 {
+		var previous entities.Comment
+		if err := r.collection.FindOne(ctx, bson.M{"_id": comment.ID}).Decode(&previous); err != nil {
+			return err
+		}
+		if err := r.insertRevision(ctx, &previous, 1, previous.UpdatedAt); err != nil {
+			return err
+		}
+		latest = 1
+	}
+	return r.insertRevision(ctx, comment, latest+1, comment.UpdatedAt)
+}
+
+// GetCommentRevisions lists all revisions of a comment, newest first
+func (r *commentRepository) GetCommentRevisions(ctx context.Context, commentID string) ([]*entities.CommentRevision, error) {
+	oid, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := r.revisionCollection.Find(ctx, bson.M{"comment_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var revisions []*entities.CommentRevision
+	for cursor.Next(ctx) {
+		var revision entities.CommentRevision
+		if err := cursor.Decode(&revision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, &revision)
+	}
+	return revisions, cursor.Err()
+}
+
+// latestRevisionVersion returns the highest stored revision number of a comment (0 if none)
+func (r *commentRepository) latestRevisionVersion(ctx context.Context, commentID primitive.ObjectID) (int, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	var revision entities.CommentRevision
+	err := r.revisionCollection.FindOne(ctx, bson.M{"comment_id": commentID}, opts).Decode(&revision)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return revision.Version, nil
+}
+
+// insertRevision stores an immutable snapshot of the comment's content; only its author edits it
+func (r *commentRepository) insertRevision(ctx context.Context, comment *entities.Comment, version int, createdAt time.Time) error {
+	revision := &entities.CommentRevision{
+		ID:        primitive.NewObjectID(),
+		CommentID: comment.ID,
+		BlogID:    comment.BlogID,
+		Version:   version,
+		Content:   comment.Content,
+		EditorID:  comment.UserID,
+		CreatedAt: createdAt,
+	}
+	_, err := r.revisionCollection.InsertOne(ctx, revision)
+	return err
+}
+
+// MarkCommentDeleted turns a comment into a tombstone; its content, author and edit history are kept
+// for moderators and it stays in the thread. Tombstones lose their pin.
+func (r *commentRepository) MarkCommentDeleted(ctx context.Context, id string, deletedBy string, deletedAt time.Time) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	update := bson.M{
+		"$set":   bson.M{"deleted": true, "deleted_at": deletedAt, "deleted_by": deletedBy},
+		"$unset": bson.M{"pinned_at": ""},
+	}
+	return r.unpinningUpdate(ctx, oid, update)
+}
+
+// UpdateReplyCount moves the reply count of a comment by change
+func (r *commentRepository) UpdateReplyCount(ctx context.Context, id string, change int) error {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
-	filter := bson.M{"_id": oid}
-	_, err = r.collection.DeleteOne(ctx, filter)
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$inc": bson.M{"reply_count": change}})
 	return err
 }
 
-// GetCommentCountByBlogID counts comments for a specific blog
+// SetHidden hides or shows a comment; hidden comments lose their pin
+func (r *commentRepository) SetHidden(ctx context.Context, id string, hidden bool) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	if !hidden {
+		_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$unset": bson.M{"hidden": ""}})
+		return false, err
+	}
+	return r.unpinningUpdate(ctx, oid, bson.M{"$set": bson.M{"hidden": true}, "$unset": bson.M{"pinned_at": ""}})
+}
+
+// unpinningUpdate applies an update that removes the pin of a comment and reports whether the comment had one
+func (r *commentRepository) unpinningUpdate(ctx context.Context, oid primitive.ObjectID, update bson.M) (bool, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before).SetProjection(bson.M{"pinned_at": 1})
+	var previous entities.Comment
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": oid}, update, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return previous.PinnedAt != nil, nil
+}
+
+// SetPinned pins a comment at pinnedAt, or unpins it when pinnedAt is nil. Only unpinned comments that are
+// neither deleted nor hidden are pinned, and only pinned ones unpinned, so it reports whether the pin changed.
+func (r *commentRepository) SetPinned(ctx context.Context, id string, pinnedAt *time.Time) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	filter := bson.M{"_id": oid, "pinned_at": bson.M{"$ne": nil}}
+	update := bson.M{"$unset": bson.M{"pinned_at": ""}}
+	if pinnedAt != nil {
+		filter = bson.M{"_id": oid, "pinned_at": nil, "deleted": bson.M{"$ne": true}, "hidden": bson.M{"$ne": true}}
+		update = bson.M{"$set": bson.M{"pinned_at": *pinnedAt}}
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// GetPinnedComments returns the pinned comments of a blog, first pinned first
+func (r *commentRepository) GetPinnedComments(ctx context.Context, blogID string) ([]*entities.Comment, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{"blog_id": blogObjID, "pinned_at": bson.M{"$ne": nil}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "pinned_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []*entities.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// UpdateReactionCounts moves the like and dislike counts of a comment and its reaction score along
+func (r *commentRepository) UpdateReactionCounts(ctx context.Context, id string, change entities.CounterChange) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$inc": bson.M{
+		"like_count":     change.Likes,
+		"dislike_count":  change.Dislikes,
+		"reaction_score": change.Likes - change.Dislikes,
+	}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
+
+// GetCommentCountByBlogID counts comments for a specific blog, leaving out deleted placeholders and hidden comments
 func (r *commentRepository) GetCommentCountByBlogID(ctx context.Context, blogID string) (int64, error) {
 	blogObjID, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
 		return 0, err
 	}
-	filter := bson.M{"blog_id": blogObjID}
+	filter := bson.M{"blog_id": blogObjID, "deleted": bson.M{"$ne": true}, "hidden": bson.M{"$ne": true}}
 	return r.collection.CountDocuments(ctx, filter)
 }
+
+// DeleteCommentsByBlogID permanently removes all comments of a blog and their edit history
+func (r *commentRepository) DeleteCommentsByBlogID(ctx context.Context, blogID string) error {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	if _, err := r.revisionCollection.DeleteMany(ctx, bson.M{"blog_id": blogObjID}); err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"blog_id": blogObjID})
+	return err
+}
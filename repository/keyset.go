@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// keysetSort returns the sort of a page and the condition selecting the items past its cursor.
+// Previous pages are fetched in reverse order, starting from the item next to the cursor,
+// so the results must be reversed afterwards (see reversePage). _id breaks ties between equal sort values.
+func keysetSort(sort entities.ListSort, position *entities.PageCursor) (bson.D, bson.M) {
+	if sort.Field == "" {
+		sort = entities.ListSort{Field: entities.SortByCreatedAt, Desc: true}
+	}
+	desc := sort.Desc
+	if position != nil && position.Before {
+		desc = !desc
+	}
+	order := 1
+	if desc {
+		order = -1
+	}
+	sortStage := bson.D{{Key: sort.Field, Value: order}, {Key: "_id", Value: order}}
+	if position == nil {
+		return sortStage, nil
+	}
+
+	past := "$gt"
+	if desc {
+		past = "$lt"
+	}
+	var value interface{} = position.Number
+	if sort.ByTime() {
+		value = position.Time
+	}
+	return sortStage, bson.M{"$or": bson.A{
+		bson.M{sort.Field: bson.M{past: value}},
+		bson.M{sort.Field: value, "_id": bson.M{past: position.ID}},
+	}}
+}
+
+// reversePage restores the display order of a page fetched backwards from its cursor
+func reversePage[T any](items []T, position *entities.PageCursor) {
+	if position == nil || !position.Before {
+		return
+	}
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type seriesRepository struct {
+	collection *mongo.Collection
+}
+
+// seriesIndexes are created when the repository starts
+var seriesIndexes = []mongo.IndexModel{
+	// A blog belongs to at most one series; series without parts are left out so they do not collide
+	{Keys: bson.D{{Key: "blog_ids", Value: 1}}, Options: options.Index().SetName("series_blog").SetUnique(true).
+		SetPartialFilterExpression(bson.M{"blog_ids": bson.M{"$type": "objectId"}})},
+}
+
+func NewSeriesRepositoryMongo(collection *mongo.Collection) interfaces.SeriesRepositoryInterface {
+	r := &seriesRepository{collection: collection}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes creates the indexes the queries rely on; creating an existing index is a no-op
+func (r *seriesRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := r.collection.Indexes().CreateMany(ctx, seriesIndexes); err != nil {
+		log.Println("⚠️ failed to create series indexes:", err)
+	}
+}
+
+func (r *seriesRepository) CreateSeries(ctx context.Context, series *entities.Series) error {
+	_, err := r.collection.InsertOne(ctx, series)
+	return err
+}
+
+// GetSeriesByID retrieves a single series by its ID
+func (r *seriesRepository) GetSeriesByID(ctx context.Context, id string) (*entities.Series, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var series entities.Series
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// GetSeriesByUserID lists the series of a user, newest first
+func (r *seriesRepository) GetSeriesByUserID(ctx context.Context, userID string) ([]*entities.Series, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var series []*entities.Series
+	for cursor.Next(ctx) {
+		var s entities.Series
+		if err := cursor.Decode(&s); err != nil {
+			return nil, err
+		}
+		series = append(series, &s)
+	}
+	return series, cursor.Err()
+}
+
+// GetSeriesByBlogID retrieves the series containing a blog (mongo.ErrNoDocuments if none)
+func (r *seriesRepository) GetSeriesByBlogID(ctx context.Context, blogID string) (*entities.Series, error) {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, err
+	}
+	var series entities.Series
+	if err := r.collection.FindOne(ctx, bson.M{"blog_ids": oid}).Decode(&series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// UpdateSeriesBlogs stores the ordered blog list of a series
+func (r *seriesRepository) UpdateSeriesBlogs(ctx context.Context, series *entities.Series) error {
+	update := bson.M{"$set": bson.M{
+		"blog_ids":   series.BlogIDs,
+		"updated_at": series.UpdatedAt,
+	}}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": series.ID}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return interfaces.ErrBlogInSeries
+	}
+	return err
+}
+
+// RemoveBlogFromSeries pulls a blog out of every series that contains it
+func (r *seriesRepository) RemoveBlogFromSeries(ctx context.Context, blogID string) error {
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{
+		"$pull": bson.M{"blog_ids": oid},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	_, err = r.collection.UpdateMany(ctx, bson.M{"blog_ids": oid}, update)
+	return err
+}
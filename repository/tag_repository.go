@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type tagRepository struct {
+	collection *mongo.Collection
+}
+
+// tagIndexes are created when the repository starts
+var tagIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetName("tag_slug").SetUnique(true)},
+	// Tags are resolved from their synonyms on every blog write
+	{Keys: bson.D{{Key: "synonyms", Value: 1}}, Options: options.Index().SetName("tag_synonyms")},
+}
+
+func NewTagRepositoryMongo(collection *mongo.Collection) interfaces.TagRepositoryInterface {
+	r := &tagRepository{collection: collection}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes creates the indexes the queries rely on; creating an existing index is a no-op
+func (r *tagRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := r.collection.Indexes().CreateMany(ctx, tagIndexes); err != nil {
+		log.Println("⚠️ failed to create tag indexes:", err)
+	}
+}
+
+func (r *tagRepository) CreateTag(ctx context.Context, tag *entities.Tag) error {
+	_, err := r.collection.InsertOne(ctx, tag)
+	return err
+}
+
+// GetTags retrieves all tags ordered by slug
+func (r *tagRepository) GetTags(ctx context.Context) ([]*entities.Tag, error) {
+	return r.findTags(ctx, bson.M{})
+}
+
+// GetTagBySlug retrieves the tag with the given canonical slug
+func (r *tagRepository) GetTagBySlug(ctx context.Context, slug string) (*entities.Tag, error) {
+	var tag entities.Tag
+	if err := r.collection.FindOne(ctx, bson.M{"slug": slug}).Decode(&tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindTags retrieves the tags whose slug or one of whose synonyms is in slugs
+func (r *tagRepository) FindTags(ctx context.Context, slugs []string) ([]*entities.Tag, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+	return r.findTags(ctx, bson.M{"$or": bson.A{
+		bson.M{"slug": bson.M{"$in": slugs}},
+		bson.M{"synonyms": bson.M{"$in": slugs}},
+	}})
+}
+
+func (r *tagRepository) findTags(ctx context.Context, filter bson.M) ([]*entities.Tag, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "slug", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tags := []*entities.Tag{}
+	if err := cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// UpdateTag stores the slug, name, description and synonyms of a tag
+func (r *tagRepository) UpdateTag(ctx context.Context, tag *entities.Tag) error {
+	update := bson.M{"$set": bson.M{
+		"slug":        tag.Slug,
+		"name":        tag.Name,
+		"description": tag.Description,
+		"synonyms":    tag.Synonyms,
+		"updated_at":  tag.UpdatedAt,
+	}}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": tag.ID}, update)
+	return err
+}
+
+// DeleteTags removes the tags with the given slugs
+func (r *tagRepository) DeleteTags(ctx context.Context, slugs []string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"slug": bson.M{"$in": slugs}})
+	return err
+}
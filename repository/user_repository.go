@@ -105,6 +105,21 @@ func (r *userRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*
 	return &user, nil
 }
 
+// FindByRole returns all users with the given role
+func (r *userRepository) FindByRole(ctx context.Context, role entities.Role) ([]*entities.User, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"role": role})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entities.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 // Deleting refresh token from cache or database
 func (r *userRepository) DeleteTokenByUserID(ctx context.Context, userID string) error {
 	filter := bson.M{"user_id": userID}
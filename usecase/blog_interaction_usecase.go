@@ -13,12 +13,14 @@ import (
 type blogInteractionUseCase struct {
 	repo     interfaces.BlogInteractionRepositoryInterface
 	blogRepo interfaces.BlogRepositoryInterface
+	weights  entities.EngagementWeights
 }
 
-func NewBlogInteractionUseCase(repo interfaces.BlogInteractionRepositoryInterface, blogRepo interfaces.BlogRepositoryInterface) interfaces.BlogInteractionUseCaseInterface {
+func NewBlogInteractionUseCase(repo interfaces.BlogInteractionRepositoryInterface, blogRepo interfaces.BlogRepositoryInterface, weights entities.EngagementWeights) interfaces.BlogInteractionUseCaseInterface {
 	return &blogInteractionUseCase{
 		repo:     repo,
 		blogRepo: blogRepo,
+		weights:  weights,
 	}
 }
 
@@ -34,7 +36,7 @@ func (u *blogInteractionUseCase) LikeBlog(ctx context.Context, blogID string, us
 			return err
 		}
 		// Decrease like count
-		return u.blogRepo.UpdateBlogCounters(ctx, blogID, -1, 0, 0)
+		return u.updateCounters(ctx, blogID, entities.CounterChange{Likes: -1})
 	}
 	
 	if hasDislike {
@@ -56,7 +58,7 @@ func (u *blogInteractionUseCase) LikeBlog(ctx context.Context, blogID string, us
 			return err
 		}
 		// Update counters: -1 dislike, +1 like
-		return u.blogRepo.UpdateBlogCounters(ctx, blogID, 1, -1, 0)
+		return u.updateCounters(ctx, blogID, entities.CounterChange{Likes: 1, Dislikes: -1})
 	}
 	
 	// User hasn't interacted before - add like
@@ -72,7 +74,7 @@ func (u *blogInteractionUseCase) LikeBlog(ctx context.Context, blogID string, us
 		return err
 	}
 	// Increase like count
-	return u.blogRepo.UpdateBlogCounters(ctx, blogID, 1, 0, 0)
+	return u.updateCounters(ctx, blogID, entities.CounterChange{Likes: 1})
 }
 
 func (u *blogInteractionUseCase) DislikeBlog(ctx context.Context, blogID string, userID string) error {
@@ -87,7 +89,7 @@ func (u *blogInteractionUseCase) DislikeBlog(ctx context.Context, blogID string,
 			return err
 		}
 		// Decrease dislike count
-		return u.blogRepo.UpdateBlogCounters(ctx, blogID, 0, -1, 0)
+		return u.updateCounters(ctx, blogID, entities.CounterChange{Dislikes: -1})
 	}
 	
 	if hasLike {
@@ -109,7 +111,7 @@ func (u *blogInteractionUseCase) DislikeBlog(ctx context.Context, blogID string,
 			return err
 		}
 		// Update counters: -1 like, +1 dislike
-		return u.blogRepo.UpdateBlogCounters(ctx, blogID, -1, 1, 0)
+		return u.updateCounters(ctx, blogID, entities.CounterChange{Likes: -1, Dislikes: 1})
 	}
 	
 	// User hasn't interacted before - add dislike
@@ -125,7 +127,7 @@ func (u *blogInteractionUseCase) DislikeBlog(ctx context.Context, blogID string,
 		return err
 	}
 	// Increase dislike count
-	return u.blogRepo.UpdateBlogCounters(ctx, blogID, 0, 1, 0)
+	return u.updateCounters(ctx, blogID, entities.CounterChange{Dislikes: 1})
 }
 
 func (u *blogInteractionUseCase) ViewBlog(ctx context.Context, blogID string, userID string, ipAddress string, userAgent string) error {
@@ -168,7 +170,12 @@ func (u *blogInteractionUseCase) ViewBlog(ctx context.Context, blogID string, us
 	}
 	
 	// Increment the view counter
-	return u.blogRepo.UpdateBlogCounters(ctx, blogID, 0, 0, 1)
+	return u.updateCounters(ctx, blogID, entities.CounterChange{Views: 1})
+}
+
+// updateCounters applies a counter change to a blog and moves its popularity score along
+func (u *blogInteractionUseCase) updateCounters(ctx context.Context, blogID string, change entities.CounterChange) error {
+	return u.blogRepo.UpdateBlogCounters(ctx, blogID, change, u.weights.Score(change))
 }
 
 func toObjectID(id string) primitive.ObjectID {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	repoMocks "github.com/Abenuterefe/a2sv-project/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,13 +15,13 @@ func TestLikeBlog_ToggleOff(t *testing.T) {
 	t.Parallel()
 	interRepo := repoMocks.NewBlogInteractionRepositoryInterface(t)
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
-	uc := NewBlogInteractionUseCase(interRepo, blogRepo)
+	uc := NewBlogInteractionUseCase(interRepo, blogRepo, DefaultEngagementWeights())
 
 	// already liked => remove like, decrement like counter
 	interRepo.On("HasInteraction", mock.Anything, "b1", "u1", "like").Return(true, nil)
 	interRepo.On("HasInteraction", mock.Anything, "b1", "u1", "dislike").Return(false, nil)
 	interRepo.On("RemoveInteraction", mock.Anything, "b1", "u1", "like").Return(nil)
-	blogRepo.On("UpdateBlogCounters", mock.Anything, "b1", -1, 0, 0).Return(nil)
+	blogRepo.On("UpdateBlogCounters", mock.Anything, "b1", entities.CounterChange{Likes: -1}, -DefaultEngagementWeights().Likes).Return(nil)
 
 	err := uc.LikeBlog(context.Background(), "b1", "u1")
 	assert.NoError(t, err)
@@ -30,13 +31,13 @@ func TestLikeBlog_SwitchFromDislike(t *testing.T) {
 	t.Parallel()
 	interRepo := repoMocks.NewBlogInteractionRepositoryInterface(t)
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
-	uc := NewBlogInteractionUseCase(interRepo, blogRepo)
+	uc := NewBlogInteractionUseCase(interRepo, blogRepo, DefaultEngagementWeights())
 
 	interRepo.On("HasInteraction", mock.Anything, "b1", "u1", "like").Return(false, nil)
 	interRepo.On("HasInteraction", mock.Anything, "b1", "u1", "dislike").Return(true, nil)
 	interRepo.On("RemoveInteraction", mock.Anything, "b1", "u1", "dislike").Return(nil)
 	interRepo.On("AddInteraction", mock.Anything, mock.MatchedBy(func(i interface{}) bool { return true })).Return(nil)
-	blogRepo.On("UpdateBlogCounters", mock.Anything, "b1", 1, -1, 0).Return(nil)
+	blogRepo.On("UpdateBlogCounters", mock.Anything, "b1", entities.CounterChange{Likes: 1, Dislikes: -1}, DefaultEngagementWeights().Likes-DefaultEngagementWeights().Dislikes).Return(nil)
 
 	err := uc.LikeBlog(context.Background(), "b1", "u1")
 	assert.NoError(t, err)
@@ -46,12 +47,12 @@ func TestDislikeBlog_New(t *testing.T) {
 	t.Parallel()
 	interRepo := repoMocks.NewBlogInteractionRepositoryInterface(t)
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
-	uc := NewBlogInteractionUseCase(interRepo, blogRepo)
+	uc := NewBlogInteractionUseCase(interRepo, blogRepo, DefaultEngagementWeights())
 
 	interRepo.On("HasInteraction", mock.Anything, "b1", "u1", "like").Return(false, nil)
 	interRepo.On("HasInteraction", mock.Anything, "b1", "u1", "dislike").Return(false, nil)
 	interRepo.On("AddInteraction", mock.Anything, mock.MatchedBy(func(i interface{}) bool { return true })).Return(nil)
-	blogRepo.On("UpdateBlogCounters", mock.Anything, "b1", 0, 1, 0).Return(nil)
+	blogRepo.On("UpdateBlogCounters", mock.Anything, "b1", entities.CounterChange{Dislikes: 1}, DefaultEngagementWeights().Dislikes).Return(nil)
 
 	err := uc.DislikeBlog(context.Background(), "b1", "u1")
 	assert.NoError(t, err)
@@ -61,7 +62,7 @@ func TestViewBlog_Anonymous_Debounce(t *testing.T) {
 	t.Parallel()
 	interRepo := repoMocks.NewBlogInteractionRepositoryInterface(t)
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
-	uc := NewBlogInteractionUseCase(interRepo, blogRepo)
+	uc := NewBlogInteractionUseCase(interRepo, blogRepo, DefaultEngagementWeights())
 
 	// First call indicates recent view exists -> no increment, no add
 	blogID := "507f1f77bcf86cd799439011" // valid ObjectID hex
@@ -75,12 +76,12 @@ func TestViewBlog_Anonymous_AddsAndIncrements(t *testing.T) {
 	t.Parallel()
 	interRepo := repoMocks.NewBlogInteractionRepositoryInterface(t)
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
-	uc := NewBlogInteractionUseCase(interRepo, blogRepo)
+	uc := NewBlogInteractionUseCase(interRepo, blogRepo, DefaultEngagementWeights())
 
 	blogID := "507f1f77bcf86cd799439011" // valid ObjectID hex
 	interRepo.On("HasRecentView", mock.Anything, blogID, "anonymous", "1.1.1.1", "agent").Return(false, nil)
 	interRepo.On("AddInteraction", mock.Anything, mock.MatchedBy(func(i interface{}) bool { return true })).Return(nil)
-	blogRepo.On("UpdateBlogCounters", mock.Anything, blogID, 0, 0, 1).Return(nil)
+	blogRepo.On("UpdateBlogCounters", mock.Anything, blogID, entities.CounterChange{Views: 1}, DefaultEngagementWeights().Views).Return(nil)
 
 	err := uc.ViewBlog(context.Background(), blogID, "", "1.1.1.1", "agent")
 	assert.NoError(t, err)
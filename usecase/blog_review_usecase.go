@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// blogReviewUseCase implements the editorial review workflow:
+// draft -> submitted -> changes_requested -> submitted -> approved -> (author publishes)
+type blogReviewUseCase struct {
+	blogRepo    interfaces.BlogRepositoryInterface
+	reviewRepo  interfaces.BlogReviewRepositoryInterface
+	userRepo    interfaces.UserRepository
+	mailService interfaces.MailService
+}
+
+func NewBlogReviewUseCase(
+	blogRepo interfaces.BlogRepositoryInterface,
+	reviewRepo interfaces.BlogReviewRepositoryInterface,
+	userRepo interfaces.UserRepository,
+	mailService interfaces.MailService) interfaces.BlogReviewUseCaseInterface {
+	return &blogReviewUseCase{
+		blogRepo:    blogRepo,
+		reviewRepo:  reviewRepo,
+		userRepo:    userRepo,
+		mailService: mailService,
+	}
+}
+
+// SubmitForReview starts a new review round and notifies the reviewers
+func (u *blogReviewUseCase) SubmitForReview(ctx context.Context, blogID string, userID string) (*entities.Blog, error) {
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil || blog.DeletedAt != nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.UserID != userID {
+		return nil, errors.New("only the owner can submit a blog for review")
+	}
+	if isPublished(blog) || blog.Status == entities.BlogStatusScheduled {
+		return nil, errors.New("published or scheduled blogs cannot be submitted for review")
+	}
+	switch blog.ReviewStatus {
+	case entities.ReviewStatusSubmitted:
+		return nil, errors.New("blog is already waiting for review")
+	case entities.ReviewStatusApproved:
+		return nil, errors.New("blog is already approved")
+	}
+
+	now := time.Now()
+	round := blog.ReviewRound + 1
+	if err := u.blogRepo.UpdateBlogReviewStatus(ctx, blogID, entities.ReviewStatusSubmitted, round, &now); err != nil {
+		return nil, err
+	}
+	blog.ReviewStatus, blog.ReviewRound, blog.SubmittedAt = entities.ReviewStatusSubmitted, round, &now
+
+	if err := recordReview(ctx, u.reviewRepo, blog, userID, ""); err != nil {
+		return nil, err
+	}
+
+	reviewers, err := u.userRepo.FindByRole(ctx, entities.RoleReviewer)
+	if err != nil {
+		log.Println("⚠️ failed to load reviewers:", err)
+	}
+	for _, reviewer := range reviewers {
+		sendReviewEmail(u.mailService, reviewer.Email, blog, "")
+	}
+	return blog, nil
+}
+
+// RequestChanges sends the blog back to its author with a comment
+func (u *blogReviewUseCase) RequestChanges(ctx context.Context, blogID string, reviewerID string, comment string) (*entities.Blog, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, errors.New("a comment is required when requesting changes")
+	}
+	return u.decide(ctx, blogID, reviewerID, entities.ReviewStatusChangesRequested, comment)
+}
+
+// ApproveBlog allows the author to publish the blog
+func (u *blogReviewUseCase) ApproveBlog(ctx context.Context, blogID string, reviewerID string, comment string) (*entities.Blog, error) {
+	return u.decide(ctx, blogID, reviewerID, entities.ReviewStatusApproved, strings.TrimSpace(comment))
+}
+
+// GetReviewQueue returns the blogs waiting for a reviewer
+func (u *blogReviewUseCase) GetReviewQueue(ctx context.Context, page int64, limit int64) ([]*entities.Blog, error) {
+	return u.blogRepo.GetBlogsByReviewStatus(ctx, entities.ReviewStatusSubmitted, page, limit)
+}
+
+// GetBlogReviews returns the submissions and reviewer decisions of a blog
+func (u *blogReviewUseCase) GetBlogReviews(ctx context.Context, blogID string, userID string) ([]*entities.BlogReview, error) {
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	if !isAuthor(blog, userID) {
+		oid, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return nil, errors.New("only the authors and reviewers can see the reviews of a blog")
+		}
+		user, err := u.userRepo.FindByID(ctx, oid)
+		if err != nil || user == nil || user.Role != entities.RoleReviewer {
+			return nil, errors.New("only the authors and reviewers can see the reviews of a blog")
+		}
+	}
+	return u.reviewRepo.GetReviewsByBlogID(ctx, blogID)
+}
+
+// decide records a reviewer decision on the current submission and notifies the author
+func (u *blogReviewUseCase) decide(ctx context.Context, blogID string, reviewerID string, status entities.ReviewStatus, comment string) (*entities.Blog, error) {
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil || blog.DeletedAt != nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.ReviewStatus != entities.ReviewStatusSubmitted {
+		return nil, errors.New("blog is not waiting for review")
+	}
+	if isAuthor(blog, reviewerID) {
+		return nil, errors.New("you cannot review your own blog")
+	}
+
+	if err := u.blogRepo.UpdateBlogReviewStatus(ctx, blogID, status, blog.ReviewRound, nil); err != nil {
+		return nil, err
+	}
+	blog.ReviewStatus = status
+
+	if err := recordReview(ctx, u.reviewRepo, blog, reviewerID, comment); err != nil {
+		return nil, err
+	}
+	notifyReviewAuthor(ctx, u.userRepo, u.mailService, blog, comment)
+	return blog, nil
+}
+
+// recordReview stores the blog's current review state in its history
+func recordReview(ctx context.Context, reviewRepo interfaces.BlogReviewRepositoryInterface, blog *entities.Blog, userID string, comment string) error {
+	return reviewRepo.CreateReview(ctx, &entities.BlogReview{
+		ID:        primitive.NewObjectID(),
+		BlogID:    blog.ID,
+		Round:     blog.ReviewRound,
+		UserID:    userID,
+		Status:    blog.ReviewStatus,
+		Comment:   comment,
+		CreatedAt: time.Now(),
+	})
+}
+
+// notifyReviewAuthor emails a review update to the owner of the blog
+func notifyReviewAuthor(ctx context.Context, userRepo interfaces.UserRepository, mailService interfaces.MailService, blog *entities.Blog, comment string) {
+	if oid, err := primitive.ObjectIDFromHex(blog.UserID); err == nil {
+		if author, err := userRepo.FindByID(ctx, oid); err == nil && author != nil {
+			sendReviewEmail(mailService, author.Email, blog, comment)
+		}
+	}
+}
+
+// sendReviewEmail emails a review update; a failed email does not undo the transition
+func sendReviewEmail(mailService interfaces.MailService, to string, blog *entities.Blog, comment string) {
+	if to == "" {
+		return
+	}
+	if err := mailService.SendBlogReviewEmail(to, blog.Title, string(blog.ReviewStatus), comment); err != nil {
+		log.Println("⚠️ failed to send review email:", err)
+	}
+}
+
+// isAuthor reports whether the user owns or co-authors the blog
+func isAuthor(blog *entities.Blog, userID string) bool {
+	if blog.UserID == userID {
+		return true
+	}
+	for _, coAuthor := range blog.CoAuthors {
+		if coAuthor == userID {
+			return true
+		}
+	}
+	return false
+}
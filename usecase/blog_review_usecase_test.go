@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	repoMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSubmitForReview_NotifiesReviewers(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	reviewRepo := repoMocks.NewBlogReviewRepositoryInterface(t)
+	userRepo := repoMocks.NewUserRepository(t)
+	mailService := repoMocks.NewMailService(t)
+	uc := NewBlogReviewUseCase(blogRepo, reviewRepo, userRepo, mailService)
+
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{Title: "Draft", UserID: "u1", Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusChangesRequested, ReviewRound: 1}, nil)
+	blogRepo.On("UpdateBlogReviewStatus", mock.Anything, "b1", entities.ReviewStatusSubmitted, 2, mock.Anything).Return(nil)
+	reviewRepo.On("CreateReview", mock.Anything, mock.MatchedBy(func(r *entities.BlogReview) bool {
+		return r.Round == 2 && r.Status == entities.ReviewStatusSubmitted && r.UserID == "u1"
+	})).Return(nil)
+	userRepo.On("FindByRole", mock.Anything, entities.RoleReviewer).Return([]*entities.User{{Email: "r1@example.com"}, {Email: "r2@example.com"}}, nil)
+	mailService.On("SendBlogReviewEmail", mock.Anything, "Draft", "submitted", "").Return(nil).Twice()
+
+	// only the owner can submit
+	_, err := uc.SubmitForReview(context.Background(), "b1", "u2")
+	assert.EqualError(t, err, "only the owner can submit a blog for review")
+
+	blog, err := uc.SubmitForReview(context.Background(), "b1", "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReviewStatusSubmitted, blog.ReviewStatus)
+	assert.Equal(t, 2, blog.ReviewRound)
+	assert.NotNil(t, blog.SubmittedAt)
+}
+
+func TestSubmitForReview_RejectsInvalidStates(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewBlogReviewUseCase(blogRepo, repoMocks.NewBlogReviewRepositoryInterface(t), repoMocks.NewUserRepository(t), repoMocks.NewMailService(t))
+
+	blogRepo.On("GetBlogByID", mock.Anything, "live").Return(&entities.Blog{UserID: "u1", Status: entities.BlogStatusPublished}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "waiting").Return(&entities.Blog{UserID: "u1", Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusSubmitted}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "approved").Return(&entities.Blog{UserID: "u1", Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusApproved}, nil)
+
+	_, err := uc.SubmitForReview(context.Background(), "live", "u1")
+	assert.EqualError(t, err, "published or scheduled blogs cannot be submitted for review")
+	_, err = uc.SubmitForReview(context.Background(), "waiting", "u1")
+	assert.EqualError(t, err, "blog is already waiting for review")
+	_, err = uc.SubmitForReview(context.Background(), "approved", "u1")
+	assert.EqualError(t, err, "blog is already approved")
+}
+
+func TestReviewDecisions(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	reviewRepo := repoMocks.NewBlogReviewRepositoryInterface(t)
+	userRepo := repoMocks.NewUserRepository(t)
+	mailService := repoMocks.NewMailService(t)
+	uc := NewBlogReviewUseCase(blogRepo, reviewRepo, userRepo, mailService)
+
+	author := primitive.NewObjectID()
+	submitted := func() *entities.Blog {
+		return &entities.Blog{Title: "Draft", UserID: author.Hex(), CoAuthors: []string{"co"}, Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusSubmitted, ReviewRound: 1}
+	}
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(submitted(), nil).Times(3)
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(submitted(), nil).Once()
+	userRepo.On("FindByID", mock.Anything, author).Return(&entities.User{ID: author, Email: "author@example.com"}, nil)
+
+	// a comment is required when requesting changes
+	_, err := uc.RequestChanges(context.Background(), "b1", "r1", "  ")
+	assert.EqualError(t, err, "a comment is required when requesting changes")
+
+	// authors cannot review their own work
+	_, err = uc.ApproveBlog(context.Background(), "b1", author.Hex(), "")
+	assert.EqualError(t, err, "you cannot review your own blog")
+	_, err = uc.ApproveBlog(context.Background(), "b1", "co", "")
+	assert.EqualError(t, err, "you cannot review your own blog")
+
+	blogRepo.On("UpdateBlogReviewStatus", mock.Anything, "b1", entities.ReviewStatusChangesRequested, 1, (*time.Time)(nil)).Return(nil).Once()
+	reviewRepo.On("CreateReview", mock.Anything, mock.MatchedBy(func(r *entities.BlogReview) bool {
+		return r.Status == entities.ReviewStatusChangesRequested && r.Comment == "Add an intro" && r.UserID == "r1"
+	})).Return(nil).Once()
+	mailService.On("SendBlogReviewEmail", "author@example.com", "Draft", "changes_requested", "Add an intro").Return(nil).Once()
+	blog, err := uc.RequestChanges(context.Background(), "b1", "r1", "Add an intro")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReviewStatusChangesRequested, blog.ReviewStatus)
+
+	blogRepo.On("UpdateBlogReviewStatus", mock.Anything, "b1", entities.ReviewStatusApproved, 1, (*time.Time)(nil)).Return(nil).Once()
+	reviewRepo.On("CreateReview", mock.Anything, mock.MatchedBy(func(r *entities.BlogReview) bool {
+		return r.Status == entities.ReviewStatusApproved
+	})).Return(nil).Once()
+	mailService.On("SendBlogReviewEmail", "author@example.com", "Draft", "approved", "").Return(nil).Once()
+	blog, err = uc.ApproveBlog(context.Background(), "b1", "r1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReviewStatusApproved, blog.ReviewStatus)
+
+	// decisions need a pending submission
+	blogRepo.On("GetBlogByID", mock.Anything, "b2").Return(&entities.Blog{UserID: author.Hex(), ReviewStatus: entities.ReviewStatusApproved}, nil)
+	_, err = uc.ApproveBlog(context.Background(), "b2", "r1", "")
+	assert.EqualError(t, err, "blog is not waiting for review")
+}
+
+func TestGetBlogReviews_AuthorsAndReviewersOnly(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	reviewRepo := repoMocks.NewBlogReviewRepositoryInterface(t)
+	userRepo := repoMocks.NewUserRepository(t)
+	uc := NewBlogReviewUseCase(blogRepo, reviewRepo, userRepo, repoMocks.NewMailService(t))
+
+	reviewer, stranger := primitive.NewObjectID(), primitive.NewObjectID()
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{UserID: "u1", CoAuthors: []string{"u2"}}, nil)
+	userRepo.On("FindByID", mock.Anything, reviewer).Return(&entities.User{ID: reviewer, Role: entities.RoleReviewer}, nil)
+	userRepo.On("FindByID", mock.Anything, stranger).Return(&entities.User{ID: stranger, Role: entities.RoleUser}, nil)
+	reviewRepo.On("GetReviewsByBlogID", mock.Anything, "b1").Return([]*entities.BlogReview{{Round: 1}}, nil)
+
+	for _, userID := range []string{"u1", "u2", reviewer.Hex()} {
+		reviews, err := uc.GetBlogReviews(context.Background(), "b1", userID)
+		assert.NoError(t, err)
+		assert.Len(t, reviews, 1)
+	}
+	_, err := uc.GetBlogReviews(context.Background(), "b1", stranger.Hex())
+	assert.Error(t, err)
+}
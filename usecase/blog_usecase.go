@@ -3,29 +3,160 @@ package usecase
 import (
 	"context"
 	"errors"
-	"sort"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
 	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+	"github.com/Abenuterefe/a2sv-project/utils"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // blogUseCase implements the BlogUseCaseInterface
 type blogUseCase struct {
-	repo        interfaces.BlogRepositoryInterface
-	commentRepo interfaces.CommentRepositoryInterface
+	repo            interfaces.BlogRepositoryInterface
+	commentRepo     interfaces.CommentRepositoryInterface
+	interactionRepo interfaces.BlogInteractionRepositoryInterface
+	seriesRepo      interfaces.SeriesRepositoryInterface
+	userRepo        interfaces.UserRepository
+	assetStorage    interfaces.BlogAssetStorage
+	renderer        interfaces.ContentRenderer
+	passwordService interfaces.PasswordService
+	accessTokens    interfaces.BlogAccessTokenService
+	cursors         interfaces.CursorSigner
+	related         interfaces.RelatedBlogIndex
+	tagRepo         interfaces.TagRepositoryInterface
+	reactionRepo    interfaces.CommentReactionRepositoryInterface
+	moderationRepo  interfaces.CommentModerationRepositoryInterface
+	reviewRepo      interfaces.BlogReviewRepositoryInterface
+	mailService     interfaces.MailService
+	settings        BlogSettings
 }
 
-func NewBlogUseCase(repo interfaces.BlogRepositoryInterface, commentRepo interfaces.CommentRepositoryInterface) interfaces.BlogUseCaseInterface {
+// BlogSettings tune the blog use case
+type BlogSettings struct {
+	TrashRetention time.Duration              // how long a deleted blog stays in the trash before it is purged
+	Engagement     entities.EngagementWeights // weigh likes, dislikes, views and comments in engagement sorts and popularity scores
+	// PopularityRecency boosts the popularity scores of recently created blogs
+	PopularityRecency []entities.RecencyBoost
+	// TrendingGravity is how fast interactions fade in trending scores; higher values favour more recent activity
+	TrendingGravity float64
+}
+
+// DefaultBlogSettings returns the settings the blog use case runs with unless the environment overrides them
+func DefaultBlogSettings() BlogSettings {
+	return BlogSettings{
+		TrashRetention: time.Hour * 24 * 30,
+		Engagement:     DefaultEngagementWeights(),
+		// Blogs created within the last day, week and month
+		PopularityRecency: []entities.RecencyBoost{
+			{Within: 24 * time.Hour, Boost: 50},
+			{Within: 7 * 24 * time.Hour, Boost: 20},
+			{Within: 30 * 24 * time.Hour, Boost: 5},
+		},
+		TrendingGravity: 1.8,
+	}
+}
+
+// DefaultEngagementWeights returns the weights of likes, dislikes, views and comments unless the environment overrides them
+func DefaultEngagementWeights() entities.EngagementWeights {
+	return entities.EngagementWeights{Likes: 3, Dislikes: -2, Views: 0.1, Comments: 5}
+}
+
+// trendingWindows are the rolling windows trending blogs are ranked over
+var trendingWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+const (
+	defaultRelatedLimit = 5  // related blogs returned when no limit is given
+	maxRelatedLimit     = 20 // upper bound on related blogs returned
+)
+
+const (
+	defaultTrendingLimit = 10 // trending blogs returned when no limit is given
+	maxTrendingLimit     = 50 // upper bound on trending blogs returned
+)
+
+// popularityScoring is how the materialized popularity scores are computed
+func (u *blogUseCase) popularityScoring() entities.PopularityScoring {
+	return entities.PopularityScoring{Weights: u.settings.Engagement, Recency: u.settings.PopularityRecency}
+}
+
+// BlogDependencies are the repositories and services the blog use case works with
+type BlogDependencies struct {
+	Repo            interfaces.BlogRepositoryInterface
+	CommentRepo     interfaces.CommentRepositoryInterface
+	InteractionRepo interfaces.BlogInteractionRepositoryInterface
+	SeriesRepo      interfaces.SeriesRepositoryInterface
+	UserRepo        interfaces.UserRepository
+	AssetStorage    interfaces.BlogAssetStorage
+	Renderer        interfaces.ContentRenderer
+	PasswordService interfaces.PasswordService
+	AccessTokens    interfaces.BlogAccessTokenService
+	Cursors         interfaces.CursorSigner
+	Related         interfaces.RelatedBlogIndex
+	TagRepo         interfaces.TagRepositoryInterface
+	ReactionRepo    interfaces.CommentReactionRepositoryInterface
+	ModerationRepo  interfaces.CommentModerationRepositoryInterface
+	ReviewRepo      interfaces.BlogReviewRepositoryInterface
+	MailService     interfaces.MailService
+}
+
+func NewBlogUseCase(deps BlogDependencies, settings BlogSettings) interfaces.BlogUseCaseInterface {
 	return &blogUseCase{
-		repo:        repo,
-		commentRepo: commentRepo,
+		repo:            deps.Repo,
+		commentRepo:     deps.CommentRepo,
+		interactionRepo: deps.InteractionRepo,
+		seriesRepo:      deps.SeriesRepo,
+		userRepo:        deps.UserRepo,
+		assetStorage:    deps.AssetStorage,
+		renderer:        deps.Renderer,
+		passwordService: deps.PasswordService,
+		accessTokens:    deps.AccessTokens,
+		cursors:         deps.Cursors,
+		related:         deps.Related,
+		tagRepo:         deps.TagRepo,
+		reactionRepo:    deps.ReactionRepo,
+		moderationRepo:  deps.ModerationRepo,
+		reviewRepo:      deps.ReviewRepo,
+		mailService:     deps.MailService,
+		settings:        settings,
+	}
+}
+
+const (
+	excerptLength     = 200    // maximum excerpt length in characters
+	wordsPerMinute    = 200    // average reading speed used for the reading time estimate
+	minPasswordLength = 4      // minimum passphrase length of password-protected blogs
+	maxContentLength  = 100000 // longest Markdown source accepted, in characters
+	maxSlugAttempts   = 3      // saves retried when a concurrent write takes the chosen slug
+)
+
+// errContentTooLong is returned when the Markdown source exceeds maxContentLength
+var errContentTooLong = fmt.Errorf("content must be at most %d characters", maxContentLength)
+
+// checkContentLength bounds the source the renderer works on
+func checkContentLength(content string) error {
+	if utf8.RuneCountInString(content) > maxContentLength {
+		return errContentTooLong
 	}
+	return nil
 }
 
 func (u *blogUseCase) CreateBlog(ctx context.Context, blog *entities.Blog, userID string) error {
+	if err := checkContentLength(blog.Content); err != nil {
+		return err
+	}
+
 	// Generate a new ObjectID for the blog
 	blog.ID = primitive.NewObjectID()
 
@@ -37,98 +168,992 @@ func (u *blogUseCase) CreateBlog(ctx context.Context, blog *entities.Blog, userI
 	blog.CreatedAt = now
 	blog.UpdatedAt = now
 
-	return u.repo.CreateBlog(ctx, blog)
+	// Schedules are only set through ScheduleBlog
+	blog.PublishAt = nil
+	blog.UnpublishAt = nil
+	blog.PublishedAt = nil
+
+	// New blogs start as drafts unless the author publishes them right away
+	switch blog.Status {
+	case "":
+		blog.Status = entities.BlogStatusDraft
+	case entities.BlogStatusDraft:
+	case entities.BlogStatusPublished:
+		blog.PublishedAt = &now
+	default:
+		return errors.New("invalid status value. Valid values: draft, published")
+	}
+
+	// Passphrases are only set through SetBlogVisibility
+	switch blog.Visibility {
+	case "", entities.BlogVisibilityPublic, entities.BlogVisibilityUnlisted, entities.BlogVisibilityPrivate:
+	default:
+		return errors.New("invalid visibility value. Valid values: public, unlisted, private")
+	}
+	blog.PasswordHash = ""
+
+	// Reviews are only started through the review workflow
+	blog.ReviewStatus, blog.ReviewRound, blog.SubmittedAt = "", 0, nil
+	if blog.Status == entities.BlogStatusPublished {
+		if err := u.checkReviewApproval(ctx, blog); err != nil {
+			return err
+		}
+	}
+
+	// Tags are stored in their canonical form so synonyms do not split the same content
+	tags, err := canonicalTags(ctx, u.tagRepo, blog.Tags)
+	if err != nil {
+		return err
+	}
+	blog.Tags = tags
+
+	// Render the Markdown source to sanitized HTML
+	u.renderContent(blog)
+
+	// New blogs start with no comments and the full recency boost; interactions move the score from there
+	blog.CommentCount = 0
+	blog.PopularityScore = u.popularityScoring().RecencyBoost(0)
+
+	// Generate a unique, human-readable slug from the title; when a concurrent create
+	// takes the same slug first, the next free one is picked
+	blog.OldSlugs = nil
+	for attempt := 1; ; attempt++ {
+		slug, err := u.uniqueSlug(ctx, blog.Title)
+		if err != nil {
+			return err
+		}
+		blog.Slug = slug
+		err = u.repo.CreateBlog(ctx, blog)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, interfaces.ErrSlugTaken) || attempt == maxSlugAttempts {
+			return err
+		}
+	}
+	u.refreshRelated(blog)
+	return nil
 }
 
-// GetBlogsByUserID returns paginated blogs for a user
-func (u *blogUseCase) GetBlogsByUserID(ctx context.Context, userID string, page int64, limit int64) ([]*entities.Blog, error) {
-	return u.repo.GetBlogsByUserID(ctx, userID, page, limit)
+// GetBlogsByUserID returns one page of a user's blogs, newest first
+// The owner sees all of their blogs, everyone else only sees published ones
+// A cursor from a previous page takes precedence over the page number
+func (u *blogUseCase) GetBlogsByUserID(ctx context.Context, userID string, viewerID string, page int64, limit int64, cursor string) (*entities.BlogPage, error) {
+	sort := entities.ListSort{Field: entities.SortByCreatedAt, Desc: true}
+	position, err := decodeCursor(u.cursors, cursor, sort)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	query := &entities.PageQuery{Limit: int(limit) + 1, Skip: int((page - 1) * limit), Sort: sort, Position: position}
+	blogs, err := u.repo.GetBlogsByUserID(ctx, userID, query, userID != viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*entities.PageCursor, len(blogs))
+	for i, blog := range blogs {
+		positions[i] = blogPosition(blog, sort, 0, nil)
+	}
+	window, err := paginate(u.cursors, positions, int(limit), position, position == nil && query.Skip > 0)
+	if err != nil {
+		return nil, err
+	}
+	blogs = blogs[window.start:window.end]
+	for _, blog := range blogs {
+		u.ensureRendered(blog)
+	}
+	return &entities.BlogPage{Blogs: blogs, Count: len(blogs), NextCursor: window.next, PrevCursor: window.prev}, nil
 }
 
-// GetBlogByID returns a single blog by ID
-func (u *blogUseCase) GetBlogByID(ctx context.Context, id string) (*entities.Blog, error) {
+// GetBlogByID returns a single published blog by ID
+func (u *blogUseCase) GetBlogByID(ctx context.Context, id string, access *entities.BlogAccess) (*entities.Blog, error) {
+	blog, err := u.repo.GetBlogByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isPublished(blog) {
+		return nil, errors.New("blog not found")
+	}
+	if err := u.checkVisibility(blog, access); err != nil {
+		return nil, err
+	}
+	u.ensureRendered(blog)
+	u.attachSeries(ctx, blog)
+	return blog, nil
+}
+
+// GetBlogBySlug returns a single published blog by its current or a previous slug
+// Callers can compare the requested slug with blog.Slug to detect renamed blogs
+func (u *blogUseCase) GetBlogBySlug(ctx context.Context, slug string, access *entities.BlogAccess) (*entities.Blog, error) {
+	blog, err := u.repo.GetBlogBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !isPublished(blog) {
+		return nil, errors.New("blog not found")
+	}
+	if err := u.checkVisibility(blog, access); err != nil {
+		return nil, err
+	}
+	u.ensureRendered(blog)
+	u.attachSeries(ctx, blog)
+	return blog, nil
+}
+
+// checkVisibility decides whether the reader may see a published blog
+func (u *blogUseCase) checkVisibility(blog *entities.Blog, access *entities.BlogAccess) error {
+	return checkBlogVisibility(blog, access, u.accessTokens)
+}
+
+// checkBlogVisibility decides whether the reader may see a published blog or its comments.
+// Private blogs look like missing blogs to everyone but their authors.
+func checkBlogVisibility(blog *entities.Blog, access *entities.BlogAccess, accessTokens interfaces.BlogAccessTokenService) error {
+	if access == nil {
+		access = &entities.BlogAccess{}
+	}
+	switch blog.Visibility {
+	case entities.BlogVisibilityPrivate:
+		if !isAuthor(blog, access.UserID) {
+			return errors.New("blog not found")
+		}
+	case entities.BlogVisibilityPassword:
+		if isAuthor(blog, access.UserID) {
+			return nil
+		}
+		if access.AccessToken == "" || accessTokens.VerifyBlogAccessToken(access.AccessToken, blog.ID.Hex()) != nil {
+			return errors.New("this blog is password protected")
+		}
+	}
+	return nil
+}
+
+// SetBlogVisibility changes who can read a blog; password-protected blogs need a passphrase
+func (u *blogUseCase) SetBlogVisibility(ctx context.Context, id string, request *entities.BlogVisibilityRequest) (*entities.Blog, error) {
+	blog, err := u.repo.GetBlogByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.DeletedAt != nil {
+		return nil, errBlogInTrash
+	}
+
+	passwordHash := ""
+	switch request.Visibility {
+	case entities.BlogVisibilityPublic, entities.BlogVisibilityUnlisted, entities.BlogVisibilityPrivate:
+	case entities.BlogVisibilityPassword:
+		if len(request.Password) < minPasswordLength {
+			return nil, fmt.Errorf("password must be at least %d characters", minPasswordLength)
+		}
+		if passwordHash, err = u.passwordService.HashPassword(request.Password); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("invalid visibility value. Valid values: public, unlisted, private, password")
+	}
+
+	if err := u.repo.UpdateBlogVisibility(ctx, id, request.Visibility, passwordHash); err != nil {
+		return nil, err
+	}
+	blog.Visibility, blog.PasswordHash = request.Visibility, passwordHash
+	u.refreshRelated(blog)
+	return blog, nil
+}
+
+// UnlockBlog checks the passphrase of a password-protected blog and issues a token for reading it
+func (u *blogUseCase) UnlockBlog(ctx context.Context, id string, password string) (*entities.BlogAccessToken, error) {
+	blog, err := u.repo.GetBlogByID(ctx, id)
+	if err != nil || !isPublished(blog) {
+		return nil, errors.New("blog not found")
+	}
+	if blog.Visibility != entities.BlogVisibilityPassword {
+		return nil, errors.New("blog is not password protected")
+	}
+	if err := u.passwordService.VerifyPassword(blog.PasswordHash, password); err != nil {
+		return nil, errors.New("invalid password")
+	}
+
+	token, expiresAt, err := u.accessTokens.CreateBlogAccessToken(blog.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BlogAccessToken{AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// GetBlogByIDForOwner returns a single blog by ID whatever its status
+func (u *blogUseCase) GetBlogByIDForOwner(ctx context.Context, id string) (*entities.Blog, error) {
 	return u.repo.GetBlogByID(ctx, id)
 }
 
-// UpdateBlog updates an existing blog
-func (u *blogUseCase) UpdateBlog(ctx context.Context, blog *entities.Blog) error {
-	// Update timestamp
+// AddCoAuthor lets another user edit the blog; the user is found by ID or, if the identifier contains "@", by email
+func (u *blogUseCase) AddCoAuthor(ctx context.Context, blogID string, identifier string) (*entities.Blog, error) {
+	blog, err := u.repo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+
+	identifier = strings.TrimSpace(identifier)
+	var user *entities.User
+	if strings.Contains(identifier, "@") {
+		user, err = u.userRepo.FindByEmail(ctx, strings.ToLower(identifier))
+	} else {
+		var oid primitive.ObjectID
+		if oid, err = primitive.ObjectIDFromHex(identifier); err == nil {
+			user, err = u.userRepo.FindByID(ctx, oid)
+		}
+	}
+	if err != nil || user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	userID := user.ID.Hex()
+	if userID == blog.UserID {
+		return nil, errors.New("the owner is already an author of this blog")
+	}
+	for _, coAuthor := range blog.CoAuthors {
+		if coAuthor == userID {
+			return nil, errors.New("user is already a co-author")
+		}
+	}
+
+	blog.CoAuthors = append(blog.CoAuthors, userID)
+	if err := u.repo.UpdateBlogCoAuthors(ctx, blogID, blog.CoAuthors); err != nil {
+		return nil, err
+	}
+	return blog, nil
+}
+
+// RemoveCoAuthor revokes a co-author's access to the blog
+func (u *blogUseCase) RemoveCoAuthor(ctx context.Context, blogID string, userID string) (*entities.Blog, error) {
+	blog, err := u.repo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+
+	for i, coAuthor := range blog.CoAuthors {
+		if coAuthor == userID {
+			blog.CoAuthors = append(blog.CoAuthors[:i], blog.CoAuthors[i+1:]...)
+			if err := u.repo.UpdateBlogCoAuthors(ctx, blogID, blog.CoAuthors); err != nil {
+				return nil, err
+			}
+			return blog, nil
+		}
+	}
+	return nil, errors.New("user is not a co-author of this blog")
+}
+
+// checkReviewApproval blocks regular users from publishing blogs a reviewer has not approved.
+// Reviewers and admins publish directly, and blogs that were published before need no new review.
+func (u *blogUseCase) checkReviewApproval(ctx context.Context, blog *entities.Blog) error {
+	if blog.ReviewStatus == entities.ReviewStatusApproved || (blog.PublishedAt != nil && blog.Status != entities.BlogStatusPublished) {
+		return nil
+	}
+	if oid, err := primitive.ObjectIDFromHex(blog.UserID); err == nil {
+		user, err := u.userRepo.FindByID(ctx, oid)
+		if err == nil && user != nil && (user.Role == entities.RoleAdmin || user.Role == entities.RoleReviewer) {
+			return nil
+		}
+	}
+	return errors.New("blog must be approved by a reviewer before it is published")
+}
+
+// PublishBlog makes a blog publicly visible
+func (u *blogUseCase) PublishBlog(ctx context.Context, id string) (*entities.Blog, error) {
+	blog, err := u.untrashedBlog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if blog.Status == entities.BlogStatusPublished {
+		return blog, nil
+	}
+	if err := u.checkReviewApproval(ctx, blog); err != nil {
+		return nil, err
+	}
+
+	// Keep the original publication date when a blog is re-published
+	var publishedAt *time.Time
+	if blog.PublishedAt == nil {
+		now := time.Now()
+		publishedAt = &now
+		blog.PublishedAt = publishedAt
+	}
+
+	if err := u.repo.UpdateBlogStatus(ctx, id, entities.BlogStatusPublished, publishedAt); err != nil {
+		return nil, err
+	}
+	blog.Status = entities.BlogStatusPublished
+	blog.PublishAt = nil
+	u.refreshRelated(blog)
+	return blog, nil
+}
+
+// UnpublishBlog moves a blog back to draft
+func (u *blogUseCase) UnpublishBlog(ctx context.Context, id string) (*entities.Blog, error) {
+	return u.changeStatus(ctx, id, entities.BlogStatusDraft)
+}
+
+// ArchiveBlog hides a blog from the public without deleting it
+func (u *blogUseCase) ArchiveBlog(ctx context.Context, id string) (*entities.Blog, error) {
+	return u.changeStatus(ctx, id, entities.BlogStatusArchived)
+}
+
+// ScheduleBlog queues a blog to be published and/or unpublished at a later time
+func (u *blogUseCase) ScheduleBlog(ctx context.Context, id string, schedule *entities.BlogSchedule) (*entities.Blog, error) {
+	if schedule.PublishAt == nil && schedule.UnpublishAt == nil {
+		return nil, errors.New("at least one of publish_at or unpublish_at must be provided")
+	}
+
+	now := time.Now()
+	if schedule.PublishAt != nil && !schedule.PublishAt.After(now) {
+		return nil, errors.New("publish_at must be in the future")
+	}
+	if schedule.UnpublishAt != nil && !schedule.UnpublishAt.After(now) {
+		return nil, errors.New("unpublish_at must be in the future")
+	}
+	if schedule.PublishAt != nil && schedule.UnpublishAt != nil && !schedule.UnpublishAt.After(*schedule.PublishAt) {
+		return nil, errors.New("unpublish_at must be after publish_at")
+	}
+
+	blog, err := u.untrashedBlog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := blog.Status
+	if status == "" {
+		status = entities.BlogStatusPublished
+	}
+	publishAt := blog.PublishAt
+	if schedule.PublishAt != nil {
+		if isPublished(blog) {
+			return nil, errors.New("blog is already published")
+		}
+		if err := u.checkReviewApproval(ctx, blog); err != nil {
+			return nil, err
+		}
+		status = entities.BlogStatusScheduled
+		publishAt = schedule.PublishAt
+	} else if status != entities.BlogStatusScheduled && !isPublished(blog) {
+		// An expiry on its own only makes sense for a blog that is (or will be) live
+		return nil, errors.New("only published or scheduled blogs can be scheduled for unpublishing")
+	}
+
+	unpublishAt := blog.UnpublishAt
+	if schedule.UnpublishAt != nil {
+		unpublishAt = schedule.UnpublishAt
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return nil, errors.New("unpublish_at must be after publish_at")
+	}
+
+	if err := u.repo.UpdateBlogSchedule(ctx, id, status, publishAt, unpublishAt); err != nil {
+		return nil, err
+	}
+	blog.Status = status
+	blog.PublishAt = publishAt
+	blog.UnpublishAt = unpublishAt
+	u.refreshRelated(blog)
+	return blog, nil
+}
+
+// CancelBlogSchedule removes any pending publish/unpublish time
+// A scheduled blog falls back to draft
+func (u *blogUseCase) CancelBlogSchedule(ctx context.Context, id string) (*entities.Blog, error) {
+	blog, err := u.untrashedBlog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := blog.Status
+	if status == entities.BlogStatusScheduled {
+		status = entities.BlogStatusDraft
+	}
+	if err := u.repo.UpdateBlogSchedule(ctx, id, status, nil, nil); err != nil {
+		return nil, err
+	}
+	blog.Status = status
+	blog.PublishAt = nil
+	blog.UnpublishAt = nil
+	u.refreshRelated(blog)
+	return blog, nil
+}
+
+// ApplyBlogSchedules flips scheduled blogs whose publish or unpublish time has passed
+func (u *blogUseCase) ApplyBlogSchedules(ctx context.Context, now time.Time) (int64, int64, error) {
+	published, err := u.repo.PublishDueBlogs(ctx, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	unpublished, err := u.repo.UnpublishExpiredBlogs(ctx, now)
+	if err != nil {
+		return published, 0, err
+	}
+	if published > 0 || unpublished > 0 {
+		u.related.Invalidate()
+	}
+	return published, unpublished, nil
+}
+
+// changeStatus moves a blog to a non-published status
+func (u *blogUseCase) changeStatus(ctx context.Context, id string, status entities.BlogStatus) (*entities.Blog, error) {
+	blog, err := u.untrashedBlog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if blog.Status == status {
+		return blog, nil
+	}
+	if err := u.repo.UpdateBlogStatus(ctx, id, status, nil); err != nil {
+		return nil, err
+	}
+	blog.Status = status
+	blog.PublishAt = nil
+	u.refreshRelated(blog)
+	return blog, nil
+}
+
+// errBlogInTrash refuses status, schedule and visibility changes of trashed blogs
+var errBlogInTrash = errors.New("blog is in the trash, restore it first")
+
+// untrashedBlog returns a blog whose lifecycle may change; trashed blogs have to be restored first
+func (u *blogUseCase) untrashedBlog(ctx context.Context, id string) (*entities.Blog, error) {
+	blog, err := u.repo.GetBlogByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if blog.DeletedAt != nil {
+		return nil, errBlogInTrash
+	}
+	return blog, nil
+}
+
+// isListed reports whether a blog may appear in public listings (blogs stored before visibility existed are public)
+func isListed(blog *entities.Blog) bool {
+	return blog.Visibility == "" || blog.Visibility == entities.BlogVisibilityPublic
+}
+
+// isPublished reports whether a blog is publicly visible right now
+// Blogs stored before statuses were introduced have an empty status and count as published
+func isPublished(blog *entities.Blog) bool {
+	now := time.Now()
+	if blog.DeletedAt != nil {
+		return false
+	}
+	if blog.UnpublishAt != nil && !blog.UnpublishAt.After(now) {
+		return false
+	}
+	switch blog.Status {
+	case entities.BlogStatusPublished, "":
+		return true
+	case entities.BlogStatusScheduled:
+		return blog.PublishAt != nil && !blog.PublishAt.After(now)
+	}
+	return false
+}
+
+// UpdateBlog updates an existing blog; the repository keeps the previous content as a revision
+func (u *blogUseCase) UpdateBlog(ctx context.Context, blog *entities.Blog, editorID string) error {
+	if err := checkContentLength(blog.Content); err != nil {
+		return err
+	}
+
+	// Update timestamp and editor
 	blog.UpdatedAt = time.Now()
-	return u.repo.UpdateBlog(ctx, blog)
+	blog.UpdatedBy = editorID
+
+	tags, err := canonicalTags(ctx, u.tagRepo, blog.Tags)
+	if err != nil {
+		return err
+	}
+	blog.Tags = tags
+
+	// Re-render the Markdown source
+	u.renderContent(blog)
+
+	// An approval covers the reviewed content; editing an unpublished approved blog needs a new review
+	withdrawn := blog.ReviewStatus == entities.ReviewStatusApproved && blog.PublishedAt == nil
+	if withdrawn {
+		blog.ReviewStatus = entities.ReviewStatusApprovalWithdrawn
+		// A scheduled publication would put the unreviewed content live, so the blog goes back to draft.
+		// This happens before the content is saved, so the scheduler never publishes the edit.
+		if blog.Status == entities.BlogStatusScheduled {
+			if err := u.repo.UpdateBlogSchedule(ctx, blog.ID.Hex(), entities.BlogStatusDraft, nil, blog.UnpublishAt); err != nil {
+				return err
+			}
+			blog.Status = entities.BlogStatusDraft
+			blog.PublishAt = nil
+		}
+	}
+
+	// A new title gets a new slug; the old one is kept so existing links redirect.
+	// When a concurrent create or rename takes the new slug first, the next free one is picked.
+	slug, oldSlugs := blog.Slug, blog.OldSlugs
+	renamed := !slugMatchesTitle(blog.Slug, blog.Title)
+	for attempt := 1; ; attempt++ {
+		if renamed {
+			blog.Slug, blog.OldSlugs = slug, oldSlugs
+			if err := u.renameSlug(ctx, blog); err != nil {
+				return err
+			}
+		}
+		err := u.repo.UpdateBlog(ctx, blog)
+		if err == nil {
+			break
+		}
+		if !renamed || !errors.Is(err, interfaces.ErrSlugTaken) || attempt == maxSlugAttempts {
+			return err
+		}
+	}
+	u.refreshRelated(blog)
+
+	// The withdrawal goes into the review history and the owner learns the blog needs a new review
+	if withdrawn {
+		if err := recordReview(ctx, u.reviewRepo, blog, editorID, ""); err != nil {
+			return err
+		}
+		notifyReviewAuthor(ctx, u.userRepo, u.mailService, blog, "")
+	}
+	return nil
 }
 
-// DeleteBlog removes a blog by ID
-func (u *blogUseCase) DeleteBlog(ctx context.Context, id string) error {
-	return u.repo.DeleteBlog(ctx, id)
+// attachSeries adds previous/next/table-of-contents data when the blog is part of a series.
+// Navigation is optional, so lookup failures leave the blog without it.
+func (u *blogUseCase) attachSeries(ctx context.Context, blog *entities.Blog) {
+	series, err := u.seriesRepo.GetSeriesByBlogID(ctx, blog.ID.Hex())
+	if err != nil || series == nil {
+		return
+	}
+	parts, err := publishedSeriesParts(ctx, u.repo, series)
+	if err != nil {
+		return
+	}
+
+	nav := &entities.SeriesNavigation{
+		SeriesID: series.ID,
+		Title:    series.Title,
+		Total:    len(parts),
+		Parts:    parts,
+	}
+	for i := range parts {
+		if parts[i].BlogID != blog.ID {
+			continue
+		}
+		nav.Position = parts[i].Position
+		if i > 0 {
+			nav.Previous = &parts[i-1]
+		}
+		if i < len(parts)-1 {
+			nav.Next = &parts[i+1]
+		}
+	}
+	blog.Series = nav
 }
 
-// calculatePopularityScore calculates the popularity score for a blog
-func (u *blogUseCase) calculatePopularityScore(blog *entities.Blog) float64 {
-	// Get comment count
-	commentCount, _ := u.commentRepo.GetCommentCountByBlogID(context.Background(), blog.ID.Hex())
+// renderContent renders the Markdown source and fills the derived content fields
+func (u *blogUseCase) renderContent(blog *entities.Blog) {
+	rendered := u.renderer.Render(blog.Content)
+	blog.ContentHTML = rendered.HTML
+	blog.Excerpt = excerpt(rendered.PlainText, excerptLength)
+	blog.WordCount = len(strings.Fields(rendered.PlainText))
+	blog.ReadingTime = readingTime(blog.WordCount)
+}
 
-	// Base popularity score using your algorithm
-	score := float64(blog.LikeCount*3) + float64(commentCount*5) + float64(blog.ViewCount)*0.1 + float64(blog.DislikeCount*-2)
+// ensureRendered renders blogs stored before Markdown rendering was introduced
+func (u *blogUseCase) ensureRendered(blog *entities.Blog) {
+	if blog.ContentHTML == "" && blog.Content != "" {
+		u.renderContent(blog)
+	}
+}
 
-	// Recency boost
-	recencyBoost := u.calculateRecencyBoost(blog.CreatedAt)
+// excerpt shortens plain text to at most max characters, cutting at a word boundary
+func excerpt(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	cut := string(runes[:max])
+	if space := strings.LastIndex(cut, " "); space > 0 {
+		cut = cut[:space]
+	}
+	return strings.TrimRight(cut, " .,;:!?-") + "…"
+}
 
-	return score + recencyBoost
+// readingTime estimates the reading time in minutes (at least one minute for non-empty content)
+func readingTime(words int) int {
+	if words == 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
 }
 
-// calculateRecencyBoost calculates bonus points for recent posts
-func (u *blogUseCase) calculateRecencyBoost(createdAt time.Time) float64 {
-	daysSinceCreation := time.Since(createdAt).Hours() / 24
+// renameSlug gives a blog a slug matching its current title
+func (u *blogUseCase) renameSlug(ctx context.Context, blog *entities.Blog) error {
+	previous := blog.Slug
 
-	if daysSinceCreation <= 1 {
-		return 50 // Very recent posts
-	} else if daysSinceCreation <= 7 {
-		return 20 // Posts from this week
-	} else if daysSinceCreation <= 30 {
-		return 5 // Posts from this month
+	// Renaming back to an earlier title reuses the blog's own earlier slug
+	newSlug := ""
+	oldSlugs := make([]string, 0, len(blog.OldSlugs)+1)
+	for _, old := range blog.OldSlugs {
+		if newSlug == "" && slugMatchesTitle(old, blog.Title) {
+			newSlug = old
+			continue
+		}
+		oldSlugs = append(oldSlugs, old)
+	}
+	if newSlug == "" {
+		slug, err := u.uniqueSlug(ctx, blog.Title)
+		if err != nil {
+			return err
+		}
+		newSlug = slug
+	}
+	if previous != "" {
+		oldSlugs = append(oldSlugs, previous)
 	}
-	return 0 // Older posts
+
+	blog.Slug = newSlug
+	blog.OldSlugs = oldSlugs
+	return nil
 }
 
-// GetPopularBlogs retrieves blogs sorted by popularity score
-func (u *blogUseCase) GetPopularBlogs(ctx context.Context, limit int64) ([]*entities.BlogWithPopularity, error) {
-	blogs, err := u.repo.GetAllBlogs(ctx)
+// uniqueSlug builds a slug from the title that is not used by any blog,
+// appending -2, -3, ... when the plain slug is taken
+func (u *blogUseCase) uniqueSlug(ctx context.Context, title string) (string, error) {
+	base := utils.Slugify(title)
+	candidate := base
+	for i := 2; i <= 100; i++ {
+		exists, err := u.repo.SlugExists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	// Extremely common titles fall back to a random suffix
+	return fmt.Sprintf("%s-%s", base, primitive.NewObjectID().Hex()), nil
+}
+
+// slugMatchesTitle reports whether slug was generated from title (with or without a numeric suffix)
+func slugMatchesTitle(slug string, title string) bool {
+	if slug == "" {
+		return false
+	}
+	base := utils.Slugify(title)
+	if slug == base {
+		return true
+	}
+	suffix, found := strings.CutPrefix(slug, base+"-")
+	if !found || suffix == "" {
+		return false
+	}
+	if len(suffix) == 24 {
+		// random ObjectID suffix
+		if _, err := primitive.ObjectIDFromHex(suffix); err == nil {
+			return true
+		}
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
+
+// GetBlogRevisions lists the revisions of a blog, newest first
+func (u *blogUseCase) GetBlogRevisions(ctx context.Context, blogID string) ([]*entities.BlogRevision, error) {
+	return u.repo.GetBlogRevisions(ctx, blogID)
+}
+
+// GetBlogRevision returns a single revision of a blog
+func (u *blogUseCase) GetBlogRevision(ctx context.Context, blogID string, version int) (*entities.BlogRevision, error) {
+	return u.repo.GetBlogRevision(ctx, blogID, version)
+}
+
+// DiffBlogRevisions returns a unified diff between two revisions of a blog
+func (u *blogUseCase) DiffBlogRevisions(ctx context.Context, blogID string, from int, to int) (*entities.BlogRevisionDiff, error) {
+	if from < 1 || to < 1 {
+		return nil, errors.New("revision versions must be positive")
+	}
+
+	fromRevision, err := u.repo.GetBlogRevision(ctx, blogID, from)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d not found", from)
+	}
+	toRevision, err := u.repo.GetBlogRevision(ctx, blogID, to)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d not found", to)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(renderRevision(fromRevision)),
+		B:        difflib.SplitLines(renderRevision(toRevision)),
+		FromFile: fmt.Sprintf("revision %d", from),
+		FromDate: fromRevision.CreatedAt.Format(time.RFC3339),
+		ToFile:   fmt.Sprintf("revision %d", to),
+		ToDate:   toRevision.CreatedAt.Format(time.RFC3339),
+		Context:  3,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to BlogWithPopularity and calculate scores
-	popularBlogs := make([]*entities.BlogWithPopularity, 0, len(blogs))
+	return &entities.BlogRevisionDiff{
+		BlogID: blogID,
+		From:   from,
+		To:     to,
+		Diff:   diff,
+	}, nil
+}
+
+// RestoreBlogRevision makes an old revision the current content of the blog
+// The restored content is stored as a new revision so history stays linear
+func (u *blogUseCase) RestoreBlogRevision(ctx context.Context, blogID string, version int, editorID string) (*entities.Blog, error) {
+	revision, err := u.repo.GetBlogRevision(ctx, blogID, version)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d not found", version)
+	}
+	blog, err := u.repo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	blog.Title = revision.Title
+	blog.Content = revision.Content
+	blog.Tags = revision.Tags
+	if err := u.UpdateBlog(ctx, blog, editorID); err != nil {
+		return nil, err
+	}
+	return blog, nil
+}
+
+// renderRevision formats a revision as plain text for diffing
+func renderRevision(revision *entities.BlogRevision) string {
+	text := "Title: " + revision.Title + "\n" +
+		"Tags: " + strings.Join(revision.Tags, ", ") + "\n\n" +
+		revision.Content
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text
+}
+
+// DeleteBlog moves a blog to the trash; it can be restored until the trash retention period has passed
+func (u *blogUseCase) DeleteBlog(ctx context.Context, id string) error {
+	now := time.Now()
+	if err := u.repo.TrashBlog(ctx, id, now, now.Add(u.settings.TrashRetention)); err != nil {
+		return errors.New("blog not found or already in the trash")
+	}
+	u.related.Remove(id)
+	return nil
+}
+
+// GetTrashedBlogs lists the blogs a user has deleted that are not purged yet
+func (u *blogUseCase) GetTrashedBlogs(ctx context.Context, userID string) ([]*entities.Blog, error) {
+	blogs, err := u.repo.GetTrashedBlogsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 	for _, blog := range blogs {
-		commentCount, _ := u.commentRepo.GetCommentCountByBlogID(ctx, blog.ID.Hex())
-
-		popularBlog := &entities.BlogWithPopularity{
-			ID:              blog.ID,
-			Title:           blog.Title,
-			Content:         blog.Content,
-			UserID:          blog.UserID,
-			LikeCount:       blog.LikeCount,
-			DislikeCount:    blog.DislikeCount,
-			ViewCount:       blog.ViewCount,
-			CommentCount:    int(commentCount),
-			PopularityScore: u.calculatePopularityScore(blog),
-			CreatedAt:       blog.CreatedAt,
-			UpdatedAt:       blog.UpdatedAt,
-		}
-		popularBlogs = append(popularBlogs, popularBlog)
-	}
-
-	// Sort by popularity score (descending)
-	sort.Slice(popularBlogs, func(i, j int) bool {
-		return popularBlogs[i].PopularityScore > popularBlogs[j].PopularityScore
-	})
+		u.ensureRendered(blog)
+	}
+	return blogs, nil
+}
 
-	// Apply limit
-	if limit > 0 && int64(len(popularBlogs)) > limit {
-		popularBlogs = popularBlogs[:limit]
+// RestoreBlog takes a blog out of the trash with its previous status
+func (u *blogUseCase) RestoreBlog(ctx context.Context, id string) (*entities.Blog, error) {
+	blog, err := u.repo.GetBlogByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.DeletedAt == nil {
+		return nil, errors.New("blog is not in the trash")
+	}
+	if err := u.repo.RestoreBlog(ctx, id); err != nil {
+		return nil, err
+	}
+	restored, err := u.repo.GetBlogByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	u.refreshRelated(restored)
+	return restored, nil
+}
+
+// PurgeTrashedBlogs permanently removes trashed blogs whose retention has ended.
+// Comment reactions, comments, interactions, series entries and assets go first so a failed purge is retried on the next run.
+func (u *blogUseCase) PurgeTrashedBlogs(ctx context.Context, now time.Time) (int64, error) {
+	blogs, err := u.repo.GetExpiredTrashedBlogs(ctx, now)
+	if err != nil {
+		return 0, err
 	}
 
-	return popularBlogs, nil
+	var purged int64
+	for _, blog := range blogs {
+		blogID := blog.ID.Hex()
+		if err := u.reactionRepo.DeleteReactionsByBlogID(ctx, blogID); err != nil {
+			return purged, err
+		}
+		if err := u.commentRepo.DeleteCommentsByBlogID(ctx, blogID); err != nil {
+			return purged, err
+		}
+		if err := u.moderationRepo.DeleteActionsByBlogID(ctx, blogID); err != nil {
+			return purged, err
+		}
+		if err := u.reviewRepo.DeleteReviewsByBlogID(ctx, blogID); err != nil {
+			return purged, err
+		}
+		if err := u.interactionRepo.DeleteInteractionsByBlogID(ctx, blogID); err != nil {
+			return purged, err
+		}
+		if err := u.seriesRepo.RemoveBlogFromSeries(ctx, blogID); err != nil {
+			return purged, err
+		}
+		if err := u.assetStorage.DeleteBlogAssets(blogID); err != nil {
+			return purged, err
+		}
+		if err := u.repo.DeleteBlog(ctx, blogID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// RecomputePopularityScores rewrites the popularity score of every blog as of now. Likes, views and
+// comments move the scores as they happen; this expires the recency boosts and picks up weight changes.
+func (u *blogUseCase) RecomputePopularityScores(ctx context.Context, now time.Time) error {
+	return u.repo.RecomputePopularityScores(ctx, u.popularityScoring(), now)
+}
+
+// GetPopularBlogs retrieves the listed blogs with the highest popularity scores
+func (u *blogUseCase) GetPopularBlogs(ctx context.Context, limit int64) ([]*entities.BlogWithPopularity, error) {
+	blogs, err := u.repo.GetPopularBlogs(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, popular := range blogs {
+		// The popular list has its own projection; render through the same path as full blogs
+		blog := entities.Blog{
+			Content:     popular.Content,
+			ContentHTML: popular.ContentHTML,
+			Excerpt:     popular.Excerpt,
+			WordCount:   popular.WordCount,
+			ReadingTime: popular.ReadingTime,
+		}
+		u.ensureRendered(&blog)
+		popular.ContentHTML, popular.Excerpt = blog.ContentHTML, blog.Excerpt
+		popular.WordCount, popular.ReadingTime = blog.WordCount, blog.ReadingTime
+	}
+	return blogs, nil
+}
+
+// GetRelatedBlogs recommends what to read after a blog: the listed blogs with the most similar tags,
+// title and content, best match first. Drafts and other unlisted blogs are never recommended.
+func (u *blogUseCase) GetRelatedBlogs(ctx context.Context, id string, access *entities.BlogAccess, limit int) ([]*entities.RelatedBlog, error) {
+	blog, err := u.repo.GetBlogByID(ctx, id)
+	if err != nil || !isPublished(blog) {
+		return nil, errors.New("blog not found")
+	}
+	if err := u.checkVisibility(blog, access); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+	if limit > maxRelatedLimit {
+		limit = maxRelatedLimit
+	}
+
+	// The index is built from the stored blogs on first use and whenever it gets too old
+	if now := time.Now(); u.related.Stale(now) {
+		listed, err := u.repo.GetListedBlogs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		u.related.Rebuild(listed, now)
+	}
+
+	matches := u.related.Related(blog, limit)
+	ids := make([]primitive.ObjectID, len(matches))
+	for i, match := range matches {
+		ids[i] = toObjectID(match.BlogID)
+	}
+	found, err := u.repo.GetBlogsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entities.Blog, len(found))
+	for _, candidate := range found {
+		byID[candidate.ID.Hex()] = candidate
+	}
+
+	related := []*entities.RelatedBlog{}
+	for _, match := range matches {
+		candidate, ok := byID[match.BlogID]
+		// The index may lag behind changes made through another instance
+		if !ok || !isPublished(candidate) || !isListed(candidate) {
+			continue
+		}
+		u.ensureRendered(candidate)
+		related = append(related, &entities.RelatedBlog{Blog: *candidate, RelatedScore: match.Score, SharedTags: match.SharedTags})
+	}
+	return related, nil
+}
+
+// refreshRelated updates the related blogs index after a blog changed
+func (u *blogUseCase) refreshRelated(blog *entities.Blog) {
+	if isPublished(blog) && isListed(blog) {
+		u.related.Upsert(blog)
+	} else {
+		u.related.Remove(blog.ID.Hex())
+	}
+}
+
+// GetTrendingBlogs ranks the listed blogs by their engagement within a rolling window ("24h" by default, "7d" or "30d"),
+// optionally only blogs tagged with tag. Each interaction fades with its age, so a hot new blog outranks an old viral one.
+func (u *blogUseCase) GetTrendingBlogs(ctx context.Context, window string, tag string, limit int) ([]*entities.TrendingBlog, error) {
+	if window == "" {
+		window = "24h"
+	}
+	length, ok := trendingWindows[window]
+	if !ok {
+		return nil, errors.New("window must be one of 24h, 7d or 30d")
+	}
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+
+	if tag != "" {
+		canonical, err := canonicalTags(ctx, u.tagRepo, []string{tag})
+		if err != nil {
+			return nil, err
+		}
+		tag = ""
+		if len(canonical) > 0 {
+			tag = canonical[0]
+		}
+	}
+
+	now := time.Now()
+	blogs, err := u.interactionRepo.GetTrendingBlogs(ctx, &entities.TrendingQuery{
+		Since:   now.Add(-length),
+		Now:     now,
+		Tag:     tag,
+		Limit:   limit,
+		Weights: u.settings.Engagement,
+		Gravity: u.settings.TrendingGravity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, blog := range blogs {
+		u.ensureRendered(&blog.Blog)
+	}
+	return blogs, nil
 }
 
 // FilterBlogs filters blogs based on provided criteria
@@ -145,33 +1170,56 @@ func (u *blogUseCase) FilterBlogs(ctx context.Context, filter *entities.BlogFilt
 		filter.Limit = 20 // default limit
 	}
 
-	// Validate popularity sort options
-	if filter.PopularitySort != "" {
-		validSortTypes := map[string]bool{
-			"views":      true,
-			"likes":      true,
-			"dislikes":   true,
-			"engagement": true,
-		}
-		if !validSortTypes[filter.PopularitySort] {
-			return nil, errors.New("invalid popularity_sort value. Valid values: views, likes, dislikes, engagement")
-		}
+	// "Golang" finds the blogs tagged with its canonical tag
+	tags, err := canonicalTags(ctx, u.tagRepo, filter.Tags)
+	if err != nil {
+		return nil, err
 	}
+	filter.Tags = tags
 
-	// Validate sort order
-	if filter.SortOrder != "" && filter.SortOrder != "asc" && filter.SortOrder != "desc" {
-		return nil, errors.New("invalid sort_order value. Valid values: asc, desc")
+	// Validate popularity sort options and sort order
+	if err := validatePopularitySort(filter.PopularitySort, filter.SortOrder); err != nil {
+		return nil, err
+	}
+	filter.Sort = blogListSort(filter.PopularitySort, filter.SortOrder, false, u.settings.Engagement)
+	position, err := decodeCursor(u.cursors, filter.Cursor, filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+	filter.Position = position
+
+	// Get filtered blogs from repository; one extra blog tells whether another page follows
+	query := *filter
+	query.Limit++
+	blogs, totalCount, err := u.repo.FilterBlogs(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]*entities.PageCursor, len(blogs))
+	for i, blog := range blogs {
+		positions[i] = blogPosition(&blog.Blog, filter.Sort, 0, blog.EngagementScore)
+	}
+	window, err := paginate(u.cursors, positions, filter.Limit, position, position == nil && filter.Skip > 0)
+	if err != nil {
+		return nil, err
+	}
+	blogs = blogs[window.start:window.end]
+	for _, blog := range blogs {
+		u.ensureRendered(&blog.Blog)
 	}
 
-	// Get filtered blogs from repository
-	blogs, totalCount, err := u.repo.FilterBlogs(ctx, filter)
+	// Count all matching blogs per tag, author and month for drill-down
+	facets, err := u.repo.GetFilterFacets(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate page info
-	page := 1
-	if filter.Skip > 0 && filter.Limit > 0 {
+	// Calculate page info (offset pagination only)
+	page := 0
+	if position == nil {
+		page = 1
+	}
+	if position == nil && filter.Skip > 0 && filter.Limit > 0 {
 		page = (filter.Skip / filter.Limit) + 1
 	}
 
@@ -182,16 +1230,49 @@ func (u *blogUseCase) FilterBlogs(ctx context.Context, filter *entities.BlogFilt
 		TotalCount: totalCount,
 		Page:       page,
 		Limit:      filter.Limit,
+		Facets:     facets,
+		NextCursor: window.next,
+		PrevCursor: window.prev,
 	}
 
 	return response, nil
 }
 
-// SearchBlogs searches for blogs based on title and/or author name
+// validatePopularitySort checks the popularity_sort and sort_order options shared by filtering and search
+func validatePopularitySort(popularitySort string, sortOrder string) error {
+	if popularitySort != "" {
+		validSortTypes := map[string]bool{
+			"views":      true,
+			"likes":      true,
+			"dislikes":   true,
+			"engagement": true,
+		}
+		if !validSortTypes[popularitySort] {
+			return errors.New("invalid popularity_sort value. Valid values: views, likes, dislikes, engagement")
+		}
+	}
+	if sortOrder != "" && sortOrder != "asc" && sortOrder != "desc" {
+		return errors.New("invalid sort_order value. Valid values: asc, desc")
+	}
+	return nil
+}
+
+// SearchBlogs searches for blogs with the query language (text, tags, authors, dates, counters)
+// and/or the title and author parameters
 func (u *blogUseCase) SearchBlogs(ctx context.Context, search *entities.BlogSearch) (*entities.SearchResponse, error) {
+	// Parse the query language; free text is reduced to plain words and phrases so special characters cannot change its meaning
+	parsed, err := parseSearchQuery(search.Query)
+	if err != nil {
+		return nil, err
+	}
+	search.Parsed = parsed
+
 	// Validate search criteria - at least one search parameter must be provided
-	if search.Title == "" && search.Author == "" {
-		return nil, errors.New("at least one search parameter (title or author) must be provided")
+	if len(parsed.Clauses) == 0 && search.Title == "" && search.Author == "" {
+		return nil, errors.New("at least one search parameter (q, title or author) must be provided")
+	}
+	if err := validatePopularitySort(search.PopularitySort, search.SortOrder); err != nil {
+		return nil, err
 	}
 	
 	// Set default values
@@ -206,9 +1287,56 @@ func (u *blogUseCase) SearchBlogs(ctx context.Context, search *entities.BlogSear
 	if search.Skip < 0 {
 		return nil, errors.New("skip must be non-negative")
 	}
+	search.Sort = blogListSort(search.PopularitySort, search.SortOrder, hasFreeText(parsed), u.settings.Engagement)
+	position, err := decodeCursor(u.cursors, search.Cursor, search.Sort)
+	if err != nil {
+		return nil, err
+	}
+	search.Position = position
+
+	// tag: clauses match the stored canonical tags, so "tag:Golang" finds the blogs tagged "go"
+	for i, clause := range parsed.Clauses {
+		if clause.Field != entities.SearchFieldTag {
+			continue
+		}
+		tags, err := canonicalTags(ctx, u.tagRepo, []string{clause.Text})
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) > 0 {
+			parsed.Clauses[i].Text = tags[0]
+		}
+	}
 	
-	// Get search results from repository
-	blogs, totalCount, err := u.repo.SearchBlogs(ctx, search)
+	// Get search results from repository; one extra blog tells whether another page follows
+	query := *search
+	query.Limit++
+	blogs, totalCount, err := u.repo.SearchBlogs(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]*entities.PageCursor, len(blogs))
+	for i, blog := range blogs {
+		positions[i] = blogPosition(&blog.Blog, search.Sort, blog.Score, blog.EngagementScore)
+	}
+	window, err := paginate(u.cursors, positions, search.Limit, position, position == nil && search.Skip > 0)
+	if err != nil {
+		return nil, err
+	}
+	blogs = blogs[window.start:window.end]
+	terms := searchHighlightTerms(parsed)
+	for _, blog := range blogs {
+		u.ensureRendered(&blog.Blog)
+		if len(terms) > 0 {
+			blog.Highlights = &entities.SearchHighlights{
+				Title:   highlightMatches(blog.Title, terms),
+				Snippet: searchSnippet(u.renderer.Render(blog.Content).PlainText, terms),
+			}
+		}
+	}
+
+	// Count all matching blogs per tag, author and month for drill-down
+	facets, err := u.repo.GetSearchFacets(ctx, search)
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +1347,58 @@ func (u *blogUseCase) SearchBlogs(ctx context.Context, search *entities.BlogSear
 		Count:      len(blogs),
 		TotalCount: totalCount,
 		Query:      search,
+		Facets:     facets,
+		NextCursor: window.next,
+		PrevCursor: window.prev,
 	}
 	
 	return response, nil
 }
+
+const (
+	defaultSuggestLimit = 5  // suggestions per kind when no limit is given
+	maxSuggestLimit     = 10 // upper bound on suggestions per kind
+	maxSuggestLength    = 50 // longest prefix accepted, in characters
+)
+
+// SuggestSearch returns the titles, tags and author usernames starting with the typed prefix.
+// The three lookups are served by prefix indexes and run concurrently to keep type-ahead fast.
+func (u *blogUseCase) SuggestSearch(ctx context.Context, query string, limit int) (*entities.SearchSuggestions, error) {
+	prefix := strings.TrimSpace(query)
+	if prefix == "" {
+		return nil, errors.New("q is required")
+	}
+	if utf8.RuneCountInString(prefix) > maxSuggestLength {
+		return nil, fmt.Errorf("q must be at most %d characters", maxSuggestLength)
+	}
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	if limit > maxSuggestLimit {
+		limit = maxSuggestLimit
+	}
+
+	suggestions := &entities.SearchSuggestions{Query: prefix}
+	lookups := []struct {
+		suggest func(context.Context, string, int) ([]entities.Suggestion, error)
+		into    *[]entities.Suggestion
+	}{
+		{u.repo.SuggestTitles, &suggestions.Titles},
+		{u.repo.SuggestTags, &suggestions.Tags},
+		{u.repo.SuggestAuthors, &suggestions.Authors},
+	}
+	errs := make([]error, len(lookups))
+	var wg sync.WaitGroup
+	for i, lookup := range lookups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			*lookup.into, errs[i] = lookup.suggest(ctx, prefix, limit)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
@@ -2,16 +2,76 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/markdown"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/related"
 	repoMocks "github.com/Abenuterefe/a2sv-project/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// newTestBlogUseCase builds a blog use case for tests; dependencies left out get a fresh mock
+// or the real implementation
+func newTestBlogUseCase(t *testing.T, deps BlogDependencies) interfaces.BlogUseCaseInterface {
+	if deps.Repo == nil {
+		deps.Repo = repoMocks.NewBlogRepositoryInterface(t)
+	}
+	if deps.CommentRepo == nil {
+		deps.CommentRepo = repoMocks.NewCommentRepositoryInterface(t)
+	}
+	if deps.InteractionRepo == nil {
+		deps.InteractionRepo = repoMocks.NewBlogInteractionRepositoryInterface(t)
+	}
+	if deps.SeriesRepo == nil {
+		deps.SeriesRepo = repoMocks.NewSeriesRepositoryInterface(t)
+	}
+	if deps.UserRepo == nil {
+		deps.UserRepo = repoMocks.NewUserRepository(t)
+	}
+	if deps.AssetStorage == nil {
+		deps.AssetStorage = repoMocks.NewBlogAssetStorage(t)
+	}
+	if deps.Renderer == nil {
+		deps.Renderer = markdown.NewMarkdownRenderer()
+	}
+	if deps.PasswordService == nil {
+		deps.PasswordService = auth.NewBcryptPasswordService()
+	}
+	if deps.AccessTokens == nil {
+		deps.AccessTokens = auth.NewBlogAccessTokenService()
+	}
+	if deps.Cursors == nil {
+		deps.Cursors = auth.NewCursorSigner()
+	}
+	if deps.Related == nil {
+		deps.Related = related.NewRelatedBlogIndex()
+	}
+	if deps.TagRepo == nil {
+		deps.TagRepo = repoMocks.NewTagRepositoryInterface(t)
+	}
+	if deps.ReactionRepo == nil {
+		deps.ReactionRepo = repoMocks.NewCommentReactionRepositoryInterface(t)
+	}
+	if deps.ModerationRepo == nil {
+		deps.ModerationRepo = repoMocks.NewCommentModerationRepositoryInterface(t)
+	}
+	if deps.ReviewRepo == nil {
+		deps.ReviewRepo = repoMocks.NewBlogReviewRepositoryInterface(t)
+	}
+	if deps.MailService == nil {
+		deps.MailService = repoMocks.NewMailService(t)
+	}
+	return NewBlogUseCase(deps, DefaultBlogSettings())
+}
+
 // Basic contract for NewBlogUseCase validations without hitting repositories
 func TestFilterBlogs_InvalidDateRange(t *testing.T) {
 	t.Parallel()
@@ -19,7 +79,7 @@ func TestFilterBlogs_InvalidDateRange(t *testing.T) {
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
 	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
 
-	uc := NewBlogUseCase(blogRepo, commentRepo)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, CommentRepo: commentRepo})
 
 	// date_from after date_to should be rejected
 	df := time.Now().Add(24 * time.Hour)
@@ -39,13 +99,17 @@ func TestSearchBlogs_MissingParams(t *testing.T) {
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
 	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
 
-	uc := NewBlogUseCase(blogRepo, commentRepo)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, CommentRepo: commentRepo})
 
 	// both title and author are empty
 	resp, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{})
 	assert.Nil(t, resp)
 	assert.Error(t, err)
-	assert.Equal(t, "at least one search parameter (title or author) must be provided", err.Error())
+	assert.Equal(t, "at least one search parameter (q, title or author) must be provided", err.Error())
+
+	// a query made only of special characters is empty
+	_, err = uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: `.*(") +? $`})
+	assert.Error(t, err)
 }
 
 // A tiny happy-path smoke test for SearchBlogs pagination defaults
@@ -55,11 +119,12 @@ func TestSearchBlogs_DefaultLimitAndNonNegative(t *testing.T) {
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
 	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
 
-	uc := NewBlogUseCase(blogRepo, commentRepo)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, CommentRepo: commentRepo})
 
 	blogRepo.On("SearchBlogs", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
-		return s.Title == "Go" && s.Limit == 20 && s.Skip == 0
+		return s.Title == "Go" && s.Limit == 21 && s.Skip == 0 // default limit plus one blog to detect the next page
 	})).Return([]*entities.BlogWithAuthor{}, int64(0), nil)
+	blogRepo.On("GetSearchFacets", mock.Anything, mock.Anything).Return(&entities.SearchFacets{}, nil)
 
 	resp, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{Title: "Go"})
 	assert.NoError(t, err)
@@ -71,7 +136,7 @@ func TestSearchBlogs_DefaultLimitAndNonNegative(t *testing.T) {
 func TestFilterBlogs_InvalidPopularitySort(t *testing.T) {
 	t.Parallel()
 
-	uc := NewBlogUseCase(repoMocks.NewBlogRepositoryInterface(t), repoMocks.NewCommentRepositoryInterface(t))
+	uc := newTestBlogUseCase(t, BlogDependencies{})
 	_, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "unknown"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid popularity_sort value")
@@ -80,7 +145,7 @@ func TestFilterBlogs_InvalidPopularitySort(t *testing.T) {
 func TestFilterBlogs_InvalidSortOrder(t *testing.T) {
 	t.Parallel()
 
-	uc := NewBlogUseCase(repoMocks.NewBlogRepositoryInterface(t), repoMocks.NewCommentRepositoryInterface(t))
+	uc := newTestBlogUseCase(t, BlogDependencies{})
 	_, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{SortOrder: "up"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid sort_order value")
@@ -91,12 +156,13 @@ func TestFilterBlogs_HappyPath_PageAndCount(t *testing.T) {
 
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
 	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
-	uc := NewBlogUseCase(blogRepo, commentRepo)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, CommentRepo: commentRepo})
 
-	blogs := []*entities.Blog{{Title: "A"}, {Title: "B"}}
+	blogs := []*entities.ScoredBlog{{Blog: entities.Blog{Title: "A"}}, {Blog: entities.Blog{Title: "B"}}}
 	blogRepo.On("FilterBlogs", mock.Anything, mock.MatchedBy(func(f *entities.BlogFilter) bool {
-		return f.Limit == 10 && f.Skip == 10 // page=2, limit=10 => skip=10
+		return f.Limit == 11 && f.Skip == 10 // page=2, limit=10 => skip=10, plus one blog to detect the next page
 	})).Return(blogs, int64(25), nil)
+	blogRepo.On("GetFilterFacets", mock.Anything, mock.Anything).Return(&entities.SearchFacets{}, nil)
 
 	// page -> skip conversion logic executed in handler, not in usecase; here we pass Skip directly
 	resp, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{Limit: 10, Skip: 10})
@@ -110,7 +176,7 @@ func TestFilterBlogs_HappyPath_PageAndCount(t *testing.T) {
 func TestSearchBlogs_NegativeLimitSkip(t *testing.T) {
 	t.Parallel()
 
-	uc := NewBlogUseCase(repoMocks.NewBlogRepositoryInterface(t), repoMocks.NewCommentRepositoryInterface(t))
+	uc := newTestBlogUseCase(t, BlogDependencies{})
 
 	_, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{Title: "x", Limit: -1})
 	assert.Error(t, err)
@@ -121,43 +187,131 @@ func TestSearchBlogs_NegativeLimitSkip(t *testing.T) {
 	assert.Contains(t, err.Error(), "skip must be non-negative")
 }
 
-func TestGetPopularBlogs_SortsAndLimits(t *testing.T) {
+func TestGetPopularBlogs_ReadsMaterializedScores(t *testing.T) {
 	t.Parallel()
 
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
-	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
-	uc := NewBlogUseCase(blogRepo, commentRepo)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	// the repository ranks by the stored score; blogs stored before rendering get rendered
+	popular := []*entities.BlogWithPopularity{
+		{ID: primitive.NewObjectID(), Title: "New and liked", Content: "**hot**", PopularityScore: 500},
+		{ID: primitive.NewObjectID(), Title: "Old but many views", ContentHTML: "<p>old</p>", PopularityScore: 140},
+	}
+	blogRepo.On("GetPopularBlogs", mock.Anything, int64(2)).Return(popular, nil)
+
+	blogs, err := uc.GetPopularBlogs(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 2)
+	assert.Equal(t, "New and liked", blogs[0].Title)
+	assert.Contains(t, blogs[0].ContentHTML, "<strong>hot</strong>")
+	assert.Equal(t, "<p>old</p>", blogs[1].ContentHTML)
+}
+
+func TestRecomputePopularityScores_UsesScoring(t *testing.T) {
+	t.Parallel()
+
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	now := time.Now()
+	blogRepo.On("RecomputePopularityScores", mock.Anything, entities.PopularityScoring{Weights: DefaultEngagementWeights(), Recency: DefaultBlogSettings().PopularityRecency}, now).Return(nil)
+	assert.NoError(t, uc.RecomputePopularityScores(context.Background(), now))
+
+	scoring := uc.(*blogUseCase).popularityScoring()
+	assert.Equal(t, 50.0, scoring.RecencyBoost(12*time.Hour))
+	assert.Equal(t, 20.0, scoring.RecencyBoost(3*24*time.Hour))
+	assert.Equal(t, 5.0, scoring.RecencyBoost(10*24*time.Hour))
+	assert.Equal(t, 0.0, scoring.RecencyBoost(40*24*time.Hour))
+}
+
+func TestGetTrendingBlogs_WindowsAndTag(t *testing.T) {
+	t.Parallel()
+
+	interactionRepo := repoMocks.NewBlogInteractionRepositoryInterface(t)
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{InteractionRepo: interactionRepo, TagRepo: tagRepo})
+	// "golang" is a synonym of the canonical "go" tag
+	tagRepo.On("FindTags", mock.Anything, []string{"golang"}).Return([]*entities.Tag{{Slug: "go", Synonyms: []string{"golang"}}}, nil)
+
+	// the last 24 hours by default, with the engagement weights and gravity
+	interactionRepo.On("GetTrendingBlogs", mock.Anything, mock.MatchedBy(func(q *entities.TrendingQuery) bool {
+		return q.Now.Sub(q.Since) == 24*time.Hour && q.Tag == "" && q.Limit == 10 && q.Weights == DefaultEngagementWeights() && q.Gravity == DefaultBlogSettings().TrendingGravity
+	})).Return([]*entities.TrendingBlog{{Blog: entities.Blog{Content: "**hot**"}, TrendingScore: 3}}, nil).Once()
+	blogs, err := uc.GetTrendingBlogs(context.Background(), "", "", 0)
+	assert.NoError(t, err)
+	assert.Contains(t, blogs[0].ContentHTML, "<strong>hot</strong>")
+
+	// "trending in #go" over a week through a synonym, limit capped
+	interactionRepo.On("GetTrendingBlogs", mock.Anything, mock.MatchedBy(func(q *entities.TrendingQuery) bool {
+		return q.Now.Sub(q.Since) == 7*24*time.Hour && q.Tag == "go" && q.Limit == 50
+	})).Return([]*entities.TrendingBlog{}, nil).Once()
+	_, err = uc.GetTrendingBlogs(context.Background(), "7d", " #Golang", 500)
+	assert.NoError(t, err)
 
-	// Create 3 blogs with different metrics
-	b1 := &entities.Blog{ID: primitive.NewObjectID(), Title: "Old but many views", ViewCount: 1000, LikeCount: 10, DislikeCount: 1, CreatedAt: time.Now().Add(-40 * 24 * time.Hour)}
-	b2 := &entities.Blog{ID: primitive.NewObjectID(), Title: "New and liked", ViewCount: 50, LikeCount: 100, DislikeCount: 0, CreatedAt: time.Now().Add(-12 * time.Hour)}
-	b3 := &entities.Blog{ID: primitive.NewObjectID(), Title: "Average", ViewCount: 200, LikeCount: 20, DislikeCount: 2, CreatedAt: time.Now().Add(-10 * 24 * time.Hour)}
+	_, err = uc.GetTrendingBlogs(context.Background(), "1y", "", 0)
+	assert.EqualError(t, err, "window must be one of 24h, 7d or 30d")
+}
+
+func TestGetRelatedBlogs_RanksByTagsAndContent(t *testing.T) {
+	t.Parallel()
+
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, TagRepo: tagRepo})
+	tagRepo.On("FindTags", mock.Anything, mock.Anything).Return([]*entities.Tag{}, nil)
+
+	current := &entities.Blog{ID: primitive.NewObjectID(), Title: "Goroutines explained", Content: "Goroutines and channels make concurrency simple.", Tags: []string{"go", "concurrency"}}
+	similar := &entities.Blog{ID: primitive.NewObjectID(), Title: "Channels in practice", Content: "Buffered channels and goroutines for concurrency.", Tags: []string{"go", "concurrency"}}
+	loose := &entities.Blog{ID: primitive.NewObjectID(), Title: "Go modules", Content: "Versioning dependencies with modules.", Tags: []string{"go"}}
+	unrelated := &entities.Blog{ID: primitive.NewObjectID(), Title: "Sourdough bread", Slug: "sourdough-bread", Content: "Flour, water and patience.", Tags: []string{"baking"}}
+	// unpublished after the index was built, e.g. through another instance
+	drafted := &entities.Blog{ID: primitive.NewObjectID(), Title: "Goroutine leaks", Content: "Leaking goroutines and channels.", Tags: []string{"go"}, Status: entities.BlogStatusDraft}
 
-	blogRepo.On("GetAllBlogs", mock.Anything).Return([]*entities.Blog{b1, b2, b3}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, current.ID.Hex()).Return(current, nil)
+	// the index is built once, on first use
+	blogRepo.On("GetListedBlogs", mock.Anything).Return([]*entities.Blog{current, similar, loose, unrelated, drafted}, nil).Once()
+	blogRepo.On("GetBlogsByIDs", mock.Anything, mock.Anything).Return([]*entities.Blog{loose, drafted, similar, unrelated}, nil)
 
-	// Popularity uses comment counts twice per blog (score + explicit field)
-	// We'll return comment counts per blog consistently
-	counts := map[string]int64{b1.ID.Hex(): 2, b2.ID.Hex(): 30, b3.ID.Hex(): 5}
-	commentRepo.On("GetCommentCountByBlogID", mock.Anything, b1.ID.Hex()).Return(counts[b1.ID.Hex()], nil).Twice()
-	commentRepo.On("GetCommentCountByBlogID", mock.Anything, b2.ID.Hex()).Return(counts[b2.ID.Hex()], nil).Twice()
-	commentRepo.On("GetCommentCountByBlogID", mock.Anything, b3.ID.Hex()).Return(counts[b3.ID.Hex()], nil).Twice()
+	blogs, err := uc.GetRelatedBlogs(context.Background(), current.ID.Hex(), &entities.BlogAccess{}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, blogs, 2) {
+		assert.Equal(t, similar.ID, blogs[0].ID)
+		assert.Equal(t, []string{"concurrency", "go"}, blogs[0].SharedTags)
+		assert.Equal(t, loose.ID, blogs[1].ID)
+		assert.Greater(t, blogs[0].RelatedScore, blogs[1].RelatedScore)
+	}
+
+	// updating a blog refreshes the index without reloading it
+	unrelated.Title, unrelated.Slug, unrelated.Tags = "Concurrency in Go", "concurrency-in-go", []string{"go", "concurrency"}
+	unrelated.Content = "Goroutines and channels for concurrency."
+	blogRepo.On("UpdateBlog", mock.Anything, unrelated).Return(nil)
+	assert.NoError(t, uc.UpdateBlog(context.Background(), unrelated, "u1"))
 
-	popular, err := uc.GetPopularBlogs(context.Background(), 2)
+	blogs, err = uc.GetRelatedBlogs(context.Background(), current.ID.Hex(), &entities.BlogAccess{}, 1)
 	assert.NoError(t, err)
-	assert.Len(t, popular, 2)
-	// Expect the very recent & highly liked b2 to be first
-	assert.Equal(t, "New and liked", popular[0].Title)
+	if assert.Len(t, blogs, 1) {
+		assert.Equal(t, unrelated.ID, blogs[0].ID)
+	}
+
+	// drafts have no related blogs page
+	blogRepo.On("GetBlogByID", mock.Anything, drafted.ID.Hex()).Return(drafted, nil)
+	_, err = uc.GetRelatedBlogs(context.Background(), drafted.ID.Hex(), &entities.BlogAccess{}, 0)
+	assert.EqualError(t, err, "blog not found")
 }
 
 func TestCreateBlog_SetsFieldsAndCallsRepo(t *testing.T) {
 	t.Parallel()
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
 	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
-	uc := NewBlogUseCase(blogRepo, commentRepo)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, CommentRepo: commentRepo})
+
+	blogRepo.On("SlugExists", mock.Anything, "t").Return(false, nil)
 
 	// Expect CreateBlog with blog having ID, userID and timestamps set
 	blogRepo.On("CreateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
-		return b.ID.Hex() != "" && b.UserID == "u1" && !b.CreatedAt.IsZero() && !b.UpdatedAt.IsZero()
+		return b.ID.Hex() != "" && b.UserID == "u1" && !b.CreatedAt.IsZero() && !b.UpdatedAt.IsZero() &&
+			b.PopularityScore == 50 // new blogs start with the full recency boost
 	})).Return(nil)
 
 	err := uc.CreateBlog(context.Background(), &entities.Blog{Title: "t"}, "u1")
@@ -168,15 +322,903 @@ func TestUpdateBlog_UpdatesTimestampAndCallsRepo(t *testing.T) {
 	t.Parallel()
 	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
 	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
-	uc := NewBlogUseCase(blogRepo, commentRepo)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, CommentRepo: commentRepo})
 
 	before := time.Now().Add(-time.Minute)
-	blog := &entities.Blog{Title: "t", UpdatedAt: before}
+	blog := &entities.Blog{Title: "t", Slug: "t", UpdatedAt: before}
 
 	blogRepo.On("UpdateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
-		return b.UpdatedAt.After(before) || b.UpdatedAt.Equal(before) == false
+		return (b.UpdatedAt.After(before) || b.UpdatedAt.Equal(before) == false) && b.UpdatedBy == "u1"
+	})).Return(nil)
+
+	err := uc.UpdateBlog(context.Background(), blog, "u1")
+	assert.NoError(t, err)
+}
+
+func TestUpdateBlog_UnschedulesApprovedBlog(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	userRepo := repoMocks.NewUserRepository(t)
+	reviewRepo := repoMocks.NewBlogReviewRepositoryInterface(t)
+	mailService := repoMocks.NewMailService(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, UserRepo: userRepo, ReviewRepo: reviewRepo, MailService: mailService})
+
+	author := primitive.NewObjectID()
+	publishAt := time.Now().Add(time.Hour)
+	unpublishAt := publishAt.Add(24 * time.Hour)
+	blog := &entities.Blog{ID: primitive.NewObjectID(), UserID: author.Hex(), Title: "t", Slug: "t", Status: entities.BlogStatusScheduled, PublishAt: &publishAt, UnpublishAt: &unpublishAt, ReviewStatus: entities.ReviewStatusApproved, ReviewRound: 2}
+
+	// the edit needs a new review, so the scheduler must not publish it
+	blogRepo.On("UpdateBlogSchedule", mock.Anything, blog.ID.Hex(), entities.BlogStatusDraft, (*time.Time)(nil), &unpublishAt).Return(nil).Once()
+	blogRepo.On("UpdateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.ReviewStatus == entities.ReviewStatusApprovalWithdrawn && b.Status == entities.BlogStatusDraft && b.PublishAt == nil
+	})).Return(nil).Once()
+	// the withdrawal is recorded and the owner is told
+	reviewRepo.On("CreateReview", mock.Anything, mock.MatchedBy(func(r *entities.BlogReview) bool {
+		return r.BlogID == blog.ID && r.Round == 2 && r.UserID == "u1" && r.Status == entities.ReviewStatusApprovalWithdrawn
+	})).Return(nil).Once()
+	userRepo.On("FindByID", mock.Anything, author).Return(&entities.User{ID: author, Email: "author@example.com"}, nil).Once()
+	mailService.On("SendBlogReviewEmail", "author@example.com", "t", "approval_withdrawn", "").Return(nil).Once()
+
+	assert.NoError(t, uc.UpdateBlog(context.Background(), blog, "u1"))
+	assert.Equal(t, entities.BlogStatusDraft, blog.Status)
+
+	// blogs that were published before keep their schedule
+	publishedAt := time.Now().Add(-time.Hour)
+	republish := &entities.Blog{ID: primitive.NewObjectID(), Title: "t", Slug: "t", Status: entities.BlogStatusScheduled, PublishAt: &publishAt, PublishedAt: &publishedAt}
+	blogRepo.On("UpdateBlog", mock.Anything, republish).Return(nil).Once()
+	assert.NoError(t, uc.UpdateBlog(context.Background(), republish, "u1"))
+	assert.Equal(t, entities.BlogStatusScheduled, republish.Status)
+}
+
+func TestCreateBlog_DefaultsToDraftAndRejectsArchived(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	userRepo := repoMocks.NewUserRepository(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, UserRepo: userRepo})
+	admin := primitive.NewObjectID()
+	userRepo.On("FindByID", mock.Anything, admin).Return(&entities.User{ID: admin, Role: entities.RoleAdmin}, nil)
+
+	blogRepo.On("SlugExists", mock.Anything, "t").Return(false, nil)
+	blogRepo.On("CreateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.Status == entities.BlogStatusDraft && b.PublishedAt == nil
+	})).Return(nil).Once()
+	backdated := time.Now().Add(-time.Hour)
+	assert.NoError(t, uc.CreateBlog(context.Background(), &entities.Blog{Title: "t", PublishedAt: &backdated}, "u1"))
+
+	blogRepo.On("CreateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.Status == entities.BlogStatusPublished && b.PublishedAt != nil
+	})).Return(nil).Once()
+	assert.NoError(t, uc.CreateBlog(context.Background(), &entities.Blog{Title: "t", Status: entities.BlogStatusPublished}, admin.Hex()))
+
+	err := uc.CreateBlog(context.Background(), &entities.Blog{Title: "t", Status: entities.BlogStatusArchived}, "u1")
+	assert.Error(t, err)
+}
+
+func TestGetBlogByID_HidesDrafts(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	seriesRepo := repoMocks.NewSeriesRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, SeriesRepo: seriesRepo})
+
+	blogRepo.On("GetBlogByID", mock.Anything, "draft").Return(&entities.Blog{Status: entities.BlogStatusDraft}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "legacy").Return(&entities.Blog{}, nil)
+	seriesRepo.On("GetSeriesByBlogID", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+	_, err := uc.GetBlogByID(context.Background(), "draft", nil)
+	assert.EqualError(t, err, "blog not found")
+
+	// blogs stored before statuses existed stay public
+	blog, err := uc.GetBlogByID(context.Background(), "legacy", nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, blog)
+
+	// the owner lookup ignores the status
+	blog, err = uc.GetBlogByIDForOwner(context.Background(), "draft")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.BlogStatusDraft, blog.Status)
+}
+
+func TestGetBlogsByUserID_OnlyOwnerSeesDrafts(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("GetBlogsByUserID", mock.Anything, "u1", mock.Anything, false).Return([]*entities.Blog{}, nil).Once()
+	blogRepo.On("GetBlogsByUserID", mock.Anything, "u1", mock.Anything, true).Return([]*entities.Blog{}, nil).Once()
+
+	_, err := uc.GetBlogsByUserID(context.Background(), "u1", "u1", 1, 5, "")
+	assert.NoError(t, err)
+	_, err = uc.GetBlogsByUserID(context.Background(), "u1", "u2", 1, 5, "")
+	assert.NoError(t, err)
+}
+
+func TestPublishBlog_SetsPublishedAtOnce(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusApproved}, nil).Once()
+	blogRepo.On("UpdateBlogStatus", mock.Anything, "b1", entities.BlogStatusPublished, mock.MatchedBy(func(p *time.Time) bool {
+		return p != nil
+	})).Return(nil).Once()
+
+	blog, err := uc.PublishBlog(context.Background(), "b1")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.BlogStatusPublished, blog.Status)
+	assert.NotNil(t, blog.PublishedAt)
+
+	// re-publishing an archived blog keeps the original publication date
+	first := time.Now().Add(-48 * time.Hour)
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{Status: entities.BlogStatusArchived, PublishedAt: &first}, nil).Once()
+	blogRepo.On("UpdateBlogStatus", mock.Anything, "b1", entities.BlogStatusPublished, (*time.Time)(nil)).Return(nil).Once()
+
+	blog, err = uc.PublishBlog(context.Background(), "b1")
+	assert.NoError(t, err)
+	assert.Equal(t, first, *blog.PublishedAt)
+}
+
+func TestPublishBlog_RequiresReviewApproval(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	userRepo := repoMocks.NewUserRepository(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, UserRepo: userRepo})
+
+	author := primitive.NewObjectID()
+	userRepo.On("FindByID", mock.Anything, author).Return(&entities.User{ID: author, Role: entities.RoleUser}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{UserID: author.Hex(), Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusSubmitted}, nil)
+
+	_, err := uc.PublishBlog(context.Background(), "b1")
+	assert.EqualError(t, err, "blog must be approved by a reviewer before it is published")
+
+	err = uc.CreateBlog(context.Background(), &entities.Blog{Title: "t", Status: entities.BlogStatusPublished}, author.Hex())
+	assert.EqualError(t, err, "blog must be approved by a reviewer before it is published")
+}
+
+func TestScheduleBlog_Validation(t *testing.T) {
+	t.Parallel()
+	uc := newTestBlogUseCase(t, BlogDependencies{})
+
+	past := time.Now().Add(-time.Hour)
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(2 * time.Hour)
+
+	_, err := uc.ScheduleBlog(context.Background(), "b1", &entities.BlogSchedule{})
+	assert.Error(t, err)
+
+	_, err = uc.ScheduleBlog(context.Background(), "b1", &entities.BlogSchedule{PublishAt: &past})
+	assert.EqualError(t, err, "publish_at must be in the future")
+
+	_, err = uc.ScheduleBlog(context.Background(), "b1", &entities.BlogSchedule{PublishAt: &later, UnpublishAt: &soon})
+	assert.EqualError(t, err, "unpublish_at must be after publish_at")
+}
+
+func TestScheduleBlog_DraftBecomesScheduled(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	publishAt := time.Now().Add(time.Hour)
+	unpublishAt := time.Now().Add(48 * time.Hour)
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusApproved}, nil)
+	blogRepo.On("UpdateBlogSchedule", mock.Anything, "b1", entities.BlogStatusScheduled, &publishAt, &unpublishAt).Return(nil)
+
+	blog, err := uc.ScheduleBlog(context.Background(), "b1", &entities.BlogSchedule{PublishAt: &publishAt, UnpublishAt: &unpublishAt})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.BlogStatusScheduled, blog.Status)
+
+	// not visible before publish_at
+	assert.False(t, isPublished(blog))
+}
+
+func TestScheduleBlog_UnpublishOnlyNeedsLiveBlog(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	unpublishAt := time.Now().Add(time.Hour)
+	blogRepo.On("GetBlogByID", mock.Anything, "draft").Return(&entities.Blog{Status: entities.BlogStatusDraft}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "live").Return(&entities.Blog{Status: entities.BlogStatusPublished}, nil)
+	blogRepo.On("UpdateBlogSchedule", mock.Anything, "live", entities.BlogStatusPublished, (*time.Time)(nil), &unpublishAt).Return(nil)
+
+	_, err := uc.ScheduleBlog(context.Background(), "draft", &entities.BlogSchedule{UnpublishAt: &unpublishAt})
+	assert.Error(t, err)
+
+	blog, err := uc.ScheduleBlog(context.Background(), "live", &entities.BlogSchedule{UnpublishAt: &unpublishAt})
+	assert.NoError(t, err)
+	assert.True(t, isPublished(blog))
+}
+
+func TestIsPublished_RespectsSchedule(t *testing.T) {
+	t.Parallel()
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Minute)
+
+	assert.True(t, isPublished(&entities.Blog{Status: entities.BlogStatusScheduled, PublishAt: &past}))
+	assert.False(t, isPublished(&entities.Blog{Status: entities.BlogStatusScheduled, PublishAt: &future}))
+	assert.False(t, isPublished(&entities.Blog{Status: entities.BlogStatusPublished, UnpublishAt: &past}))
+	assert.True(t, isPublished(&entities.Blog{Status: entities.BlogStatusPublished, UnpublishAt: &future}))
+}
+
+func TestApplyBlogSchedules_CallsRepo(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	now := time.Now()
+	blogRepo.On("PublishDueBlogs", mock.Anything, now).Return(int64(2), nil)
+	blogRepo.On("UnpublishExpiredBlogs", mock.Anything, now).Return(int64(1), nil)
+
+	published, unpublished, err := uc.ApplyBlogSchedules(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), published)
+	assert.Equal(t, int64(1), unpublished)
+}
+
+func TestDiffBlogRevisions_UnifiedDiff(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("GetBlogRevision", mock.Anything, "b1", 1).Return(&entities.BlogRevision{Version: 1, Title: "Go", Content: "line one\nline two", Tags: []string{"go"}}, nil)
+	blogRepo.On("GetBlogRevision", mock.Anything, "b1", 2).Return(&entities.BlogRevision{Version: 2, Title: "Go", Content: "line one\nline 2", Tags: []string{"go"}}, nil)
+	blogRepo.On("GetBlogRevision", mock.Anything, "b1", 9).Return((*entities.BlogRevision)(nil), assert.AnError)
+
+	diff, err := uc.DiffBlogRevisions(context.Background(), "b1", 1, 2)
+	assert.NoError(t, err)
+	assert.Contains(t, diff.Diff, "--- revision 1")
+	assert.Contains(t, diff.Diff, "+++ revision 2")
+	assert.Contains(t, diff.Diff, "-line two")
+	assert.Contains(t, diff.Diff, "+line 2")
+
+	// identical revisions produce an empty diff
+	diff, err = uc.DiffBlogRevisions(context.Background(), "b1", 1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Diff)
+
+	_, err = uc.DiffBlogRevisions(context.Background(), "b1", 1, 9)
+	assert.EqualError(t, err, "revision 9 not found")
+
+	_, err = uc.DiffBlogRevisions(context.Background(), "b1", 0, 1)
+	assert.Error(t, err)
+}
+
+func TestRestoreBlogRevision_StoresAsNewUpdate(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, TagRepo: tagRepo})
+	tagRepo.On("FindTags", mock.Anything, mock.Anything).Return([]*entities.Tag{}, nil)
+
+	blogRepo.On("GetBlogRevision", mock.Anything, "b1", 1).Return(&entities.BlogRevision{Version: 1, Title: "Old", Content: "old body", Tags: []string{"a"}}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{Title: "New", Slug: "new", OldSlugs: []string{"old"}, Content: "new body", Status: entities.BlogStatusPublished}, nil)
+	blogRepo.On("UpdateBlog", mock.Anything, mock.MatchedBy(func(b *entities.Blog) bool {
+		return b.Title == "Old" && b.Content == "old body" && b.UpdatedBy == "editor" && b.Status == entities.BlogStatusPublished &&
+			b.Slug == "old" && len(b.OldSlugs) == 1 && b.OldSlugs[0] == "new"
+	})).Return(nil)
+
+	blog, err := uc.RestoreBlogRevision(context.Background(), "b1", 1, "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "Old", blog.Title)
+}
+
+func TestCreateBlog_UniqueSlug(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("SlugExists", mock.Anything, "hello-go-world").Return(true, nil)
+	blogRepo.On("SlugExists", mock.Anything, "hello-go-world-2").Return(true, nil)
+	blogRepo.On("SlugExists", mock.Anything, "hello-go-world-3").Return(false, nil)
+	blogRepo.On("CreateBlog", mock.Anything, mock.Anything).Return(nil)
+
+	blog := &entities.Blog{Title: "  Hello, Go World!  "}
+	assert.NoError(t, uc.CreateBlog(context.Background(), blog, "u1"))
+	assert.Equal(t, "hello-go-world-3", blog.Slug)
+}
+
+func TestUpdateBlog_TitleChangeKeepsOldSlug(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("SlugExists", mock.Anything, "new-title").Return(false, nil)
+	blogRepo.On("UpdateBlog", mock.Anything, mock.Anything).Return(nil)
+
+	// a numeric suffix still matches the title, so nothing changes
+	blog := &entities.Blog{Title: "Old Title", Slug: "old-title-2"}
+	assert.NoError(t, uc.UpdateBlog(context.Background(), blog, "u1"))
+	assert.Equal(t, "old-title-2", blog.Slug)
+	assert.Empty(t, blog.OldSlugs)
+
+	blog.Title = "New title"
+	assert.NoError(t, uc.UpdateBlog(context.Background(), blog, "u1"))
+	assert.Equal(t, "new-title", blog.Slug)
+	assert.Equal(t, []string{"old-title-2"}, blog.OldSlugs)
+}
+
+func TestSaveBlog_RetriesWhenSlugTakenConcurrently(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	// another blog takes "race" between the check and the insert
+	blogRepo.On("SlugExists", mock.Anything, "race").Return(false, nil).Once()
+	blogRepo.On("CreateBlog", mock.Anything, mock.Anything).Return(interfaces.ErrSlugTaken).Once()
+	blogRepo.On("SlugExists", mock.Anything, "race").Return(true, nil).Once()
+	blogRepo.On("SlugExists", mock.Anything, "race-2").Return(false, nil).Once()
+	blogRepo.On("CreateBlog", mock.Anything, mock.Anything).Return(nil).Once()
+
+	blog := &entities.Blog{Title: "Race"}
+	assert.NoError(t, uc.CreateBlog(context.Background(), blog, "u1"))
+	assert.Equal(t, "race-2", blog.Slug)
+
+	// the same happens to a rename; the taken slug does not end up in the old slugs
+	blogRepo.On("SlugExists", mock.Anything, "renamed").Return(false, nil).Once()
+	blogRepo.On("UpdateBlog", mock.Anything, mock.Anything).Return(interfaces.ErrSlugTaken).Once()
+	blogRepo.On("SlugExists", mock.Anything, "renamed").Return(true, nil).Once()
+	blogRepo.On("SlugExists", mock.Anything, "renamed-2").Return(false, nil).Once()
+	blogRepo.On("UpdateBlog", mock.Anything, mock.Anything).Return(nil).Once()
+
+	blog.Title = "Renamed"
+	assert.NoError(t, uc.UpdateBlog(context.Background(), blog, "u1"))
+	assert.Equal(t, "renamed-2", blog.Slug)
+	assert.Equal(t, []string{"race-2"}, blog.OldSlugs)
+}
+
+func TestGetBlogBySlug_HidesDrafts(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	seriesRepo := repoMocks.NewSeriesRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, SeriesRepo: seriesRepo})
+
+	blogRepo.On("GetBlogBySlug", mock.Anything, "draft").Return(&entities.Blog{Slug: "draft", Status: entities.BlogStatusDraft}, nil)
+	blogRepo.On("GetBlogBySlug", mock.Anything, "old").Return(&entities.Blog{Slug: "new", OldSlugs: []string{"old"}, Status: entities.BlogStatusPublished}, nil)
+	seriesRepo.On("GetSeriesByBlogID", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+	_, err := uc.GetBlogBySlug(context.Background(), "draft", nil)
+	assert.Error(t, err)
+
+	blog, err := uc.GetBlogBySlug(context.Background(), "old", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", blog.Slug)
+}
+
+func TestCreateBlog_RendersSanitizedMarkdown(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("SlugExists", mock.Anything, mock.Anything).Return(false, nil)
+	blogRepo.On("CreateBlog", mock.Anything, mock.Anything).Return(nil)
+
+	blog := &entities.Blog{
+		Title:   "Markdown",
+		Content: "# Hello\n\nSome **bold** text <script>alert(1)</script> and [a link](javascript:alert(1)).\n\n<img src=x onerror=alert(1)>",
+	}
+	assert.NoError(t, uc.CreateBlog(context.Background(), blog, "u1"))
+
+	assert.Contains(t, blog.ContentHTML, "<h1>Hello</h1>")
+	assert.Contains(t, blog.ContentHTML, "<strong>bold</strong>")
+	assert.NotContains(t, blog.ContentHTML, "<script")
+	assert.NotContains(t, blog.ContentHTML, "<img")
+	assert.NotContains(t, blog.ContentHTML, "javascript:")
+	assert.Equal(t, "# Hello\n\nSome **bold** text <script>alert(1)</script> and [a link](javascript:alert(1)).\n\n<img src=x onerror=alert(1)>", blog.Content)
+	assert.Equal(t, 1, blog.ReadingTime)
+	assert.Greater(t, blog.WordCount, 0)
+}
+
+func TestRenderMarkdown_PathologicalInputsStayLinear(t *testing.T) {
+	t.Parallel()
+	renderer := markdown.NewMarkdownRenderer()
+
+	inputs := map[string]string{
+		"unclosed emphasis": strings.Repeat("_a ", 20000),
+		"nested quotes":     strings.Repeat("> ", 20000) + "deep",
+		"unclosed brackets": strings.Repeat("[", 50000),
+		"unclosed links":    strings.Repeat("[a](", 20000),
+		"unclosed code":     strings.Repeat("`` ` ", 10000),
+	}
+	for name, input := range inputs {
+		start := time.Now()
+		rendered := renderer.Render(input)
+		assert.Less(t, time.Since(start), time.Second, name)
+		assert.NotEmpty(t, rendered.HTML, name)
+	}
+
+	rendered := renderer.Render("*a __b__ c* and _d_ with [x](http://e.com/(f)) and `g`")
+	assert.Equal(t, `<p><em>a <strong>b</strong> c</em> and <em>d</em> with <a href="http://e.com/(f)" rel="nofollow noopener noreferrer">x</a> and <code>g</code></p>`+"\n", rendered.HTML)
+}
+
+func TestCreateBlog_RejectsOversizedContent(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blog := &entities.Blog{Title: "Huge", Content: strings.Repeat("a", maxContentLength+1)}
+	assert.EqualError(t, uc.CreateBlog(context.Background(), blog, "u1"), "content must be at most 100000 characters")
+	assert.EqualError(t, uc.UpdateBlog(context.Background(), blog, "u1"), "content must be at most 100000 characters")
+}
+
+func TestUpdateBlog_DerivesExcerptAndReadingTime(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("UpdateBlog", mock.Anything, mock.Anything).Return(nil)
+
+	blog := &entities.Blog{Title: "Long", Slug: "long", Content: "*word* " + strings.Repeat("word ", 449)}
+	assert.NoError(t, uc.UpdateBlog(context.Background(), blog, "u1"))
+
+	assert.Equal(t, 450, blog.WordCount)
+	assert.Equal(t, 3, blog.ReadingTime)
+	assert.True(t, strings.HasPrefix(blog.Excerpt, "word word"))
+	assert.True(t, strings.HasSuffix(blog.Excerpt, "…"))
+	assert.LessOrEqual(t, len([]rune(blog.Excerpt)), 201)
+}
+
+func TestDeleteBlog_MovesToTrash(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("TrashBlog", mock.Anything, "id1", mock.AnythingOfType("time.Time"), mock.MatchedBy(func(purgeAt time.Time) bool {
+		return purgeAt.After(time.Now().Add(DefaultBlogSettings().TrashRetention - time.Minute))
 	})).Return(nil)
 
-	err := uc.UpdateBlog(context.Background(), blog)
+	assert.NoError(t, uc.DeleteBlog(context.Background(), "id1"))
+}
+
+func TestRestoreBlog(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	deletedAt := time.Now()
+	blogRepo.On("GetBlogByID", mock.Anything, "live").Return(&entities.Blog{Status: entities.BlogStatusPublished}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "trashed").Return(&entities.Blog{Status: entities.BlogStatusPublished, DeletedAt: &deletedAt}, nil).Once()
+	blogRepo.On("RestoreBlog", mock.Anything, "trashed").Return(nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "trashed").Return(&entities.Blog{Status: entities.BlogStatusPublished}, nil).Once()
+
+	_, err := uc.RestoreBlog(context.Background(), "live")
+	assert.EqualError(t, err, "blog is not in the trash")
+
+	blog, err := uc.RestoreBlog(context.Background(), "trashed")
+	assert.NoError(t, err)
+	assert.Nil(t, blog.DeletedAt)
+	assert.Equal(t, entities.BlogStatusPublished, blog.Status)
+}
+
+func TestGetBlogByID_HidesTrashed(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	deletedAt := time.Now()
+	blogRepo.On("GetBlogByID", mock.Anything, "id1").Return(&entities.Blog{Status: entities.BlogStatusPublished, DeletedAt: &deletedAt}, nil)
+
+	_, err := uc.GetBlogByID(context.Background(), "id1", nil)
+	assert.Error(t, err)
+}
+
+func TestLifecycleChanges_RejectTrashed(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	deletedAt := time.Now()
+	blogRepo.On("GetBlogByID", mock.Anything, "id1").Return(&entities.Blog{Status: entities.BlogStatusDraft, ReviewStatus: entities.ReviewStatusApproved, DeletedAt: &deletedAt}, nil)
+
+	// nothing is written: the blog has to be restored first
+	publishAt := time.Now().Add(time.Hour)
+	_, err := uc.PublishBlog(context.Background(), "id1")
+	assert.EqualError(t, err, "blog is in the trash, restore it first")
+	_, err = uc.ArchiveBlog(context.Background(), "id1")
+	assert.EqualError(t, err, "blog is in the trash, restore it first")
+	_, err = uc.ScheduleBlog(context.Background(), "id1", &entities.BlogSchedule{PublishAt: &publishAt})
+	assert.EqualError(t, err, "blog is in the trash, restore it first")
+	_, err = uc.CancelBlogSchedule(context.Background(), "id1")
+	assert.EqualError(t, err, "blog is in the trash, restore it first")
+	_, err = uc.SetBlogVisibility(context.Background(), "id1", &entities.BlogVisibilityRequest{Visibility: entities.BlogVisibilityPrivate})
+	assert.EqualError(t, err, "blog is in the trash, restore it first")
+}
+
+func TestPurgeTrashedBlogs_RemovesEverything(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
+	interactionRepo := repoMocks.NewBlogInteractionRepositoryInterface(t)
+	seriesRepo := repoMocks.NewSeriesRepositoryInterface(t)
+	assets := repoMocks.NewBlogAssetStorage(t)
+	reactionRepo := repoMocks.NewCommentReactionRepositoryInterface(t)
+	moderationRepo := repoMocks.NewCommentModerationRepositoryInterface(t)
+	reviewRepo := repoMocks.NewBlogReviewRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, CommentRepo: commentRepo, InteractionRepo: interactionRepo, SeriesRepo: seriesRepo, AssetStorage: assets, ReactionRepo: reactionRepo, ModerationRepo: moderationRepo, ReviewRepo: reviewRepo})
+
+	now := time.Now()
+	first, second := primitive.NewObjectID(), primitive.NewObjectID()
+	blogRepo.On("GetExpiredTrashedBlogs", mock.Anything, now).Return([]*entities.Blog{{ID: first}, {ID: second}}, nil)
+	reactionRepo.On("DeleteReactionsByBlogID", mock.Anything, first.Hex()).Return(nil)
+	commentRepo.On("DeleteCommentsByBlogID", mock.Anything, first.Hex()).Return(nil)
+	moderationRepo.On("DeleteActionsByBlogID", mock.Anything, first.Hex()).Return(nil)
+	reviewRepo.On("DeleteReviewsByBlogID", mock.Anything, first.Hex()).Return(nil)
+	interactionRepo.On("DeleteInteractionsByBlogID", mock.Anything, first.Hex()).Return(nil)
+	seriesRepo.On("RemoveBlogFromSeries", mock.Anything, first.Hex()).Return(nil)
+	assets.On("DeleteBlogAssets", first.Hex()).Return(nil)
+	blogRepo.On("DeleteBlog", mock.Anything, first.Hex()).Return(nil)
+	// the second blog keeps its document when a dependent delete fails, so the next run retries
+	reactionRepo.On("DeleteReactionsByBlogID", mock.Anything, second.Hex()).Return(nil)
+	commentRepo.On("DeleteCommentsByBlogID", mock.Anything, second.Hex()).Return(errors.New("db down"))
+
+	purged, err := uc.PurgeTrashedBlogs(context.Background(), now)
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), purged)
+	blogRepo.AssertNotCalled(t, "DeleteBlog", mock.Anything, second.Hex())
+}
+
+func TestGetBlogByID_SeriesNavigation(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	seriesRepo := repoMocks.NewSeriesRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, SeriesRepo: seriesRepo})
+
+	part1, draft, part2, part3 := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	series := &entities.Series{ID: primitive.NewObjectID(), Title: "Go from zero", BlogIDs: []primitive.ObjectID{part1, draft, part2, part3}}
+	current := &entities.Blog{ID: part2, Title: "Part 2", Status: entities.BlogStatusPublished}
+
+	blogRepo.On("GetBlogByID", mock.Anything, part2.Hex()).Return(current, nil)
+	seriesRepo.On("GetSeriesByBlogID", mock.Anything, part2.Hex()).Return(series, nil)
+	blogRepo.On("GetBlogsByIDs", mock.Anything, series.BlogIDs).Return([]*entities.Blog{
+		{ID: part3, Title: "Part 3", Status: entities.BlogStatusPublished},
+		{ID: part1, Title: "Part 1", Status: entities.BlogStatusPublished},
+		{ID: draft, Title: "Unfinished", Status: entities.BlogStatusDraft},
+		current,
+	}, nil)
+
+	blog, err := uc.GetBlogByID(context.Background(), part2.Hex(), nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, blog.Series) {
+		assert.Equal(t, "Go from zero", blog.Series.Title)
+		assert.Equal(t, 2, blog.Series.Position)
+		assert.Equal(t, 3, blog.Series.Total)
+		assert.Equal(t, "Part 1", blog.Series.Previous.Title)
+		assert.Equal(t, "Part 3", blog.Series.Next.Title)
+		assert.Len(t, blog.Series.Parts, 3) // the draft is not listed
+	}
+}
+
+func TestAddCoAuthor_ByEmailAndID(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	userRepo := repoMocks.NewUserRepository(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, UserRepo: userRepo})
+
+	owner, abel, sara := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(func(context.Context, string) (*entities.Blog, error) {
+		return &entities.Blog{UserID: owner.Hex(), CoAuthors: []string{sara.Hex()}}, nil
+	})
+	userRepo.On("FindByEmail", mock.Anything, "abel@example.com").Return(&entities.User{ID: abel}, nil)
+	userRepo.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, errors.New("no documents"))
+	userRepo.On("FindByID", mock.Anything, owner).Return(&entities.User{ID: owner}, nil)
+	userRepo.On("FindByID", mock.Anything, sara).Return(&entities.User{ID: sara}, nil)
+	blogRepo.On("UpdateBlogCoAuthors", mock.Anything, "b1", []string{sara.Hex(), abel.Hex()}).Return(nil)
+
+	blog, err := uc.AddCoAuthor(context.Background(), "b1", " Abel@Example.com ")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sara.Hex(), abel.Hex()}, blog.CoAuthors)
+
+	_, err = uc.AddCoAuthor(context.Background(), "b1", "nobody@example.com")
+	assert.EqualError(t, err, "user not found")
+	_, err = uc.AddCoAuthor(context.Background(), "b1", "not-an-id")
+	assert.EqualError(t, err, "user not found")
+	_, err = uc.AddCoAuthor(context.Background(), "b1", owner.Hex())
+	assert.EqualError(t, err, "the owner is already an author of this blog")
+	_, err = uc.AddCoAuthor(context.Background(), "b1", sara.Hex())
+	assert.EqualError(t, err, "user is already a co-author")
+}
+
+func TestRemoveCoAuthor(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{UserID: "owner", CoAuthors: []string{"a", "b"}}, nil)
+	blogRepo.On("UpdateBlogCoAuthors", mock.Anything, "b1", []string{"b"}).Return(nil)
+
+	_, err := uc.RemoveCoAuthor(context.Background(), "b1", "c")
+	assert.EqualError(t, err, "user is not a co-author of this blog")
+
+	blog, err := uc.RemoveCoAuthor(context.Background(), "b1", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, blog.CoAuthors)
+}
+
+func TestGetBlogByID_Visibility(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	seriesRepo := repoMocks.NewSeriesRepositoryInterface(t)
+	passwords := auth.NewBcryptPasswordService()
+	accessTokens := auth.NewBlogAccessTokenService()
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, SeriesRepo: seriesRepo, PasswordService: passwords, AccessTokens: accessTokens})
+	seriesRepo.On("GetSeriesByBlogID", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+	hash, err := passwords.HashPassword("open sesame")
+	assert.NoError(t, err)
+	unlisted := &entities.Blog{ID: primitive.NewObjectID(), UserID: "owner", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityUnlisted}
+	private := &entities.Blog{ID: primitive.NewObjectID(), UserID: "owner", CoAuthors: []string{"co"}, Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPrivate}
+	protected := &entities.Blog{ID: primitive.NewObjectID(), UserID: "owner", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPassword, PasswordHash: hash}
+	blogRepo.On("GetBlogByID", mock.Anything, "unlisted").Return(unlisted, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "private").Return(private, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "protected").Return(protected, nil)
+
+	// unlisted blogs are readable by link
+	_, err = uc.GetBlogByID(context.Background(), "unlisted", nil)
+	assert.NoError(t, err)
+
+	// private blogs only exist for their authors
+	_, err = uc.GetBlogByID(context.Background(), "private", &entities.BlogAccess{UserID: "stranger"})
+	assert.EqualError(t, err, "blog not found")
+	_, err = uc.GetBlogByID(context.Background(), "private", &entities.BlogAccess{UserID: "co"})
+	assert.NoError(t, err)
+
+	// password-protected blogs need a token for that very blog
+	_, err = uc.GetBlogByID(context.Background(), "protected", nil)
+	assert.EqualError(t, err, "this blog is password protected")
+	_, err = uc.UnlockBlog(context.Background(), "protected", "wrong")
+	assert.EqualError(t, err, "invalid password")
+	_, err = uc.UnlockBlog(context.Background(), "unlisted", "open sesame")
+	assert.EqualError(t, err, "blog is not password protected")
+
+	token, err := uc.UnlockBlog(context.Background(), "protected", "open sesame")
+	assert.NoError(t, err)
+	assert.True(t, token.ExpiresAt.After(time.Now()))
+	_, err = uc.GetBlogByID(context.Background(), "protected", &entities.BlogAccess{AccessToken: token.AccessToken})
+	assert.NoError(t, err)
+
+	otherToken, _, err := accessTokens.CreateBlogAccessToken(unlisted.ID.Hex())
+	assert.NoError(t, err)
+	_, err = uc.GetBlogByID(context.Background(), "protected", &entities.BlogAccess{AccessToken: otherToken})
+	assert.EqualError(t, err, "this blog is password protected")
+}
+
+func TestSetBlogVisibility(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{Status: entities.BlogStatusPublished}, nil)
+
+	_, err := uc.SetBlogVisibility(context.Background(), "b1", &entities.BlogVisibilityRequest{Visibility: "secret"})
+	assert.Error(t, err)
+	_, err = uc.SetBlogVisibility(context.Background(), "b1", &entities.BlogVisibilityRequest{Visibility: entities.BlogVisibilityPassword, Password: "abc"})
+	assert.EqualError(t, err, "password must be at least 4 characters")
+
+	blogRepo.On("UpdateBlogVisibility", mock.Anything, "b1", entities.BlogVisibilityPassword, mock.MatchedBy(func(hash string) bool {
+		return hash != "" && hash != "open sesame"
+	})).Return(nil).Once()
+	blog, err := uc.SetBlogVisibility(context.Background(), "b1", &entities.BlogVisibilityRequest{Visibility: entities.BlogVisibilityPassword, Password: "open sesame"})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.BlogVisibilityPassword, blog.Visibility)
+
+	// other visibilities drop the passphrase
+	blogRepo.On("UpdateBlogVisibility", mock.Anything, "b1", entities.BlogVisibilityUnlisted, "").Return(nil).Once()
+	blog, err = uc.SetBlogVisibility(context.Background(), "b1", &entities.BlogVisibilityRequest{Visibility: entities.BlogVisibilityUnlisted, Password: "ignored"})
+	assert.NoError(t, err)
+	assert.Empty(t, blog.PasswordHash)
+
+	// password protection cannot be chosen when creating a blog
+	err = uc.CreateBlog(context.Background(), &entities.Blog{Title: "t", Visibility: entities.BlogVisibilityPassword}, "u1")
+	assert.Error(t, err)
+}
+
+func TestSearchBlogs_FullTextHighlights(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	result := &entities.BlogWithAuthor{Blog: entities.Blog{Title: "Error handling in Go", Content: "Go code **handles** errors explicitly."}, Score: 12.5}
+	blogRepo.On("SearchBlogs", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
+		return len(s.Parsed.Clauses) == 2 && s.Parsed.Clauses[0].Text == "handle" && s.Parsed.Clauses[1].Text == "go"
+	})).Return([]*entities.BlogWithAuthor{result}, int64(1), nil)
+	blogRepo.On("GetSearchFacets", mock.Anything, mock.Anything).Return(&entities.SearchFacets{}, nil)
+
+	resp, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: "handle* go$"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Count)
+	assert.Equal(t, "Error handling in <mark>Go</mark>", resp.Blogs[0].Highlights.Title)
+	assert.Equal(t, "<mark>Go</mark> code <mark>handles</mark> errors explicitly.", resp.Blogs[0].Highlights.Snippet)
+}
+
+func TestSearchBlogs_QueryLanguageErrors(t *testing.T) {
+	t.Parallel()
+	uc := newTestBlogUseCase(t, BlogDependencies{})
+
+	_, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: "after:2025-13-01"})
+	assert.EqualError(t, err, `invalid date "2025-13-01" for after: (use YYYY-MM-DD)`)
+
+	_, err = uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: "tag:go", PopularitySort: "comments"})
+	assert.EqualError(t, err, "invalid popularity_sort value. Valid values: views, likes, dislikes, engagement")
+}
+
+func TestSearchAndFilterBlogs_Facets(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, TagRepo: tagRepo})
+	tagRepo.On("FindTags", mock.Anything, mock.Anything).Return([]*entities.Tag{}, nil)
+
+	facets := &entities.SearchFacets{
+		Tags:    []entities.FacetCount{{Value: "go", Count: 7}, {Value: "api", Count: 3}},
+		Authors: []entities.FacetCount{{Value: "alice", ID: "u1", Count: 5}},
+		Months:  []entities.FacetCount{{Value: "2025-08", Count: 4}},
+	}
+
+	// facets are computed over the same parsed search as the results
+	blogRepo.On("SearchBlogs", mock.Anything, mock.Anything).Return([]*entities.BlogWithAuthor{}, int64(10), nil).Once()
+	blogRepo.On("GetSearchFacets", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
+		return s.Parsed != nil && len(s.Parsed.Clauses) == 1 && s.Parsed.Clauses[0].Field == entities.SearchFieldTag
+	})).Return(facets, nil).Once()
+	resp, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: "tag:go"})
+	assert.NoError(t, err)
+	assert.Equal(t, facets, resp.Facets)
+
+	blogRepo.On("FilterBlogs", mock.Anything, mock.Anything).Return([]*entities.ScoredBlog{}, int64(10), nil).Once()
+	blogRepo.On("GetFilterFacets", mock.Anything, mock.MatchedBy(func(f *entities.BlogFilter) bool {
+		return len(f.Tags) == 1 && f.Tags[0] == "go"
+	})).Return(facets, nil).Once()
+	filterResp, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{Tags: []string{"go"}})
+	assert.NoError(t, err)
+	assert.Equal(t, facets, filterResp.Facets)
+
+	// a failing facet aggregation fails the request
+	blogRepo.On("FilterBlogs", mock.Anything, mock.Anything).Return([]*entities.ScoredBlog{}, int64(0), nil).Once()
+	blogRepo.On("GetFilterFacets", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+	_, err = uc.FilterBlogs(context.Background(), &entities.BlogFilter{})
+	assert.EqualError(t, err, "db down")
+}
+
+func TestSearchBlogs_CanonicalizesTagClauses(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo, TagRepo: tagRepo})
+
+	tagRepo.On("FindTags", mock.Anything, []string{"golang"}).Return([]*entities.Tag{{Slug: "go", Synonyms: []string{"golang"}}}, nil)
+	blogRepo.On("SearchBlogs", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
+		return len(s.Parsed.Clauses) == 1 && s.Parsed.Clauses[0].Text == "go"
+	})).Return([]*entities.BlogWithAuthor{}, int64(0), nil)
+	blogRepo.On("GetSearchFacets", mock.Anything, mock.Anything).Return(&entities.SearchFacets{}, nil)
+
+	_, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: "tag:Golang"})
+	assert.NoError(t, err)
+}
+
+func TestSuggestSearch(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+
+	titles := []entities.Suggestion{{Value: "Go generics", ID: "b1", Slug: "go-generics", Popularity: 42}}
+	tags := []entities.Suggestion{{Value: "golang", Popularity: 12}, {Value: "go", Popularity: 7}}
+	authors := []entities.Suggestion{{Value: "gopher", ID: "u1", Popularity: 3}}
+
+	// the prefix is trimmed and the limit defaults to 5
+	blogRepo.On("SuggestTitles", mock.Anything, "go", 5).Return(titles, nil).Once()
+	blogRepo.On("SuggestTags", mock.Anything, "go", 5).Return(tags, nil).Once()
+	blogRepo.On("SuggestAuthors", mock.Anything, "go", 5).Return(authors, nil).Once()
+	resp, err := uc.SuggestSearch(context.Background(), "  go ", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.SearchSuggestions{Query: "go", Titles: titles, Tags: tags, Authors: authors}, resp)
+
+	// the limit is capped and any failing lookup fails the request
+	blogRepo.On("SuggestTitles", mock.Anything, "go", 10).Return(titles, nil).Once()
+	blogRepo.On("SuggestTags", mock.Anything, "go", 10).Return(nil, errors.New("db down")).Once()
+	blogRepo.On("SuggestAuthors", mock.Anything, "go", 10).Return(authors, nil).Once()
+	_, err = uc.SuggestSearch(context.Background(), "go", 50)
+	assert.EqualError(t, err, "db down")
+
+	_, err = uc.SuggestSearch(context.Background(), "   ", 5)
+	assert.EqualError(t, err, "q is required")
+
+	_, err = uc.SuggestSearch(context.Background(), strings.Repeat("g", 51), 5)
+	assert.EqualError(t, err, "q must be at most 50 characters")
+}
+
+func TestFilterBlogs_CursorPagination(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+	blogRepo.On("GetFilterFacets", mock.Anything, mock.Anything).Return(&entities.SearchFacets{}, nil)
+
+	a := &entities.ScoredBlog{Blog: entities.Blog{ID: primitive.NewObjectID(), Title: "A", LikeCount: 9}}
+	b := &entities.ScoredBlog{Blog: entities.Blog{ID: primitive.NewObjectID(), Title: "B", LikeCount: 5}}
+	c := &entities.ScoredBlog{Blog: entities.Blog{ID: primitive.NewObjectID(), Title: "C", LikeCount: 1}}
+
+	// first page: the extra blog means there is a next page, but no previous one
+	blogRepo.On("FilterBlogs", mock.Anything, mock.MatchedBy(func(f *entities.BlogFilter) bool {
+		return f.Position == nil && f.Limit == 3 && f.Sort == entities.ListSort{Field: entities.SortByLikes, Desc: true}
+	})).Return([]*entities.ScoredBlog{a, b, c}, int64(3), nil).Once()
+	first, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "likes", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.ScoredBlog{a, b}, first.Blogs)
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Empty(t, first.PrevCursor)
+
+	// next page: starts after the last blog of the first page
+	blogRepo.On("FilterBlogs", mock.Anything, mock.MatchedBy(func(f *entities.BlogFilter) bool {
+		return f.Position != nil && f.Position.ID == b.ID && f.Position.Number == 5 && !f.Position.Before
+	})).Return([]*entities.ScoredBlog{c}, int64(3), nil).Once()
+	second, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "likes", Limit: 2, Cursor: first.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.ScoredBlog{c}, second.Blogs)
+	assert.Empty(t, second.NextCursor)
+	assert.NotEmpty(t, second.PrevCursor)
+	assert.Equal(t, 0, second.Page)
+
+	// previous page: ends before the first blog of the second page
+	blogRepo.On("FilterBlogs", mock.Anything, mock.MatchedBy(func(f *entities.BlogFilter) bool {
+		return f.Position != nil && f.Position.ID == c.ID && f.Position.Before
+	})).Return([]*entities.ScoredBlog{a, b}, int64(3), nil).Once()
+	back, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "likes", Limit: 2, Cursor: second.PrevCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.ScoredBlog{a, b}, back.Blogs)
+	assert.NotEmpty(t, back.NextCursor)
+	assert.Empty(t, back.PrevCursor)
+
+	// cursors are only valid for the sort they were issued for, and cannot be altered
+	_, err = uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "views", Limit: 2, Cursor: first.NextCursor})
+	assert.EqualError(t, err, "cursor does not match the sort order")
+	_, err = uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "likes", Limit: 2, Cursor: "x" + first.NextCursor})
+	assert.EqualError(t, err, "invalid cursor")
+}
+
+func TestSearchBlogs_CursorByRelevance(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+	blogRepo.On("GetSearchFacets", mock.Anything, mock.Anything).Return(&entities.SearchFacets{}, nil)
+
+	first := &entities.BlogWithAuthor{Blog: entities.Blog{ID: primitive.NewObjectID(), Title: "Go"}, Score: 2.5}
+	second := &entities.BlogWithAuthor{Blog: entities.Blog{ID: primitive.NewObjectID(), Title: "Go again"}, Score: 1.25}
+	blogRepo.On("SearchBlogs", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
+		return s.Position == nil && s.Sort == entities.ListSort{Field: entities.SortByScore, Desc: true}
+	})).Return([]*entities.BlogWithAuthor{first, second}, int64(2), nil).Once()
+	resp, err := uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: "go", Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Count)
+
+	// the relevance score of the last result is the sort value of the next page
+	blogRepo.On("SearchBlogs", mock.Anything, mock.MatchedBy(func(s *entities.BlogSearch) bool {
+		return s.Position != nil && s.Position.ID == first.ID && s.Position.Number == 2.5
+	})).Return([]*entities.BlogWithAuthor{second}, int64(2), nil).Once()
+	resp, err = uc.SearchBlogs(context.Background(), &entities.BlogSearch{Query: "go", Limit: 1, Cursor: resp.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, second.ID, resp.Blogs[0].ID)
+	assert.Empty(t, resp.NextCursor)
+}
+
+func TestFilterBlogs_EngagementSort(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := newTestBlogUseCase(t, BlogDependencies{Repo: blogRepo})
+	blogRepo.On("GetFilterFacets", mock.Anything, mock.Anything).Return(&entities.SearchFacets{}, nil)
+
+	high, low := 42.5, 7.0
+	a := &entities.ScoredBlog{Blog: entities.Blog{ID: primitive.NewObjectID(), Title: "A"}, EngagementScore: &high}
+	b := &entities.ScoredBlog{Blog: entities.Blog{ID: primitive.NewObjectID(), Title: "B"}, EngagementScore: &low}
+
+	// the repository computes the score with the configured weights
+	blogRepo.On("FilterBlogs", mock.Anything, mock.MatchedBy(func(f *entities.BlogFilter) bool {
+		return f.Sort == entities.ListSort{Field: entities.SortByEngagement, Desc: true, Weights: DefaultEngagementWeights()}
+	})).Return([]*entities.ScoredBlog{a, b}, int64(3), nil).Once()
+	resp, err := uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "engagement", Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.ScoredBlog{a}, resp.Blogs)
+
+	// the next page continues after the score of the last blog
+	blogRepo.On("FilterBlogs", mock.Anything, mock.MatchedBy(func(f *entities.BlogFilter) bool {
+		return f.Position != nil && f.Position.ID == a.ID && f.Position.Number == 42.5
+	})).Return([]*entities.ScoredBlog{b}, int64(3), nil).Once()
+	_, err = uc.FilterBlogs(context.Background(), &entities.BlogFilter{PopularitySort: "engagement", Limit: 1, Cursor: resp.NextCursor})
 	assert.NoError(t, err)
 }
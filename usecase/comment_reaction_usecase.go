@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// commentReactionUseCase implements the CommentReactionUseCaseInterface
+type commentReactionUseCase struct {
+	repo         interfaces.CommentReactionRepositoryInterface
+	commentRepo  interfaces.CommentRepositoryInterface
+	blogRepo     interfaces.BlogRepositoryInterface
+	accessTokens interfaces.BlogAccessTokenService
+}
+
+func NewCommentReactionUseCase(repo interfaces.CommentReactionRepositoryInterface, commentRepo interfaces.CommentRepositoryInterface, blogRepo interfaces.BlogRepositoryInterface, accessTokens interfaces.BlogAccessTokenService) interfaces.CommentReactionUseCaseInterface {
+	return &commentReactionUseCase{repo: repo, commentRepo: commentRepo, blogRepo: blogRepo, accessTokens: accessTokens}
+}
+
+// LikeComment likes a comment, takes the like back when the user already liked it,
+// or switches a dislike to a like
+func (u *commentReactionUseCase) LikeComment(ctx context.Context, commentID string, actor *entities.CommentActor) (string, error) {
+	return u.react(ctx, commentID, actor, "like")
+}
+
+// DislikeComment dislikes a comment, takes the dislike back when the user already disliked it,
+// or switches a like to a dislike
+func (u *commentReactionUseCase) DislikeComment(ctx context.Context, commentID string, actor *entities.CommentActor) (string, error) {
+	return u.react(ctx, commentID, actor, "dislike")
+}
+
+// react toggles the reaction of a user to a comment, moves the counters of the comment along
+// and returns the user's reaction afterwards ("" when they have none).
+// Counters only move for the reactions this request actually removed or stored, so concurrent
+// clicks of the same user cannot count a reaction twice.
+func (u *commentReactionUseCase) react(ctx context.Context, commentID string, actor *entities.CommentActor, reaction string) (string, error) {
+	comment, err := u.commentRepo.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return "", errors.New("comment not found")
+	}
+	if err := u.checkBlogAccess(ctx, comment, actor); err != nil {
+		return "", err
+	}
+	if comment.Deleted {
+		return "", errors.New("cannot react to a deleted comment")
+	}
+	if comment.Hidden {
+		return "", errors.New("cannot react to a hidden comment")
+	}
+	userID := actor.UserID
+	current, err := u.repo.GetReaction(ctx, commentID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	var change entities.CounterChange
+	raced := false
+	if current != "" {
+		removed, err := u.repo.RemoveReaction(ctx, commentID, userID, current)
+		if err != nil {
+			return "", err
+		}
+		if removed {
+			change = reactionChange(current, -1)
+		} else {
+			raced = true
+		}
+	}
+	if current != reaction {
+		added, err := u.repo.AddReaction(ctx, &entities.CommentReaction{
+			ID:        primitive.NewObjectID(),
+			CommentID: comment.ID,
+			BlogID:    comment.BlogID,
+			UserID:    userID,
+			Type:      reaction,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return "", err
+		}
+		if added {
+			addedChange := reactionChange(reaction, 1)
+			change.Likes += addedChange.Likes
+			change.Dislikes += addedChange.Dislikes
+		} else {
+			raced = true
+		}
+	}
+	if change != (entities.CounterChange{}) {
+		if err := u.commentRepo.UpdateReactionCounts(ctx, commentID, change); err != nil {
+			return "", err
+		}
+	}
+	if raced {
+		// a concurrent click of the same user got there first; report what it left behind
+		return u.repo.GetReaction(ctx, commentID, userID)
+	}
+	if current == reaction {
+		return "", nil
+	}
+	return reaction, nil
+}
+
+// checkBlogAccess lets users react only to the comments of blogs they may read
+func (u *commentReactionUseCase) checkBlogAccess(ctx context.Context, comment *entities.Comment, actor *entities.CommentActor) error {
+	blog, err := u.blogRepo.GetBlogByID(ctx, comment.BlogID.Hex())
+	if err != nil || !isPublished(blog) {
+		return errors.New("comment not found")
+	}
+	access := &entities.BlogAccess{UserID: actor.UserID, AccessToken: actor.AccessToken}
+	if err := checkBlogVisibility(blog, access, u.accessTokens); err != nil {
+		if err.Error() == "blog not found" {
+			return errors.New("comment not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// reactionChange is the counter change of adding (delta 1) or removing (delta -1) a reaction
+func reactionChange(reaction string, delta int) entities.CounterChange {
+	if reaction == "like" {
+		return entities.CounterChange{Likes: delta}
+	}
+	return entities.CounterChange{Dislikes: delta}
+}
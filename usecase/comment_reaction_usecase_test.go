@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	repoMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newReactionTestComment returns a comment on a published blog the mocks serve
+func newReactionTestComment(commentRepo *repoMocks.CommentRepositoryInterface, blogRepo *repoMocks.BlogRepositoryInterface, blog *entities.Blog) *entities.Comment {
+	comment := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blog.ID}
+	commentRepo.On("GetCommentByID", mock.Anything, comment.ID.Hex()).Return(comment, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, blog.ID.Hex()).Return(blog, nil)
+	return comment
+}
+
+func TestLikeComment_Toggles(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentReactionRepositoryInterface(t)
+	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentReactionUseCase(repo, commentRepo, blogRepo, repoMocks.NewBlogAccessTokenService(t))
+
+	comment := newReactionTestComment(commentRepo, blogRepo, &entities.Blog{ID: primitive.NewObjectID(), Status: entities.BlogStatusPublished})
+	id := comment.ID.Hex()
+	actor := &entities.CommentActor{UserID: "u1"}
+
+	// first like
+	repo.On("GetReaction", mock.Anything, id, "u1").Return("", nil).Once()
+	repo.On("AddReaction", mock.Anything, mock.MatchedBy(func(r *entities.CommentReaction) bool {
+		return r.CommentID == comment.ID && r.BlogID == comment.BlogID && r.UserID == "u1" && r.Type == "like"
+	})).Return(true, nil).Once()
+	commentRepo.On("UpdateReactionCounts", mock.Anything, id, entities.CounterChange{Likes: 1}).Return(nil).Once()
+	reaction, err := uc.LikeComment(context.Background(), id, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "like", reaction)
+
+	// liking again takes the like back
+	repo.On("GetReaction", mock.Anything, id, "u1").Return("like", nil).Once()
+	repo.On("RemoveReaction", mock.Anything, id, "u1", "like").Return(true, nil).Once()
+	commentRepo.On("UpdateReactionCounts", mock.Anything, id, entities.CounterChange{Likes: -1}).Return(nil).Once()
+	reaction, err = uc.LikeComment(context.Background(), id, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "", reaction)
+
+	// a concurrent click already took the like back: the counters stay as they are
+	repo.On("GetReaction", mock.Anything, id, "u1").Return("like", nil).Once()
+	repo.On("RemoveReaction", mock.Anything, id, "u1", "like").Return(false, nil).Once()
+	repo.On("GetReaction", mock.Anything, id, "u1").Return("", nil).Once()
+	reaction, err = uc.LikeComment(context.Background(), id, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "", reaction)
+
+	// a concurrent click already stored the like: it is not counted twice
+	repo.On("GetReaction", mock.Anything, id, "u1").Return("", nil).Once()
+	repo.On("AddReaction", mock.Anything, mock.Anything).Return(false, nil).Once()
+	repo.On("GetReaction", mock.Anything, id, "u1").Return("like", nil).Once()
+	reaction, err = uc.LikeComment(context.Background(), id, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "like", reaction)
+}
+
+func TestDislikeComment_SwitchesLike(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentReactionRepositoryInterface(t)
+	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentReactionUseCase(repo, commentRepo, blogRepo, repoMocks.NewBlogAccessTokenService(t))
+
+	comment := newReactionTestComment(commentRepo, blogRepo, &entities.Blog{ID: primitive.NewObjectID(), Status: entities.BlogStatusPublished})
+	id := comment.ID.Hex()
+	repo.On("GetReaction", mock.Anything, id, "u1").Return("like", nil)
+	repo.On("RemoveReaction", mock.Anything, id, "u1", "like").Return(true, nil)
+	repo.On("AddReaction", mock.Anything, mock.MatchedBy(func(r *entities.CommentReaction) bool { return r.Type == "dislike" })).Return(true, nil)
+	commentRepo.On("UpdateReactionCounts", mock.Anything, id, entities.CounterChange{Likes: -1, Dislikes: 1}).Return(nil)
+
+	reaction, err := uc.DislikeComment(context.Background(), id, &entities.CommentActor{UserID: "u1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "dislike", reaction)
+}
+
+func TestLikeComment_Rejected(t *testing.T) {
+	t.Parallel()
+	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	accessTokens := repoMocks.NewBlogAccessTokenService(t)
+	uc := NewCommentReactionUseCase(repoMocks.NewCommentReactionRepositoryInterface(t), commentRepo, blogRepo, accessTokens)
+	actor := &entities.CommentActor{UserID: "u1"}
+
+	published := &entities.Blog{ID: primitive.NewObjectID(), Status: entities.BlogStatusPublished}
+	blogRepo.On("GetBlogByID", mock.Anything, published.ID.Hex()).Return(published, nil)
+	commentRepo.On("GetCommentByID", mock.Anything, "missing").Return(nil, assert.AnError)
+	commentRepo.On("GetCommentByID", mock.Anything, "gone").Return(&entities.Comment{BlogID: published.ID, Deleted: true}, nil)
+	commentRepo.On("GetCommentByID", mock.Anything, "hidden").Return(&entities.Comment{BlogID: published.ID, Hidden: true}, nil)
+
+	_, err := uc.LikeComment(context.Background(), "missing", actor)
+	assert.EqualError(t, err, "comment not found")
+	_, err = uc.DislikeComment(context.Background(), "gone", actor)
+	assert.EqualError(t, err, "cannot react to a deleted comment")
+	_, err = uc.LikeComment(context.Background(), "hidden", actor)
+	assert.EqualError(t, err, "cannot react to a hidden comment")
+}
+
+func TestLikeComment_ChecksBlogAccess(t *testing.T) {
+	t.Parallel()
+	commentRepo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	accessTokens := repoMocks.NewBlogAccessTokenService(t)
+	uc := NewCommentReactionUseCase(repoMocks.NewCommentReactionRepositoryInterface(t), commentRepo, blogRepo, accessTokens)
+	actor := &entities.CommentActor{UserID: "u1", AccessToken: "bad-token"}
+
+	draft := newReactionTestComment(commentRepo, blogRepo, &entities.Blog{ID: primitive.NewObjectID(), UserID: "author", Status: entities.BlogStatusDraft})
+	private := newReactionTestComment(commentRepo, blogRepo, &entities.Blog{ID: primitive.NewObjectID(), UserID: "author", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPrivate})
+	protectedBlog := &entities.Blog{ID: primitive.NewObjectID(), UserID: "author", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPassword}
+	protected := newReactionTestComment(commentRepo, blogRepo, protectedBlog)
+	accessTokens.On("VerifyBlogAccessToken", "bad-token", protectedBlog.ID.Hex()).Return(assert.AnError)
+
+	_, err := uc.LikeComment(context.Background(), draft.ID.Hex(), actor)
+	assert.EqualError(t, err, "comment not found")
+	_, err = uc.LikeComment(context.Background(), private.ID.Hex(), actor)
+	assert.EqualError(t, err, "comment not found")
+	_, err = uc.DislikeComment(context.Background(), protected.ID.Hex(), actor)
+	assert.EqualError(t, err, "this blog is password protected")
+}
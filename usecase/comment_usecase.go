@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
@@ -13,53 +14,577 @@ import (
 
 // commentUseCase implements the CommentUseCaseInterface
 type commentUseCase struct {
-	repo interfaces.CommentRepositoryInterface
+	repo           interfaces.CommentRepositoryInterface
+	blogRepo       interfaces.BlogRepositoryInterface
+	cursors        interfaces.CursorSigner
+	accessTokens   interfaces.BlogAccessTokenService
+	moderationRepo interfaces.CommentModerationRepositoryInterface
+	settings       CommentSettings
 }
 
-func NewCommentUseCase(repo interfaces.CommentRepositoryInterface) interfaces.CommentUseCaseInterface {
-	return &commentUseCase{repo: repo}
+// CommentSettings tune the comment use case
+type CommentSettings struct {
+	Engagement        entities.EngagementWeights // comments move the engagement and popularity scores of their blog
+	MaxPinnedComments int                        // how many comments a blog can pin at once
 }
 
-func (u *commentUseCase) CreateComment(ctx context.Context, comment *entities.Comment, userID string, blogID string) error {
-	// Generate a new ObjectID for the comment
-	comment.ID = primitive.NewObjectID()
+// DefaultCommentSettings returns the settings the comment use case runs with unless the environment overrides them
+func DefaultCommentSettings() CommentSettings {
+	return CommentSettings{Engagement: DefaultEngagementWeights(), MaxPinnedComments: 3}
+}
+
+func NewCommentUseCase(repo interfaces.CommentRepositoryInterface, blogRepo interfaces.BlogRepositoryInterface, cursors interfaces.CursorSigner, accessTokens interfaces.BlogAccessTokenService, moderationRepo interfaces.CommentModerationRepositoryInterface, settings CommentSettings) interfaces.CommentUseCaseInterface {
+	return &commentUseCase{repo: repo, blogRepo: blogRepo, cursors: cursors, accessTokens: accessTokens, moderationRepo: moderationRepo, settings: settings}
+}
+
+const (
+	defaultCommentLimit       = 20  // comments per page when no limit is given
+	maxCommentLimit           = 100 // upper bound on comments per page
+	maxCommentDepth           = 5   // depth of the deepest reply; top-level comments have depth 0
+	defaultModerationLogLimit = 50  // moderation actions returned when no limit is given
+	maxModerationLogLimit     = 200 // upper bound on the moderation actions returned
+	deletedContent            = "[deleted]"
+	hiddenContent             = "[hidden]"
+)
+
+// commentSorts are the orders comments and replies can be listed in; oldest is the default
+var commentSorts = map[string]entities.ListSort{
+	"oldest":     {Field: entities.SortByCreatedAt},
+	"newest":     {Field: entities.SortByCreatedAt, Desc: true},
+	"most_liked": {Field: entities.SortByLikes, Desc: true},
+	"top":        {Field: entities.SortByReactions, Desc: true},
+}
 
+func (u *commentUseCase) CreateComment(ctx context.Context, comment *entities.Comment, author *entities.CommentActor, blogID string) error {
 	// Convert blogID string to ObjectID
 	blogObjID, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
 		return err
 	}
+	if err := u.checkUnlocked(ctx, blogID, author); err != nil {
+		return err
+	}
+
+	// Only the content comes from the client; a new comment starts its own thread
+	now := time.Now()
+	*comment = entities.Comment{
+		ID:        primitive.NewObjectID(),
+		BlogID:    blogObjID,
+		UserID:    author.UserID,
+		Content:   comment.Content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := u.repo.CreateComment(ctx, comment); err != nil {
+		return err
+	}
+	return u.updateCommentCount(ctx, blogID, 1)
+}
 
-	// Set the user ID and blog ID
-	comment.UserID = userID
-	comment.BlogID = blogObjID
+// CreateReply adds a reply under an existing comment of the same blog
+func (u *commentUseCase) CreateReply(ctx context.Context, reply *entities.Comment, author *entities.CommentActor, parentID string) error {
+	parent, err := u.repo.GetCommentByID(ctx, parentID)
+	if err != nil {
+		return errors.New("comment not found")
+	}
+	if parent.Deleted {
+		return errors.New("cannot reply to a deleted comment")
+	}
+	if parent.Hidden {
+		return errors.New("cannot reply to a hidden comment")
+	}
+	if parent.Depth >= maxCommentDepth {
+		return errors.New("this thread cannot be nested any deeper")
+	}
+	if err := u.checkUnlocked(ctx, parent.BlogID.Hex(), author); err != nil {
+		return err
+	}
 
-	// Set timestamps
 	now := time.Now()
-	comment.CreatedAt = now
-	comment.UpdatedAt = now
+	*reply = entities.Comment{
+		ID:        primitive.NewObjectID(),
+		BlogID:    parent.BlogID,
+		ParentID:  &parent.ID,
+		Depth:     parent.Depth + 1,
+		UserID:    author.UserID,
+		Content:   reply.Content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
 
-	return u.repo.CreateComment(ctx, comment)
+	if err := u.repo.CreateComment(ctx, reply); err != nil {
+		return err
+	}
+	if err := u.repo.UpdateReplyCount(ctx, parentID, 1); err != nil {
+		return err
+	}
+	return u.updateCommentCount(ctx, parent.BlogID.Hex(), 1)
+}
+
+// GetCommentsByBlogID returns one page of the top-level comments of a blog in the given sort order.
+// The first page also carries the pinned comments.
+func (u *commentUseCase) GetCommentsByBlogID(ctx context.Context, blogID string, viewer *entities.CommentActor, sort string, limit int, cursor string) (*entities.CommentPage, error) {
+	blog, err := u.readableBlog(ctx, blogID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	page, err := u.commentPage(sort, limit, cursor, func(query *entities.PageQuery) ([]*entities.Comment, int64, error) {
+		return u.repo.GetCommentsByBlogID(ctx, blogID, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cursor == "" {
+		if page.Pinned, err = u.repo.GetPinnedComments(ctx, blogID); err != nil {
+			return nil, err
+		}
+	}
+	maskComments(page.Comments, blog, viewer)
+	maskComments(page.Pinned, blog, viewer)
+	return page, nil
 }
 
-// GetCommentsByBlogID returns all comments for a blog
-func (u *commentUseCase) GetCommentsByBlogID(ctx context.Context, blogID string) ([]*entities.Comment, error) {
-	return u.repo.GetCommentsByBlogID(ctx, blogID)
+// GetReplies returns one page of the direct replies to a comment in the given sort order
+func (u *commentUseCase) GetReplies(ctx context.Context, parentID string, viewer *entities.CommentActor, sort string, limit int, cursor string) (*entities.CommentPage, error) {
+	parent, err := u.repo.GetCommentByID(ctx, parentID)
+	if err != nil {
+		return nil, errors.New("comment not found")
+	}
+	blog, err := u.readableBlog(ctx, parent.BlogID.Hex(), viewer)
+	if err != nil {
+		return nil, err
+	}
+	page, err := u.commentPage(sort, limit, cursor, func(query *entities.PageQuery) ([]*entities.Comment, int64, error) {
+		return u.repo.GetReplies(ctx, parentID, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	maskComments(page.Comments, blog, viewer)
+	return page, nil
+}
+
+// commentPage pages through the comments returned by fetch
+func (u *commentUseCase) commentPage(sortName string, limit int, cursor string, fetch func(*entities.PageQuery) ([]*entities.Comment, int64, error)) (*entities.CommentPage, error) {
+	if sortName == "" {
+		sortName = "oldest"
+	}
+	sort, ok := commentSorts[sortName]
+	if !ok {
+		return nil, errors.New("invalid sort value. Valid values: oldest, newest, most_liked, top")
+	}
+	if limit <= 0 {
+		limit = defaultCommentLimit
+	}
+	if limit > maxCommentLimit {
+		limit = maxCommentLimit
+	}
+	position, err := decodeCursor(u.cursors, cursor, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra comment tells whether another page follows
+	comments, total, err := fetch(&entities.PageQuery{Limit: limit + 1, Sort: sort, Position: position})
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]*entities.PageCursor, len(comments))
+	for i, comment := range comments {
+		positions[i] = &entities.PageCursor{Sort: sort.Key(), Time: comment.CreatedAt, Number: commentSortValue(comment, sort), ID: comment.ID}
+	}
+	window, err := paginate(u.cursors, positions, limit, position, false)
+	if err != nil {
+		return nil, err
+	}
+	comments = comments[window.start:window.end]
+	return &entities.CommentPage{Comments: comments, Count: len(comments), TotalCount: total, NextCursor: window.next, PrevCursor: window.prev}, nil
+}
+
+// commentSortValue is the number a comment is sorted by, for cursors of the numeric sorts
+func commentSortValue(comment *entities.Comment, sort entities.ListSort) float64 {
+	if sort.Field == entities.SortByReactions {
+		return float64(comment.ReactionScore)
+	}
+	return float64(comment.LikeCount)
 }
 
 // GetCommentByID returns a single comment by ID
-func (u *commentUseCase) GetCommentByID(ctx context.Context, id string) (*entities.Comment, error) {
-	return u.repo.GetCommentByID(ctx, id)
+func (u *commentUseCase) GetCommentByID(ctx context.Context, id string, viewer *entities.CommentActor) (*entities.Comment, error) {
+	comment, err := u.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	blog, err := u.readableBlog(ctx, comment.BlogID.Hex(), viewer)
+	if err != nil {
+		return nil, err
+	}
+	maskComments([]*entities.Comment{comment}, blog, viewer)
+	return comment, nil
+}
+
+// maskComments blanks the content and author of the comments the viewer may not read as written:
+// tombstones render as "[deleted]" and hidden comments as "[hidden]". All the comments belong to blog.
+func maskComments(comments []*entities.Comment, blog *entities.Blog, viewer *entities.CommentActor) {
+	for _, comment := range comments {
+		if !comment.Deleted && !comment.Hidden {
+			continue
+		}
+		if mayReadOriginal(comment, blog, viewer) {
+			continue
+		}
+		if comment.Deleted {
+			comment.Content = deletedContent
+			comment.DeletedBy = ""
+		} else {
+			comment.Content = hiddenContent
+		}
+		comment.UserID = ""
+	}
+}
+
+// mayReadOriginal reports whether the viewer may read a deleted or hidden comment as written.
+// The moderators of the blog read both; the author of a hidden comment still reads it, but
+// deleted comments are gone for their authors too.
+func mayReadOriginal(comment *entities.Comment, blog *entities.Blog, viewer *entities.CommentActor) bool {
+	if !comment.Deleted && viewer != nil && viewer.UserID != "" && viewer.UserID == comment.UserID {
+		return true
+	}
+	return moderatorRole(blog, viewer) != ""
 }
 
-// UpdateComment updates an existing comment
+// GetCommentRevisions lists the edit history of a comment, newest first. The history of a
+// deleted or hidden comment is only shown to those who may read the comment as written.
+func (u *commentUseCase) GetCommentRevisions(ctx context.Context, id string, viewer *entities.CommentActor) ([]*entities.CommentRevision, error) {
+	comment, err := u.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("comment not found")
+	}
+	blog, err := u.readableBlog(ctx, comment.BlogID.Hex(), viewer)
+	if err != nil {
+		return nil, err
+	}
+	if (comment.Deleted || comment.Hidden) && !mayReadOriginal(comment, blog, viewer) {
+		return nil, errors.New("you cannot view the history of this comment")
+	}
+	revisions, err := u.repo.GetCommentRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Comments posted before edit history existed have no revisions until their first edit
+	if len(revisions) == 0 {
+		revisions = []*entities.CommentRevision{{
+			CommentID: comment.ID,
+			BlogID:    comment.BlogID,
+			Version:   1,
+			Content:   comment.Content,
+			EditorID:  comment.UserID,
+			CreatedAt: comment.CreatedAt,
+		}}
+	}
+	return revisions, nil
+}
+
+// UpdateComment updates the content of an existing comment and marks it as edited; the repository
+// keeps the previous content in the edit history. Its place in the thread stays as stored.
 func (u *commentUseCase) UpdateComment(ctx context.Context, comment *entities.Comment) error {
-	// Update timestamp
-	comment.UpdatedAt = time.Now()
-	return u.repo.UpdateComment(ctx, comment)
+	stored, err := u.repo.GetCommentByID(ctx, comment.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if stored.Deleted {
+		return errors.New("cannot edit a deleted comment")
+	}
+	// Saving the same content again is not an edit
+	if stored.Content != comment.Content {
+		now := time.Now()
+		stored.Content = comment.Content
+		stored.Edited = true
+		stored.EditedAt = &now
+		stored.UpdatedAt = now
+		if err := u.repo.UpdateComment(ctx, stored); err != nil {
+			return err
+		}
+	}
+	*comment = *stored
+	return nil
+}
+
+// DeleteComment deletes a comment by ID on behalf of its author or a moderator of the blog;
+// the moderation log records deletions by moderators. The comment becomes a tombstone that keeps
+// its place, replies and edit history: others see "[deleted]" while moderators still see the original.
+func (u *commentUseCase) DeleteComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error {
+	comment, err := u.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return errors.New("comment not found")
+	}
+	if comment.Deleted {
+		return nil
+	}
+	role := ""
+	if actor.UserID != comment.UserID {
+		if role, err = u.moderatorOf(ctx, comment.BlogID.Hex(), actor); err != nil {
+			return err
+		}
+		if role == "" {
+			return errors.New("you can only delete your own comments")
+		}
+	}
+
+	unpinned, err := u.repo.MarkCommentDeleted(ctx, id, actor.UserID, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := u.releasePin(ctx, comment.BlogID.Hex(), unpinned); err != nil {
+		return err
+	}
+	// Hidden comments were already taken off the comment count of the blog
+	if !comment.Hidden {
+		if err := u.updateCommentCount(ctx, comment.BlogID.Hex(), -1); err != nil {
+			return err
+		}
+	}
+	if role != "" {
+		return u.recordAction(ctx, comment.BlogID, &comment.ID, entities.ModerationDelete, actor, role, reason)
+	}
+	return nil
+}
+
+// updateCommentCount moves the comment count of a blog and its popularity score along
+func (u *commentUseCase) updateCommentCount(ctx context.Context, blogID string, change int) error {
+	counters := entities.CounterChange{Comments: change}
+	return u.blogRepo.UpdateBlogCounters(ctx, blogID, counters, u.settings.Engagement.Score(counters))
+}
+
+// HideComment hides a comment from everyone but its author and the moderators of the blog.
+// Hidden comments lose their pin and no longer count towards the comment count of the blog.
+func (u *commentUseCase) HideComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error {
+	comment, role, err := u.moderatedComment(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+	if comment.Deleted {
+		return errors.New("cannot hide a deleted comment")
+	}
+	if comment.Hidden {
+		return nil
+	}
+	unpinned, err := u.repo.SetHidden(ctx, id, true)
+	if err != nil {
+		return err
+	}
+	if err := u.releasePin(ctx, comment.BlogID.Hex(), unpinned); err != nil {
+		return err
+	}
+	if err := u.updateCommentCount(ctx, comment.BlogID.Hex(), -1); err != nil {
+		return err
+	}
+	return u.recordAction(ctx, comment.BlogID, &comment.ID, entities.ModerationHide, actor, role, reason)
+}
+
+// UnhideComment shows a hidden comment again
+func (u *commentUseCase) UnhideComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error {
+	comment, role, err := u.moderatedComment(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+	if !comment.Hidden {
+		return nil
+	}
+	if _, err := u.repo.SetHidden(ctx, id, false); err != nil {
+		return err
+	}
+	if !comment.Deleted {
+		if err := u.updateCommentCount(ctx, comment.BlogID.Hex(), 1); err != nil {
+			return err
+		}
+	}
+	return u.recordAction(ctx, comment.BlogID, &comment.ID, entities.ModerationUnhide, actor, role, reason)
+}
+
+// PinComment pins a top-level comment to the top of the blog's comments, up to the pinned comment limit
+func (u *commentUseCase) PinComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error {
+	comment, role, err := u.moderatedComment(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+	switch {
+	case comment.PinnedAt != nil:
+		return nil
+	case comment.ParentID != nil:
+		return errors.New("only top-level comments can be pinned")
+	case comment.Deleted:
+		return errors.New("cannot pin a deleted comment")
+	case comment.Hidden:
+		return errors.New("cannot pin a hidden comment")
+	}
+	// The slot is taken before the pin, so concurrent pins cannot go past the limit
+	reserved, err := u.blogRepo.ReservePinnedSlot(ctx, comment.BlogID.Hex(), u.settings.MaxPinnedComments)
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		return errors.New("pinned comment limit reached")
+	}
+	now := time.Now()
+	pinned, err := u.repo.SetPinned(ctx, id, &now)
+	if err != nil || !pinned {
+		// The comment was pinned, deleted or hidden meanwhile
+		if releaseErr := u.blogRepo.ReleasePinnedSlot(ctx, comment.BlogID.Hex()); err == nil {
+			err = releaseErr
+		}
+		return err
+	}
+	return u.recordAction(ctx, comment.BlogID, &comment.ID, entities.ModerationPin, actor, role, reason)
+}
+
+// UnpinComment unpins a pinned comment
+func (u *commentUseCase) UnpinComment(ctx context.Context, id string, actor *entities.CommentActor, reason string) error {
+	comment, role, err := u.moderatedComment(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+	if comment.PinnedAt == nil {
+		return nil
+	}
+	unpinned, err := u.repo.SetPinned(ctx, id, nil)
+	if err != nil || !unpinned {
+		return err
+	}
+	if err := u.releasePin(ctx, comment.BlogID.Hex(), true); err != nil {
+		return err
+	}
+	return u.recordAction(ctx, comment.BlogID, &comment.ID, entities.ModerationUnpin, actor, role, reason)
+}
+
+// releasePin gives the pinned comment slot of a comment back to its blog when the comment lost its pin
+func (u *commentUseCase) releasePin(ctx context.Context, blogID string, unpinned bool) error {
+	if !unpinned {
+		return nil
+	}
+	return u.blogRepo.ReleasePinnedSlot(ctx, blogID)
+}
+
+// SetCommentsLocked locks the comments of a blog, so no new comments or replies are accepted, or unlocks them
+func (u *commentUseCase) SetCommentsLocked(ctx context.Context, blogID string, actor *entities.CommentActor, locked bool, reason string) error {
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		return errors.New("blog not found")
+	}
+	role := moderatorRole(blog, actor)
+	if role == "" {
+		return errors.New("only the blog author or an admin can moderate its comments")
+	}
+	if blog.CommentsLocked == locked {
+		return nil
+	}
+	if err := u.blogRepo.SetCommentsLocked(ctx, blogID, locked); err != nil {
+		return err
+	}
+	action := entities.ModerationUnlock
+	if locked {
+		action = entities.ModerationLock
+	}
+	return u.recordAction(ctx, blog.ID, nil, action, actor, role, reason)
+}
+
+// GetModerationLog returns the latest moderation actions on the comments of a blog, newest first
+func (u *commentUseCase) GetModerationLog(ctx context.Context, blogID string, actor *entities.CommentActor, limit int) ([]*entities.ModerationAction, error) {
+	role, err := u.moderatorOf(ctx, blogID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, errors.New("only the blog author or an admin can moderate its comments")
+	}
+	if limit <= 0 {
+		limit = defaultModerationLogLimit
+	}
+	if limit > maxModerationLogLimit {
+		limit = maxModerationLogLimit
+	}
+	return u.moderationRepo.GetActionsByBlogID(ctx, blogID, limit)
+}
+
+// checkUnlocked refuses new comments and replies on blogs the author may not read and blogs whose comments are locked
+func (u *commentUseCase) checkUnlocked(ctx context.Context, blogID string, author *entities.CommentActor) error {
+	blog, err := u.readableBlog(ctx, blogID, author)
+	if err != nil {
+		return err
+	}
+	if blog.CommentsLocked {
+		return errors.New("comments are locked on this blog")
+	}
+	return nil
+}
+
+// readableBlog returns the blog of a comment thread if the viewer may read the blog itself:
+// unpublished and private blogs look missing and password-protected blogs need an access token
+func (u *commentUseCase) readableBlog(ctx context.Context, blogID string, viewer *entities.CommentActor) (*entities.Blog, error) {
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil || !isPublished(blog) {
+		return nil, errors.New("blog not found")
+	}
+	access := &entities.BlogAccess{}
+	if viewer != nil {
+		access = &entities.BlogAccess{UserID: viewer.UserID, AccessToken: viewer.AccessToken}
+	}
+	if err := checkBlogVisibility(blog, access, u.accessTokens); err != nil {
+		return nil, err
+	}
+	return blog, nil
+}
+
+// moderatedComment returns a comment the actor moderates, with the role they moderate it in
+func (u *commentUseCase) moderatedComment(ctx context.Context, id string, actor *entities.CommentActor) (*entities.Comment, string, error) {
+	comment, err := u.repo.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, "", errors.New("comment not found")
+	}
+	role, err := u.moderatorOf(ctx, comment.BlogID.Hex(), actor)
+	if err != nil {
+		return nil, "", err
+	}
+	if role == "" {
+		return nil, "", errors.New("only the blog author or an admin can moderate its comments")
+	}
+	return comment, role, nil
+}
+
+// moderatorOf returns the role the actor moderates the comments of a blog in, or "" when they may not
+func (u *commentUseCase) moderatorOf(ctx context.Context, blogID string, actor *entities.CommentActor) (string, error) {
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil {
+		return "", errors.New("blog not found")
+	}
+	return moderatorRole(blog, actor), nil
+}
+
+// moderatorRole is "owner" for the author of the blog, "admin" for admins and "" for everyone else;
+// co-authors edit a blog but do not moderate its comments
+func moderatorRole(blog *entities.Blog, actor *entities.CommentActor) string {
+	switch {
+	case actor == nil || actor.UserID == "":
+		return ""
+	case actor.UserID == blog.UserID:
+		return "owner"
+	case actor.Role == string(entities.RoleAdmin):
+		return "admin"
+	}
+	return ""
 }
 
-// DeleteComment removes a comment by ID
-func (u *commentUseCase) DeleteComment(ctx context.Context, id string) error {
-	return u.repo.DeleteComment(ctx, id)
+// recordAction appends a moderation action to the moderation log of a blog
+func (u *commentUseCase) recordAction(ctx context.Context, blogID primitive.ObjectID, commentID *primitive.ObjectID, action string, actor *entities.CommentActor, role string, reason string) error {
+	return u.moderationRepo.RecordAction(ctx, &entities.ModerationAction{
+		ID:            primitive.NewObjectID(),
+		BlogID:        blogID,
+		CommentID:     commentID,
+		Action:        action,
+		ModeratorID:   actor.UserID,
+		ModeratorRole: role,
+		Reason:        reason,
+		CreatedAt:     time.Now(),
+	})
 }
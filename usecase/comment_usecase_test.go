@@ -3,29 +3,522 @@ package usecase
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/infrastructure/auth"
 	repoMocks "github.com/Abenuterefe/a2sv-project/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestCreateComment_InvalidBlogID(t *testing.T) {
 	t.Parallel()
 	repo := repoMocks.NewCommentRepositoryInterface(t)
-	uc := NewCommentUseCase(repo)
+	uc := NewCommentUseCase(repo, repoMocks.NewBlogRepositoryInterface(t), auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
 
-	err := uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, "u1", "badid")
+	err := uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, &entities.CommentActor{UserID: "u1"}, "badid")
 	assert.Error(t, err)
 }
 
 func TestCreateComment_Success(t *testing.T) {
 	t.Parallel()
 	repo := repoMocks.NewCommentRepositoryInterface(t)
-	uc := NewCommentUseCase(repo)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
 
+	blogRepo.On("GetBlogByID", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{}, nil)
 	repo.On("CreateComment", mock.Anything, mock.Anything).Return(nil)
+	// the comment count and popularity score of the blog move along
+	blogRepo.On("UpdateBlogCounters", mock.Anything, "507f1f77bcf86cd799439011", entities.CounterChange{Comments: 1}, DefaultEngagementWeights().Comments).Return(nil)
 
-	err := uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, "u1", "507f1f77bcf86cd799439011")
+	err := uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, &entities.CommentActor{UserID: "u1"}, "507f1f77bcf86cd799439011")
 	assert.NoError(t, err)
 }
+
+func TestGetCommentsByBlogID_Pages(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	now := time.Now()
+	first := &entities.Comment{ID: primitive.NewObjectID(), CreatedAt: now}
+	second := &entities.Comment{ID: primitive.NewObjectID(), CreatedAt: now.Add(time.Minute)}
+
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{}, nil)
+	repo.On("GetPinnedComments", mock.Anything, "b1").Return([]*entities.Comment{}, nil)
+
+	// default limit, oldest first, one extra comment to detect the next page
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", &entities.PageQuery{Limit: 21, Sort: entities.ListSort{Field: entities.SortByCreatedAt}}).
+		Return([]*entities.Comment{first, second}, int64(2), nil).Once()
+	page, err := uc.GetCommentsByBlogID(context.Background(), "b1", nil, "", 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.Count)
+	assert.Equal(t, int64(2), page.TotalCount)
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
+
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", mock.MatchedBy(func(q *entities.PageQuery) bool { return q.Limit == 2 })).
+		Return([]*entities.Comment{first, second}, int64(2), nil).Once()
+	page, err = uc.GetCommentsByBlogID(context.Background(), "b1", nil, "", 1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Comment{first}, page.Comments)
+
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", mock.MatchedBy(func(q *entities.PageQuery) bool {
+		return q.Position != nil && q.Position.ID == first.ID && q.Position.Time.Equal(now)
+	})).Return([]*entities.Comment{second}, int64(2), nil).Once()
+	page, err = uc.GetCommentsByBlogID(context.Background(), "b1", nil, "", 1, page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Comment{second}, page.Comments)
+	assert.Empty(t, page.NextCursor)
+	assert.NotEmpty(t, page.PrevCursor)
+
+	_, err = uc.GetCommentsByBlogID(context.Background(), "b1", nil, "", 1, "garbage")
+	assert.EqualError(t, err, "invalid cursor")
+}
+
+func TestGetCommentsByBlogID_Sorts(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{}, nil)
+	repo.On("GetPinnedComments", mock.Anything, "b1").Return([]*entities.Comment{}, nil)
+	liked := &entities.Comment{ID: primitive.NewObjectID(), LikeCount: 9}
+	quiet := &entities.Comment{ID: primitive.NewObjectID(), LikeCount: 2}
+
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", mock.MatchedBy(func(q *entities.PageQuery) bool {
+		return q.Sort == entities.ListSort{Field: entities.SortByCreatedAt, Desc: true}
+	})).Return([]*entities.Comment{}, int64(0), nil).Once()
+	_, err := uc.GetCommentsByBlogID(context.Background(), "b1", nil, "newest", 0, "")
+	assert.NoError(t, err)
+
+	likes := entities.ListSort{Field: entities.SortByLikes, Desc: true}
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", &entities.PageQuery{Limit: 2, Sort: likes}).
+		Return([]*entities.Comment{liked, quiet}, int64(5), nil).Once()
+	page, err := uc.GetCommentsByBlogID(context.Background(), "b1", nil, "most_liked", 1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Comment{liked}, page.Comments)
+	assert.Equal(t, int64(5), page.TotalCount)
+
+	// the next page continues after the like count of the last comment
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", mock.MatchedBy(func(q *entities.PageQuery) bool {
+		return q.Position != nil && q.Position.Number == 9 && q.Position.ID == liked.ID
+	})).Return([]*entities.Comment{quiet}, int64(5), nil).Once()
+	_, err = uc.GetCommentsByBlogID(context.Background(), "b1", nil, "most_liked", 1, page.NextCursor)
+	assert.NoError(t, err)
+
+	// top comments page by reaction score
+	top := entities.ListSort{Field: entities.SortByReactions, Desc: true}
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", &entities.PageQuery{Limit: 2, Sort: top}).
+		Return([]*entities.Comment{{ID: liked.ID, ReactionScore: 6}, quiet}, int64(5), nil).Once()
+	repo.On("GetCommentsByBlogID", mock.Anything, "b1", mock.MatchedBy(func(q *entities.PageQuery) bool {
+		return q.Sort == top && q.Position != nil && q.Position.Number == 6
+	})).Return([]*entities.Comment{quiet}, int64(5), nil).Once()
+	topPage, err := uc.GetCommentsByBlogID(context.Background(), "b1", nil, "top", 1, "")
+	assert.NoError(t, err)
+	_, err = uc.GetCommentsByBlogID(context.Background(), "b1", nil, "top", 1, topPage.NextCursor)
+	assert.NoError(t, err)
+
+	_, err = uc.GetCommentsByBlogID(context.Background(), "b1", nil, "oldest", 1, page.NextCursor)
+	assert.EqualError(t, err, "cursor does not match the sort order")
+	_, err = uc.GetCommentsByBlogID(context.Background(), "b1", nil, "loudest", 0, "")
+	assert.EqualError(t, err, "invalid sort value. Valid values: oldest, newest, most_liked, top")
+}
+
+func TestDeleteComment_UpdatesBlogCounters(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	repo.On("GetCommentByID", mock.Anything, "c1").Return(&entities.Comment{BlogID: blogID, UserID: "u1"}, nil)
+	repo.On("MarkCommentDeleted", mock.Anything, "c1", "u1", mock.Anything).Return(false, nil)
+	blogRepo.On("UpdateBlogCounters", mock.Anything, blogID.Hex(), entities.CounterChange{Comments: -1}, -DefaultEngagementWeights().Comments).Return(nil)
+
+	assert.NoError(t, uc.DeleteComment(context.Background(), "c1", &entities.CommentActor{UserID: "u1"}, ""))
+}
+
+func TestCreateReply_NestsUnderParent(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	parent := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, Depth: 1}
+	repo.On("GetCommentByID", mock.Anything, parent.ID.Hex()).Return(parent, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID}, nil)
+	repo.On("CreateComment", mock.Anything, mock.MatchedBy(func(c *entities.Comment) bool {
+		return c.BlogID == blogID && *c.ParentID == parent.ID && c.Depth == 2 && c.UserID == "u1"
+	})).Return(nil)
+	repo.On("UpdateReplyCount", mock.Anything, parent.ID.Hex(), 1).Return(nil)
+	blogRepo.On("UpdateBlogCounters", mock.Anything, blogID.Hex(), entities.CounterChange{Comments: 1}, DefaultEngagementWeights().Comments).Return(nil)
+
+	// thread fields sent by the client are ignored
+	reply := &entities.Comment{Content: "agreed", ReplyCount: 7, Deleted: true}
+	assert.NoError(t, uc.CreateReply(context.Background(), reply, &entities.CommentActor{UserID: "u1"}, parent.ID.Hex()))
+	assert.Equal(t, 0, reply.ReplyCount)
+	assert.False(t, reply.Deleted)
+}
+
+func TestCreateReply_Rejected(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, repoMocks.NewBlogRepositoryInterface(t), auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	repo.On("GetCommentByID", mock.Anything, "missing").Return(nil, assert.AnError)
+	repo.On("GetCommentByID", mock.Anything, "gone").Return(&entities.Comment{Deleted: true}, nil)
+	repo.On("GetCommentByID", mock.Anything, "deep").Return(&entities.Comment{Depth: maxCommentDepth}, nil)
+
+	assert.EqualError(t, uc.CreateReply(context.Background(), &entities.Comment{}, &entities.CommentActor{UserID: "u1"}, "missing"), "comment not found")
+	assert.EqualError(t, uc.CreateReply(context.Background(), &entities.Comment{}, &entities.CommentActor{UserID: "u1"}, "gone"), "cannot reply to a deleted comment")
+	assert.EqualError(t, uc.CreateReply(context.Background(), &entities.Comment{}, &entities.CommentActor{UserID: "u1"}, "deep"), "this thread cannot be nested any deeper")
+}
+
+func TestGetReplies_Pages(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	repo.On("GetCommentByID", mock.Anything, "c1").Return(&entities.Comment{BlogID: blogID}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID}, nil)
+	now := time.Now()
+	first := &entities.Comment{ID: primitive.NewObjectID(), CreatedAt: now}
+	second := &entities.Comment{ID: primitive.NewObjectID(), CreatedAt: now.Add(time.Minute)}
+	repo.On("GetReplies", mock.Anything, "c1", &entities.PageQuery{Limit: 2, Sort: entities.ListSort{Field: entities.SortByCreatedAt}}).
+		Return([]*entities.Comment{first, second}, int64(2), nil)
+
+	page, err := uc.GetReplies(context.Background(), "c1", nil, "", 1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Comment{first}, page.Comments)
+	assert.NotEmpty(t, page.NextCursor)
+}
+
+func TestUpdateComment_KeepsThread(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, repoMocks.NewBlogRepositoryInterface(t), auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	parentID := primitive.NewObjectID()
+	stored := &entities.Comment{ID: primitive.NewObjectID(), ParentID: &parentID, Depth: 1, ReplyCount: 2, Content: "old"}
+	repo.On("GetCommentByID", mock.Anything, stored.ID.Hex()).Return(stored, nil)
+	repo.On("UpdateComment", mock.Anything, mock.MatchedBy(func(c *entities.Comment) bool {
+		return c.Content == "new" && *c.ParentID == parentID && c.Depth == 1 && c.ReplyCount == 2 && c.Edited && c.EditedAt != nil
+	})).Return(nil).Once()
+
+	update := &entities.Comment{ID: stored.ID, Content: "new", ReplyCount: 0}
+	assert.NoError(t, uc.UpdateComment(context.Background(), update))
+	assert.Equal(t, 2, update.ReplyCount)
+	assert.True(t, update.Edited)
+
+	// saving the same content again stores no new revision
+	same := &entities.Comment{ID: stored.ID, Content: "new"}
+	assert.NoError(t, uc.UpdateComment(context.Background(), same))
+	assert.True(t, same.Edited)
+}
+
+func TestDeleteComment_LeavesTombstone(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	// the reply stays in place under its parent, whose reply count does not move
+	blogID := primitive.NewObjectID()
+	parentID := primitive.NewObjectID()
+	reply := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, ParentID: &parentID, Depth: 1, UserID: "u1", Content: "rude"}
+	repo.On("GetCommentByID", mock.Anything, reply.ID.Hex()).Return(reply, nil)
+	repo.On("MarkCommentDeleted", mock.Anything, reply.ID.Hex(), "u1", mock.Anything).Return(false, nil).Once()
+	blogRepo.On("UpdateBlogCounters", mock.Anything, blogID.Hex(), entities.CounterChange{Comments: -1}, -DefaultEngagementWeights().Comments).Return(nil).Once()
+
+	assert.NoError(t, uc.DeleteComment(context.Background(), reply.ID.Hex(), &entities.CommentActor{UserID: "u1"}, ""))
+
+	// deleting a tombstone again changes nothing
+	reply.Deleted = true
+	assert.NoError(t, uc.DeleteComment(context.Background(), reply.ID.Hex(), &entities.CommentActor{UserID: "u1"}, ""))
+}
+
+func TestCreateComment_LockedBlog(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repoMocks.NewCommentRepositoryInterface(t), blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogRepo.On("GetBlogByID", mock.Anything, "507f1f77bcf86cd799439011").Return(&entities.Blog{CommentsLocked: true}, nil)
+	err := uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, &entities.CommentActor{UserID: "u1"}, "507f1f77bcf86cd799439011")
+	assert.EqualError(t, err, "comments are locked on this blog")
+}
+
+func TestComments_FollowBlogVisibility(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	accessTokens := auth.NewBlogAccessTokenService()
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), accessTokens, repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	private := &entities.Blog{ID: primitive.NewObjectID(), UserID: "owner", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPrivate}
+	protected := &entities.Blog{ID: primitive.NewObjectID(), UserID: "owner", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPassword}
+	draft := &entities.Blog{ID: primitive.NewObjectID(), UserID: "owner", Status: entities.BlogStatusDraft}
+	blogRepo.On("GetBlogByID", mock.Anything, private.ID.Hex()).Return(private, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, protected.ID.Hex()).Return(protected, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, draft.ID.Hex()).Return(draft, nil)
+	reply := &entities.Comment{ID: primitive.NewObjectID(), BlogID: private.ID}
+	repo.On("GetCommentByID", mock.Anything, reply.ID.Hex()).Return(reply, nil)
+
+	// private and unpublished blogs have no comments to read or write for strangers
+	stranger := &entities.CommentActor{UserID: "stranger"}
+	for _, blog := range []*entities.Blog{private, draft} {
+		_, err := uc.GetCommentsByBlogID(context.Background(), blog.ID.Hex(), stranger, "", 0, "")
+		assert.EqualError(t, err, "blog not found")
+		assert.EqualError(t, uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, stranger, blog.ID.Hex()), "blog not found")
+	}
+	_, err := uc.GetCommentByID(context.Background(), reply.ID.Hex(), stranger)
+	assert.EqualError(t, err, "blog not found")
+	_, err = uc.GetReplies(context.Background(), reply.ID.Hex(), nil, "", 0, "")
+	assert.EqualError(t, err, "blog not found")
+	assert.EqualError(t, uc.CreateReply(context.Background(), &entities.Comment{Content: "hi"}, stranger, reply.ID.Hex()), "blog not found")
+
+	// password-protected blogs need the access token that unlocks the blog
+	_, err = uc.GetCommentsByBlogID(context.Background(), protected.ID.Hex(), stranger, "", 0, "")
+	assert.EqualError(t, err, "this blog is password protected")
+	other, _, err := accessTokens.CreateBlogAccessToken(private.ID.Hex())
+	assert.NoError(t, err)
+	err = uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, &entities.CommentActor{UserID: "u1", AccessToken: other}, protected.ID.Hex())
+	assert.EqualError(t, err, "this blog is password protected")
+
+	token, _, err := accessTokens.CreateBlogAccessToken(protected.ID.Hex())
+	assert.NoError(t, err)
+	repo.On("CreateComment", mock.Anything, mock.Anything).Return(nil).Once()
+	blogRepo.On("UpdateBlogCounters", mock.Anything, protected.ID.Hex(), entities.CounterChange{Comments: 1}, DefaultEngagementWeights().Comments).Return(nil).Once()
+	assert.NoError(t, uc.CreateComment(context.Background(), &entities.Comment{Content: "hi"}, &entities.CommentActor{UserID: "u1", AccessToken: token}, protected.ID.Hex()))
+}
+
+func TestDeleteComment_ByModerator(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	moderationRepo := repoMocks.NewCommentModerationRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), moderationRepo, DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	comment := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "troll"}
+	repo.On("GetCommentByID", mock.Anything, "c1").Return(comment, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID, UserID: "author"}, nil)
+
+	// neither the comment's author nor a moderator
+	err := uc.DeleteComment(context.Background(), "c1", &entities.CommentActor{UserID: "someone", Role: "user"}, "")
+	assert.EqualError(t, err, "you can only delete your own comments")
+
+	// admins moderate every blog and the deletion is logged with who did it
+	repo.On("MarkCommentDeleted", mock.Anything, "c1", "admin-1", mock.Anything).Return(false, nil)
+	blogRepo.On("UpdateBlogCounters", mock.Anything, blogID.Hex(), entities.CounterChange{Comments: -1}, -DefaultEngagementWeights().Comments).Return(nil)
+	moderationRepo.On("RecordAction", mock.Anything, mock.MatchedBy(func(a *entities.ModerationAction) bool {
+		return a.BlogID == blogID && *a.CommentID == comment.ID && a.Action == entities.ModerationDelete &&
+			a.ModeratorID == "admin-1" && a.ModeratorRole == "admin" && a.Reason == "spam"
+	})).Return(nil)
+	assert.NoError(t, uc.DeleteComment(context.Background(), "c1", &entities.CommentActor{UserID: "admin-1", Role: "admin"}, "spam"))
+}
+
+func TestHideComment(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	moderationRepo := repoMocks.NewCommentModerationRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), moderationRepo, DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	repo.On("GetCommentByID", mock.Anything, "c1").Return(&entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "troll"}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID, UserID: "author"}, nil)
+
+	// co-authors and other users cannot moderate
+	err := uc.HideComment(context.Background(), "c1", &entities.CommentActor{UserID: "troll"}, "")
+	assert.EqualError(t, err, "only the blog author or an admin can moderate its comments")
+
+	// hidden comments leave the comment count of the blog
+	// hiding a pinned comment frees its pinned slot
+	repo.On("SetHidden", mock.Anything, "c1", true).Return(true, nil)
+	blogRepo.On("ReleasePinnedSlot", mock.Anything, blogID.Hex()).Return(nil).Once()
+	blogRepo.On("UpdateBlogCounters", mock.Anything, blogID.Hex(), entities.CounterChange{Comments: -1}, -DefaultEngagementWeights().Comments).Return(nil)
+	moderationRepo.On("RecordAction", mock.Anything, mock.MatchedBy(func(a *entities.ModerationAction) bool {
+		return a.Action == entities.ModerationHide && a.ModeratorID == "author" && a.ModeratorRole == "owner"
+	})).Return(nil)
+	assert.NoError(t, uc.HideComment(context.Background(), "c1", &entities.CommentActor{UserID: "author"}, "abusive"))
+}
+
+func TestPinComment(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	moderationRepo := repoMocks.NewCommentModerationRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), moderationRepo, DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	parentID := primitive.NewObjectID()
+	owner := &entities.CommentActor{UserID: "author"}
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID, UserID: "author"}, nil)
+	repo.On("GetCommentByID", mock.Anything, "reply").Return(&entities.Comment{BlogID: blogID, ParentID: &parentID, Depth: 1}, nil)
+	repo.On("GetCommentByID", mock.Anything, "c1").Return(&entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID}, nil)
+
+	assert.EqualError(t, uc.PinComment(context.Background(), "reply", owner, ""), "only top-level comments can be pinned")
+
+	// the limit is enforced by the blog's pinned slot counter
+	limit := DefaultCommentSettings().MaxPinnedComments
+	blogRepo.On("ReservePinnedSlot", mock.Anything, blogID.Hex(), limit).Return(false, nil).Once()
+	assert.EqualError(t, uc.PinComment(context.Background(), "c1", owner, ""), "pinned comment limit reached")
+
+	blogRepo.On("ReservePinnedSlot", mock.Anything, blogID.Hex(), limit).Return(true, nil).Once()
+	repo.On("SetPinned", mock.Anything, "c1", mock.AnythingOfType("*time.Time")).Return(true, nil).Once()
+	moderationRepo.On("RecordAction", mock.Anything, mock.MatchedBy(func(a *entities.ModerationAction) bool { return a.Action == entities.ModerationPin })).Return(nil).Once()
+	assert.NoError(t, uc.PinComment(context.Background(), "c1", owner, ""))
+
+	// a comment pinned, deleted or hidden by a concurrent request gives its slot back
+	blogRepo.On("ReservePinnedSlot", mock.Anything, blogID.Hex(), limit).Return(true, nil).Once()
+	repo.On("SetPinned", mock.Anything, "c1", mock.AnythingOfType("*time.Time")).Return(false, nil).Once()
+	blogRepo.On("ReleasePinnedSlot", mock.Anything, blogID.Hex()).Return(nil).Once()
+	assert.NoError(t, uc.PinComment(context.Background(), "c1", owner, ""))
+}
+
+func TestSetCommentsLocked(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	moderationRepo := repoMocks.NewCommentModerationRepositoryInterface(t)
+	uc := NewCommentUseCase(repoMocks.NewCommentRepositoryInterface(t), blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), moderationRepo, DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{ID: blogID, UserID: "author"}, nil)
+	blogRepo.On("SetCommentsLocked", mock.Anything, "b1", true).Return(nil)
+	moderationRepo.On("RecordAction", mock.Anything, mock.MatchedBy(func(a *entities.ModerationAction) bool {
+		return a.BlogID == blogID && a.CommentID == nil && a.Action == entities.ModerationLock && a.Reason == "flame war"
+	})).Return(nil)
+	assert.NoError(t, uc.SetCommentsLocked(context.Background(), "b1", &entities.CommentActor{UserID: "author"}, true, "flame war"))
+
+	// unlocking comments that are not locked changes nothing
+	assert.NoError(t, uc.SetCommentsLocked(context.Background(), "b1", &entities.CommentActor{UserID: "author"}, false, ""))
+}
+
+func TestGetCommentsByBlogID_MasksHidden(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	now := time.Now()
+	fetch := func() []*entities.Comment {
+		return []*entities.Comment{{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "troll", Content: "rude", Hidden: true}}
+	}
+	pinned := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "fan", Content: "great", PinnedAt: &now}
+	// every request gets its own copy, as it would from the database
+	for i := 0; i < 4; i++ {
+		repo.On("GetCommentsByBlogID", mock.Anything, "b1", mock.Anything).Return(fetch(), int64(1), nil).Once()
+	}
+	repo.On("GetPinnedComments", mock.Anything, "b1").Return([]*entities.Comment{pinned}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{ID: blogID, UserID: "author"}, nil)
+
+	page, err := uc.GetCommentsByBlogID(context.Background(), "b1", &entities.CommentActor{}, "", 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "[hidden]", page.Comments[0].Content)
+	assert.Empty(t, page.Comments[0].UserID)
+	assert.Equal(t, []*entities.Comment{pinned}, page.Pinned)
+
+	for _, viewer := range []*entities.CommentActor{{UserID: "author"}, {UserID: "troll"}, {UserID: "admin-1", Role: "admin"}} {
+		page, err = uc.GetCommentsByBlogID(context.Background(), "b1", viewer, "", 0, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "rude", page.Comments[0].Content)
+	}
+}
+
+func TestGetReplies_MasksTombstones(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	repo.On("GetCommentByID", mock.Anything, "c1").Return(&entities.Comment{BlogID: blogID}, nil)
+	for i := 0; i < 3; i++ {
+		repo.On("GetReplies", mock.Anything, "c1", mock.Anything).Return([]*entities.Comment{
+			{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "troll", Content: "rude", Deleted: true, DeletedBy: "troll", ReplyCount: 2},
+		}, int64(1), nil).Once()
+	}
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID, UserID: "author"}, nil)
+
+	// not even its author reads a deleted comment as written
+	for _, viewer := range []*entities.CommentActor{nil, {UserID: "troll"}} {
+		page, err := uc.GetReplies(context.Background(), "c1", viewer, "", 0, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "[deleted]", page.Comments[0].Content)
+		assert.Empty(t, page.Comments[0].UserID)
+		assert.Empty(t, page.Comments[0].DeletedBy)
+		assert.Equal(t, 2, page.Comments[0].ReplyCount)
+	}
+
+	page, err := uc.GetReplies(context.Background(), "c1", &entities.CommentActor{UserID: "author"}, "", 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "rude", page.Comments[0].Content)
+	assert.Equal(t, "troll", page.Comments[0].UserID)
+}
+
+func TestGetCommentRevisions(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewCommentRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewCommentUseCase(repo, blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), repoMocks.NewCommentModerationRepositoryInterface(t), DefaultCommentSettings())
+
+	blogID := primitive.NewObjectID()
+	live := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "u1", Content: "v2", Edited: true}
+	legacy := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "u1", Content: "as posted"}
+	deleted := &entities.Comment{ID: primitive.NewObjectID(), BlogID: blogID, UserID: "u1", Content: "rude", Deleted: true}
+	revisions := []*entities.CommentRevision{{Version: 2, Content: "v2"}, {Version: 1, Content: "v1"}}
+	repo.On("GetCommentByID", mock.Anything, live.ID.Hex()).Return(live, nil)
+	repo.On("GetCommentByID", mock.Anything, legacy.ID.Hex()).Return(legacy, nil)
+	repo.On("GetCommentByID", mock.Anything, deleted.ID.Hex()).Return(deleted, nil)
+	repo.On("GetCommentByID", mock.Anything, "missing").Return(nil, assert.AnError)
+	repo.On("GetCommentRevisions", mock.Anything, live.ID.Hex()).Return(revisions, nil)
+	repo.On("GetCommentRevisions", mock.Anything, legacy.ID.Hex()).Return(nil, nil)
+	repo.On("GetCommentRevisions", mock.Anything, deleted.ID.Hex()).Return(revisions, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID, UserID: "author"}, nil)
+
+	got, err := uc.GetCommentRevisions(context.Background(), live.ID.Hex(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, revisions, got)
+
+	// comments posted before edit history existed show their content as revision 1
+	got, err = uc.GetCommentRevisions(context.Background(), legacy.ID.Hex(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "as posted", got[0].Content)
+	assert.Equal(t, 1, got[0].Version)
+
+	// the history of a deleted comment is for moderators only
+	_, err = uc.GetCommentRevisions(context.Background(), deleted.ID.Hex(), &entities.CommentActor{UserID: "u1"})
+	assert.EqualError(t, err, "you cannot view the history of this comment")
+	got, err = uc.GetCommentRevisions(context.Background(), deleted.ID.Hex(), &entities.CommentActor{UserID: "admin-1", Role: "admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, revisions, got)
+
+	_, err = uc.GetCommentRevisions(context.Background(), "missing", nil)
+	assert.EqualError(t, err, "comment not found")
+}
+
+func TestGetModerationLog(t *testing.T) {
+	t.Parallel()
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	moderationRepo := repoMocks.NewCommentModerationRepositoryInterface(t)
+	uc := NewCommentUseCase(repoMocks.NewCommentRepositoryInterface(t), blogRepo, auth.NewCursorSigner(), auth.NewBlogAccessTokenService(), moderationRepo, DefaultCommentSettings())
+
+	blogRepo.On("GetBlogByID", mock.Anything, "b1").Return(&entities.Blog{UserID: "author"}, nil)
+	moderationRepo.On("GetActionsByBlogID", mock.Anything, "b1", 200).Return([]*entities.ModerationAction{}, nil)
+
+	_, err := uc.GetModerationLog(context.Background(), "b1", &entities.CommentActor{UserID: "reader"}, 0)
+	assert.EqualError(t, err, "only the blog author or an admin can moderate its comments")
+	actions, err := uc.GetModerationLog(context.Background(), "b1", &entities.CommentActor{UserID: "author"}, 1000)
+	assert.NoError(t, err)
+	assert.Empty(t, actions)
+}
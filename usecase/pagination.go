@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+)
+
+// decodeCursor verifies a cursor token and checks that it was issued for the same sort; no token means the first page
+func decodeCursor(signer interfaces.CursorSigner, token string, sort entities.ListSort) (*entities.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	position, err := signer.Decode(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	if position.Sort != sort.Key() {
+		return nil, errors.New("cursor does not match the sort order")
+	}
+	return position, nil
+}
+
+// listPage is the part of the fetched items that forms the page, with the cursors of the neighbouring pages
+type listPage struct {
+	start, end int
+	next, prev string
+}
+
+// paginate works out a page from items fetched one past the limit, so the extra item tells whether
+// another page follows in the direction of the fetch. positions holds the cursor of each fetched item
+// in display order; skipped reports an offset first page, which has a previous page too.
+func paginate(signer interfaces.CursorSigner, positions []*entities.PageCursor, limit int, position *entities.PageCursor, skipped bool) (listPage, error) {
+	page := listPage{end: len(positions)}
+	backwards := position != nil && position.Before
+	more := len(positions) > limit
+	if more && backwards {
+		page.start = len(positions) - limit
+	} else if more {
+		page.end = limit
+	}
+	if page.start == page.end {
+		return page, nil
+	}
+
+	hasNext, hasPrev := more, position != nil || skipped
+	if backwards {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		next := *positions[page.end-1]
+		token, err := signer.Encode(&next)
+		if err != nil {
+			return page, err
+		}
+		page.next = token
+	}
+	if hasPrev {
+		prev := *positions[page.start]
+		prev.Before = true
+		token, err := signer.Encode(&prev)
+		if err != nil {
+			return page, err
+		}
+		page.prev = token
+	}
+	return page, nil
+}
+
+// blogPosition is the cursor of a blog in a list with the given sort; relevance and engagement are
+// the scores computed by the query, if any
+func blogPosition(blog *entities.Blog, sort entities.ListSort, relevance float64, engagement *float64) *entities.PageCursor {
+	position := &entities.PageCursor{Sort: sort.Key(), ID: blog.ID}
+	switch sort.Field {
+	case entities.SortByCreatedAt:
+		position.Time = blog.CreatedAt
+	case entities.SortByViews:
+		position.Number = float64(blog.ViewCount)
+	case entities.SortByLikes:
+		position.Number = float64(blog.LikeCount)
+	case entities.SortByDislikes:
+		position.Number = float64(blog.DislikeCount)
+	case entities.SortByScore:
+		position.Number = relevance
+	case entities.SortByEngagement:
+		if engagement != nil {
+			position.Number = *engagement
+		}
+	}
+	return position
+}
+
+// blogListSort maps the popularity_sort and sort_order options to the sort of a blog list.
+// Without a popularity sort, full-text searches are ordered by relevance and everything else newest first.
+func blogListSort(popularitySort string, sortOrder string, relevance bool, weights entities.EngagementWeights) entities.ListSort {
+	switch {
+	case popularitySort == "engagement":
+		return entities.ListSort{Field: entities.SortByEngagement, Desc: sortOrder != "asc", Weights: weights}
+	case popularitySort != "":
+		fields := map[string]string{
+			"views":    entities.SortByViews,
+			"likes":    entities.SortByLikes,
+			"dislikes": entities.SortByDislikes,
+		}
+		return entities.ListSort{Field: fields[popularitySort], Desc: sortOrder != "asc"}
+	case relevance:
+		return entities.ListSort{Field: entities.SortByScore, Desc: true}
+	default:
+		return entities.ListSort{Field: entities.SortByCreatedAt, Desc: true}
+	}
+}
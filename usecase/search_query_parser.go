@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+)
+
+// maxSearchClauses keeps a single query from turning into a huge database query
+const maxSearchClauses = 20
+
+// searchQueryToken is a whitespace-separated part of a query, with its field prefix split off
+type searchQueryToken struct {
+	field  string
+	value  string
+	quoted bool
+}
+
+// parseSearchQuery turns a query like `tag:go author:abel after:2025-01-01 likes:>10 "error handling"`
+// into clauses. Free text is reduced to letters and digits, so it can never carry query operators;
+// unknown prefixes such as "http:" are treated as free text.
+func parseSearchQuery(query string) (*entities.SearchQuery, error) {
+	parsed := &entities.SearchQuery{Clauses: []entities.SearchClause{}}
+	for _, token := range tokenizeSearchQuery(query) {
+		clauses, err := parseSearchToken(token)
+		if err != nil {
+			return nil, err
+		}
+		parsed.Clauses = append(parsed.Clauses, clauses...)
+	}
+	if len(parsed.Clauses) > maxSearchClauses {
+		return nil, fmt.Errorf("search query is too long (at most %d terms)", maxSearchClauses)
+	}
+	return parsed, nil
+}
+
+func parseSearchToken(token searchQueryToken) ([]entities.SearchClause, error) {
+	field := entities.SearchField(token.field)
+	value := strings.TrimSpace(token.value)
+	switch field {
+	case entities.SearchFieldTag, entities.SearchFieldAuthor, entities.SearchFieldTitle:
+		if value == "" {
+			return nil, fmt.Errorf("%s: needs a value", field)
+		}
+		return []entities.SearchClause{{Field: field, Text: value, Phrase: token.quoted}}, nil
+
+	case entities.SearchFieldAfter, entities.SearchFieldBefore:
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q for %s: (use YYYY-MM-DD)", value, field)
+		}
+		return []entities.SearchClause{{Field: field, Date: &date}}, nil
+
+	case entities.SearchFieldLikes, entities.SearchFieldDislikes, entities.SearchFieldViews:
+		operator, number, err := parseSearchComparison(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: (use a number, optionally prefixed with >, >=, < or <=)", value, field)
+		}
+		return []entities.SearchClause{{Field: field, Operator: operator, Number: number}}, nil
+	}
+
+	// Free text (including unknown prefixes)
+	text := value
+	if token.field != "" {
+		text = token.field + " " + value
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !isWordRune(r) })
+	if token.quoted && len(words) > 1 {
+		return []entities.SearchClause{{Field: entities.SearchFieldText, Text: strings.Join(words, " "), Phrase: true}}, nil
+	}
+	clauses := make([]entities.SearchClause, 0, len(words))
+	for _, word := range words {
+		clauses = append(clauses, entities.SearchClause{Field: entities.SearchFieldText, Text: word})
+	}
+	return clauses, nil
+}
+
+// parseSearchComparison parses ">10", ">=10", "<10", "<=10" and "10"
+func parseSearchComparison(value string) (entities.SearchOperator, int, error) {
+	operator := entities.SearchOperatorEqual
+	for _, candidate := range []entities.SearchOperator{
+		entities.SearchOperatorGreaterEqual, entities.SearchOperatorLessEqual,
+		entities.SearchOperatorGreater, entities.SearchOperatorLess, entities.SearchOperatorEqual,
+	} {
+		if strings.HasPrefix(value, string(candidate)) {
+			operator = candidate
+			value = strings.TrimPrefix(value, string(candidate))
+			break
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return "", 0, errors.New("invalid number")
+	}
+	return operator, number, nil
+}
+
+// tokenizeSearchQuery splits a query at whitespace, keeping "quoted values" together.
+// A field prefix is a run of letters followed by a colon, e.g. tag:"machine learning".
+func tokenizeSearchQuery(query string) []searchQueryToken {
+	runes := []rune(query)
+	var tokens []searchQueryToken
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		token := searchQueryToken{}
+		j := i
+		for j < len(runes) && unicode.IsLetter(runes[j]) {
+			j++
+		}
+		if j > i && j < len(runes) && runes[j] == ':' {
+			token.field = strings.ToLower(string(runes[i:j]))
+			i = j + 1
+		}
+
+		// Quoted value; an unclosed quote is read as plain text
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end < len(runes) {
+				token.value, token.quoted = string(runes[i+1:end]), true
+				tokens = append(tokens, token)
+				i = end + 1
+				continue
+			}
+		}
+
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		token.value = string(runes[start:i])
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// searchHighlightTerms lists the words and phrases worth highlighting in results
+func searchHighlightTerms(query *entities.SearchQuery) []string {
+	var terms []string
+	for _, clause := range query.Clauses {
+		switch clause.Field {
+		case entities.SearchFieldText:
+			terms = append(terms, clause.Text)
+		case entities.SearchFieldTitle:
+			words := strings.FieldsFunc(strings.ToLower(clause.Text), func(r rune) bool { return !isWordRune(r) })
+			if len(words) > 0 {
+				terms = append(terms, strings.Join(words, " "))
+			}
+		}
+	}
+	return terms
+}
+
+// hasFreeText reports whether the query has words or phrases for the full-text index, so results have a relevance score
+func hasFreeText(query *entities.SearchQuery) bool {
+	for _, clause := range query.Clauses {
+		if clause.Field == entities.SearchFieldText {
+			return true
+		}
+	}
+	return false
+}
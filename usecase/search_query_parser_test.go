@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := parseSearchQuery(`tag:go author:abel after:2025-01-01 likes:>10 "error handling" Golang`)
+	assert.NoError(t, err)
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []entities.SearchClause{
+		{Field: entities.SearchFieldTag, Text: "go"},
+		{Field: entities.SearchFieldAuthor, Text: "abel"},
+		{Field: entities.SearchFieldAfter, Date: &after},
+		{Field: entities.SearchFieldLikes, Operator: entities.SearchOperatorGreater, Number: 10},
+		{Field: entities.SearchFieldText, Text: "error handling", Phrase: true},
+		{Field: entities.SearchFieldText, Text: "golang"},
+	}, parsed.Clauses)
+	assert.Equal(t, []string{"error handling", "golang"}, searchHighlightTerms(parsed))
+}
+
+func TestParseSearchQuery_ValuesAndOperators(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := parseSearchQuery(`tag:"machine learning" views:>=100 dislikes:3 TITLE:"road map"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.SearchClause{
+		{Field: entities.SearchFieldTag, Text: "machine learning", Phrase: true},
+		{Field: entities.SearchFieldViews, Operator: entities.SearchOperatorGreaterEqual, Number: 100},
+		{Field: entities.SearchFieldDislikes, Operator: entities.SearchOperatorEqual, Number: 3},
+		{Field: entities.SearchFieldTitle, Text: "road map", Phrase: true},
+	}, parsed.Clauses)
+
+	// unknown prefixes, operators and unclosed quotes are plain text
+	parsed, err = parseSearchQuery(`http://example.com $where "unclosed`)
+	assert.NoError(t, err)
+	var words []string
+	for _, clause := range parsed.Clauses {
+		assert.Equal(t, entities.SearchFieldText, clause.Field)
+		words = append(words, clause.Text)
+	}
+	assert.Equal(t, []string{"http", "example", "com", "where", "unclosed"}, words)
+
+	parsed, err = parseSearchQuery(`( ) * + ?`)
+	assert.NoError(t, err)
+	assert.Empty(t, parsed.Clauses)
+}
+
+func TestParseSearchQuery_Errors(t *testing.T) {
+	t.Parallel()
+
+	for query, message := range map[string]string{
+		`after:yesterday`: `invalid date "yesterday" for after: (use YYYY-MM-DD)`,
+		`likes:>ten`:      `invalid value ">ten" for likes: (use a number, optionally prefixed with >, >=, < or <=)`,
+		`views:-1`:        `invalid value "-1" for views: (use a number, optionally prefixed with >, >=, < or <=)`,
+		`tag:`:            `tag: needs a value`,
+	} {
+		_, err := parseSearchQuery(query)
+		assert.EqualError(t, err, message, query)
+	}
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	snippetLength = 200 // maximum length of a search snippet in characters
+	snippetLead   = 60  // characters of context shown before the first match
+)
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// highlightMatches HTML-escapes text and wraps every word starting with one of the terms in <mark>.
+// Terms match word prefixes, which roughly follows the stemming of the text index ("handle" marks "handling").
+func highlightMatches(text string, terms []string) string {
+	runes := []rune(text)
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		if end := matchAt(runes, i, terms); end > i {
+			b.WriteString("<mark>" + html.EscapeString(string(runes[i:end])) + "</mark>")
+			i = end
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+		i++
+	}
+	return b.String()
+}
+
+// searchSnippet returns a highlighted window of text around the first match,
+// or the start of the text when nothing matches
+func searchSnippet(text string, terms []string) string {
+	runes := []rune(text)
+	match := 0
+	for i := range runes {
+		if matchAt(runes, i, terms) > i {
+			match = i
+			break
+		}
+	}
+
+	// Show some context before the match, starting at a word boundary
+	start := 0
+	if match > snippetLead {
+		start = match - snippetLead
+		for start < match && isWordRune(runes[start-1]) {
+			start++
+		}
+	}
+	end := start + snippetLength
+	if end >= len(runes) {
+		end = len(runes)
+	} else {
+		cut := end
+		for cut > start && isWordRune(runes[cut]) && isWordRune(runes[cut-1]) {
+			cut--
+		}
+		if cut > start {
+			end = cut
+		}
+	}
+
+	snippet := highlightMatches(strings.TrimSpace(string(runes[start:end])), terms)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// matchAt returns the end of the word matched at position i, or i when no term starts there
+func matchAt(runes []rune, i int, terms []string) int {
+	if i > 0 && isWordRune(runes[i-1]) {
+		return i
+	}
+	for _, term := range terms {
+		termRunes := []rune(term)
+		if i+len(termRunes) > len(runes) {
+			continue
+		}
+		matched := true
+		for j, r := range termRunes {
+			// Spaces in phrases match any whitespace (line breaks included)
+			if r == ' ' && unicode.IsSpace(runes[i+j]) {
+				continue
+			}
+			if unicode.ToLower(runes[i+j]) != r {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		end := i + len(termRunes)
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		return end
+	}
+	return i
+}
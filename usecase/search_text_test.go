@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchSnippet_HighlightsAndEscapes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "<mark>Handling</mark> errors in &lt;Go&gt;", highlightMatches("Handling errors in <Go>", []string{"handl"}))
+	// only word starts match
+	assert.Equal(t, "mishandled", highlightMatches("mishandled", []string{"handl"}))
+	// phrases match across line breaks
+	assert.Equal(t, "<mark>error\nhandling</mark>", highlightMatches("error\nhandling", []string{"error handling"}))
+
+	text := strings.Repeat("filler words here ", 20) + "the needle is here " + strings.Repeat("more text after ", 20)
+	snippet := searchSnippet(text, []string{"needle"})
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Contains(t, snippet, "<mark>needle</mark>")
+
+	// no match shows the start of the text
+	assert.Equal(t, "short text", searchSnippet("short text", []string{"absent"}))
+}
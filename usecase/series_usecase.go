@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// seriesUseCase implements the SeriesUseCaseInterface
+type seriesUseCase struct {
+	repo     interfaces.SeriesRepositoryInterface
+	blogRepo interfaces.BlogRepositoryInterface
+}
+
+func NewSeriesUseCase(repo interfaces.SeriesRepositoryInterface, blogRepo interfaces.BlogRepositoryInterface) interfaces.SeriesUseCaseInterface {
+	return &seriesUseCase{repo: repo, blogRepo: blogRepo}
+}
+
+func (u *seriesUseCase) CreateSeries(ctx context.Context, series *entities.Series, userID string) error {
+	series.Title = strings.TrimSpace(series.Title)
+	if series.Title == "" {
+		return errors.New("series title is required")
+	}
+
+	series.ID = primitive.NewObjectID()
+	series.UserID = userID
+	series.BlogIDs = []primitive.ObjectID{}
+	now := time.Now()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	return u.repo.CreateSeries(ctx, series)
+}
+
+// GetSeriesByID returns a series together with its published parts
+func (u *seriesUseCase) GetSeriesByID(ctx context.Context, id string) (*entities.Series, error) {
+	series, err := u.repo.GetSeriesByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("series not found")
+	}
+	if series.Parts, err = publishedSeriesParts(ctx, u.blogRepo, series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// GetSeriesByUserID returns all series of a user
+func (u *seriesUseCase) GetSeriesByUserID(ctx context.Context, userID string) ([]*entities.Series, error) {
+	return u.repo.GetSeriesByUserID(ctx, userID)
+}
+
+// AddBlogToSeries appends a blog as the last part; a blog can only belong to one series
+func (u *seriesUseCase) AddBlogToSeries(ctx context.Context, seriesID string, blogID string, userID string) (*entities.Series, error) {
+	series, err := u.ownedSeries(ctx, seriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blog, err := u.blogRepo.GetBlogByID(ctx, blogID)
+	if err != nil || blog.DeletedAt != nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.UserID != userID {
+		return nil, errors.New("you can only add your own blogs to a series")
+	}
+	if existing, err := u.repo.GetSeriesByBlogID(ctx, blogID); err == nil && existing != nil {
+		return nil, interfaces.ErrBlogInSeries
+	}
+
+	series.BlogIDs = append(series.BlogIDs, blog.ID)
+	return u.saveBlogs(ctx, series)
+}
+
+// RemoveBlogFromSeries removes a blog from the series; the remaining parts keep their order
+func (u *seriesUseCase) RemoveBlogFromSeries(ctx context.Context, seriesID string, blogID string, userID string) (*entities.Series, error) {
+	series, err := u.ownedSeries(ctx, seriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range series.BlogIDs {
+		if id.Hex() == blogID {
+			series.BlogIDs = append(series.BlogIDs[:i], series.BlogIDs[i+1:]...)
+			return u.saveBlogs(ctx, series)
+		}
+	}
+	return nil, errors.New("blog is not part of this series")
+}
+
+// ReorderSeries replaces the order of the parts
+func (u *seriesUseCase) ReorderSeries(ctx context.Context, seriesID string, blogIDs []string, userID string) (*entities.Series, error) {
+	series, err := u.ownedSeries(ctx, seriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The new order must be a permutation of the current parts
+	current := make(map[primitive.ObjectID]bool, len(series.BlogIDs))
+	for _, id := range series.BlogIDs {
+		current[id] = true
+	}
+	if len(blogIDs) != len(current) {
+		return nil, errors.New("blog_ids must contain exactly the blogs of the series")
+	}
+	ordered := make([]primitive.ObjectID, 0, len(blogIDs))
+	for _, blogID := range blogIDs {
+		oid, err := primitive.ObjectIDFromHex(blogID)
+		if err != nil || !current[oid] {
+			return nil, errors.New("blog_ids must contain exactly the blogs of the series")
+		}
+		delete(current, oid)
+		ordered = append(ordered, oid)
+	}
+
+	series.BlogIDs = ordered
+	return u.saveBlogs(ctx, series)
+}
+
+// ownedSeries loads a series and checks that the user owns it
+func (u *seriesUseCase) ownedSeries(ctx context.Context, seriesID string, userID string) (*entities.Series, error) {
+	series, err := u.repo.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, errors.New("series not found")
+	}
+	if series.UserID != userID {
+		return nil, errors.New("you can only modify your own series")
+	}
+	return series, nil
+}
+
+func (u *seriesUseCase) saveBlogs(ctx context.Context, series *entities.Series) (*entities.Series, error) {
+	series.UpdatedAt = time.Now()
+	if err := u.repo.UpdateSeriesBlogs(ctx, series); err != nil {
+		return nil, err
+	}
+	parts, err := publishedSeriesParts(ctx, u.blogRepo, series)
+	if err != nil {
+		return nil, err
+	}
+	series.Parts = parts
+	return series, nil
+}
+
+// publishedSeriesParts lists the published blogs of a series in series order.
+// Parts the public lists leave out (drafts, scheduled, trashed, unlisted, private and password-protected
+// blogs) are skipped, so positions only count visible parts.
+func publishedSeriesParts(ctx context.Context, blogRepo interfaces.BlogRepositoryInterface, series *entities.Series) ([]entities.SeriesPart, error) {
+	blogs, err := blogRepo.GetBlogsByIDs(ctx, series.BlogIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]*entities.Blog, len(blogs))
+	for _, blog := range blogs {
+		byID[blog.ID] = blog
+	}
+
+	parts := []entities.SeriesPart{}
+	for _, id := range series.BlogIDs {
+		blog, ok := byID[id]
+		if !ok || !isPublished(blog) || !isListed(blog) {
+			continue
+		}
+		parts = append(parts, entities.SeriesPart{
+			BlogID:   blog.ID,
+			Title:    blog.Title,
+			Slug:     blog.Slug,
+			Position: len(parts) + 1,
+		})
+	}
+	return parts, nil
+}
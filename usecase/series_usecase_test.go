@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+	repoMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateSeries_RequiresTitle(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewSeriesRepositoryInterface(t)
+	uc := NewSeriesUseCase(repo, repoMocks.NewBlogRepositoryInterface(t))
+
+	err := uc.CreateSeries(context.Background(), &entities.Series{Title: "  "}, "u1")
+	assert.EqualError(t, err, "series title is required")
+
+	repo.On("CreateSeries", mock.Anything, mock.Anything).Return(nil)
+	series := &entities.Series{Title: "Go from zero"}
+	assert.NoError(t, uc.CreateSeries(context.Background(), series, "u1"))
+	assert.Equal(t, "u1", series.UserID)
+	assert.Empty(t, series.BlogIDs)
+}
+
+func TestAddBlogToSeries_Validation(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewSeriesRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewSeriesUseCase(repo, blogRepo)
+
+	seriesID := primitive.NewObjectID()
+	mine, other, taken := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	repo.On("GetSeriesByID", mock.Anything, seriesID.Hex()).Return(&entities.Series{ID: seriesID, UserID: "u1"}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, other.Hex()).Return(&entities.Blog{ID: other, UserID: "u2"}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, taken.Hex()).Return(&entities.Blog{ID: taken, UserID: "u1"}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, mine.Hex()).Return(&entities.Blog{ID: mine, UserID: "u1", Status: entities.BlogStatusPublished}, nil)
+	repo.On("GetSeriesByBlogID", mock.Anything, taken.Hex()).Return(&entities.Series{}, nil)
+	repo.On("GetSeriesByBlogID", mock.Anything, mine.Hex()).Return(nil, errors.New("not found"))
+	repo.On("UpdateSeriesBlogs", mock.Anything, mock.Anything).Return(nil)
+	blogRepo.On("GetBlogsByIDs", mock.Anything, []primitive.ObjectID{mine}).Return([]*entities.Blog{{ID: mine, Title: "Part 1", Status: entities.BlogStatusPublished}}, nil)
+
+	_, err := uc.AddBlogToSeries(context.Background(), seriesID.Hex(), mine.Hex(), "u2")
+	assert.EqualError(t, err, "you can only modify your own series")
+
+	_, err = uc.AddBlogToSeries(context.Background(), seriesID.Hex(), other.Hex(), "u1")
+	assert.EqualError(t, err, "you can only add your own blogs to a series")
+
+	_, err = uc.AddBlogToSeries(context.Background(), seriesID.Hex(), taken.Hex(), "u1")
+	assert.EqualError(t, err, "blog already belongs to a series")
+
+	series, err := uc.AddBlogToSeries(context.Background(), seriesID.Hex(), mine.Hex(), "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{mine}, series.BlogIDs)
+	assert.Equal(t, 1, series.Parts[0].Position)
+}
+
+func TestAddBlogToSeries_LosesRaceToAnotherSeries(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewSeriesRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewSeriesUseCase(repo, blogRepo)
+
+	seriesID, blogID := primitive.NewObjectID(), primitive.NewObjectID()
+	repo.On("GetSeriesByID", mock.Anything, seriesID.Hex()).Return(&entities.Series{ID: seriesID, UserID: "u1"}, nil)
+	blogRepo.On("GetBlogByID", mock.Anything, blogID.Hex()).Return(&entities.Blog{ID: blogID, UserID: "u1"}, nil)
+	repo.On("GetSeriesByBlogID", mock.Anything, blogID.Hex()).Return(nil, errors.New("not found"))
+	// another series took the blog between the check and the write
+	repo.On("UpdateSeriesBlogs", mock.Anything, mock.Anything).Return(interfaces.ErrBlogInSeries)
+
+	_, err := uc.AddBlogToSeries(context.Background(), seriesID.Hex(), blogID.Hex(), "u1")
+	assert.EqualError(t, err, "blog already belongs to a series")
+}
+
+func TestGetSeriesByID_ListsOnlyListedParts(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewSeriesRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewSeriesUseCase(repo, blogRepo)
+
+	seriesID := primitive.NewObjectID()
+	future := time.Now().Add(time.Hour)
+	trashedAt := time.Now()
+	blogs := []*entities.Blog{
+		{ID: primitive.NewObjectID(), Title: "Public", Status: entities.BlogStatusPublished},
+		{ID: primitive.NewObjectID(), Title: "Unlisted", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityUnlisted},
+		{ID: primitive.NewObjectID(), Title: "Private", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPrivate},
+		{ID: primitive.NewObjectID(), Title: "Password", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPassword},
+		{ID: primitive.NewObjectID(), Title: "Scheduled", Status: entities.BlogStatusScheduled, PublishAt: &future},
+		{ID: primitive.NewObjectID(), Title: "Trashed", Status: entities.BlogStatusPublished, DeletedAt: &trashedAt},
+		{ID: primitive.NewObjectID(), Title: "Also public", Status: entities.BlogStatusPublished, Visibility: entities.BlogVisibilityPublic},
+	}
+	ids := make([]primitive.ObjectID, len(blogs))
+	for i, blog := range blogs {
+		ids[i] = blog.ID
+	}
+	repo.On("GetSeriesByID", mock.Anything, seriesID.Hex()).Return(&entities.Series{ID: seriesID, BlogIDs: ids}, nil)
+	blogRepo.On("GetBlogsByIDs", mock.Anything, ids).Return(blogs, nil)
+
+	series, err := uc.GetSeriesByID(context.Background(), seriesID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.SeriesPart{
+		{BlogID: blogs[0].ID, Title: "Public", Position: 1},
+		{BlogID: blogs[6].ID, Title: "Also public", Position: 2},
+	}, series.Parts)
+}
+
+func TestReorderSeries_MustBePermutation(t *testing.T) {
+	t.Parallel()
+	repo := repoMocks.NewSeriesRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	uc := NewSeriesUseCase(repo, blogRepo)
+
+	seriesID := primitive.NewObjectID()
+	a, b := primitive.NewObjectID(), primitive.NewObjectID()
+	// every call loads a fresh copy, as the database would
+	for i := 0; i < 3; i++ {
+		repo.On("GetSeriesByID", mock.Anything, seriesID.Hex()).Return(&entities.Series{ID: seriesID, UserID: "u1", BlogIDs: []primitive.ObjectID{a, b}}, nil).Once()
+	}
+	repo.On("UpdateSeriesBlogs", mock.Anything, mock.MatchedBy(func(s *entities.Series) bool {
+		return len(s.BlogIDs) == 2 && s.BlogIDs[0] == b && s.BlogIDs[1] == a
+	})).Return(nil)
+	blogRepo.On("GetBlogsByIDs", mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := uc.ReorderSeries(context.Background(), seriesID.Hex(), []string{a.Hex()}, "u1")
+	assert.Error(t, err)
+	_, err = uc.ReorderSeries(context.Background(), seriesID.Hex(), []string{a.Hex(), a.Hex()}, "u1")
+	assert.Error(t, err)
+
+	series, err := uc.ReorderSeries(context.Background(), seriesID.Hex(), []string{b.Hex(), a.Hex()}, "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{b, a}, series.BlogIDs)
+}
@@ -0,0 +1,416 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	"github.com/Abenuterefe/a2sv-project/domain/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxTagLength is the maximum length of a tag slug in characters
+const maxTagLength = 50
+
+// tagUseCase implements the TagUseCaseInterface
+type tagUseCase struct {
+	repo     interfaces.TagRepositoryInterface
+	blogRepo interfaces.BlogRepositoryInterface
+	related  interfaces.RelatedBlogIndex
+}
+
+// NewTagUseCase creates the tag use case; related is the index of the blog use case, rebuilt when blog tags are rewritten
+func NewTagUseCase(repo interfaces.TagRepositoryInterface, blogRepo interfaces.BlogRepositoryInterface, related interfaces.RelatedBlogIndex) interfaces.TagUseCaseInterface {
+	return &tagUseCase{repo: repo, blogRepo: blogRepo, related: related}
+}
+
+// normalizeTag turns a tag as typed by a user into a slug: "Go Lang" and "#go_lang" become "go-lang".
+// Letters, digits, "+" and "#" are kept ("c++", "c#"); anything else separates words.
+func normalizeTag(tag string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tag)), "#") {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+	if runes := []rune(slug); len(runes) > maxTagLength {
+		slug = strings.Trim(string(runes[:maxTagLength]), "-")
+	}
+	return slug
+}
+
+// normalizeTags normalizes tags, dropping empty ones, duplicates and the excluded slugs
+func normalizeTags(tags []string, exclude ...string) []string {
+	seen := map[string]bool{}
+	for _, slug := range exclude {
+		seen[slug] = true
+	}
+	normalized := []string{}
+	for _, tag := range tags {
+		if slug := normalizeTag(tag); slug != "" && !seen[slug] {
+			seen[slug] = true
+			normalized = append(normalized, slug)
+		}
+	}
+	return normalized
+}
+
+// canonicalTags normalizes the tags of a blog and replaces synonyms by their canonical tag.
+// Tags that are not defined are kept in their normalized form.
+func canonicalTags(ctx context.Context, tagRepo interfaces.TagRepositoryInterface, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return tags, nil
+	}
+	normalized := normalizeTags(tags)
+	defined, err := tagRepo.FindTags(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	canonical := map[string]string{}
+	for _, tag := range defined {
+		for _, synonym := range tag.Synonyms {
+			canonical[synonym] = tag.Slug
+		}
+	}
+	for i, slug := range normalized {
+		if target, ok := canonical[slug]; ok {
+			normalized[i] = target
+		}
+	}
+	return normalizeTags(normalized), nil
+}
+
+// ListTags returns the defined tags and the tags used by listed blogs, most used first
+func (u *tagUseCase) ListTags(ctx context.Context) ([]*entities.Tag, error) {
+	counts, err := u.blogRepo.GetTagCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := u.repo.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bySlug := make(map[string]*entities.Tag, len(tags))
+	for _, tag := range tags {
+		bySlug[tag.Slug] = tag
+	}
+	for _, count := range counts {
+		tag, ok := bySlug[count.Value]
+		if !ok {
+			// Used but never defined
+			tag = &entities.Tag{Slug: count.Value, Name: count.Value, Synonyms: []string{}}
+			bySlug[count.Value] = tag
+			tags = append(tags, tag)
+		}
+		tag.PostCount = count.Count
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].PostCount != tags[j].PostCount {
+			return tags[i].PostCount > tags[j].PostCount
+		}
+		return tags[i].Slug < tags[j].Slug
+	})
+	return tags, nil
+}
+
+// CreateTag defines a canonical tag; blogs already using one of its synonyms are rewritten to it
+func (u *tagUseCase) CreateTag(ctx context.Context, tag *entities.Tag) (*entities.Tag, error) {
+	slug := normalizeTag(tag.Slug)
+	if slug == "" {
+		slug = normalizeTag(tag.Name)
+	}
+	if slug == "" {
+		return nil, errors.New("tag slug or name is required")
+	}
+	tag.Slug = slug
+	tag.Name = strings.TrimSpace(tag.Name)
+	if tag.Name == "" {
+		tag.Name = slug
+	}
+	tag.Description = strings.TrimSpace(tag.Description)
+	tag.Synonyms = normalizeTags(tag.Synonyms, slug)
+	if err := u.checkAvailable(ctx, primitive.NilObjectID, append([]string{slug}, tag.Synonyms...)); err != nil {
+		return nil, err
+	}
+
+	tag.ID = primitive.NewObjectID()
+	now := time.Now()
+	tag.CreatedAt, tag.UpdatedAt = now, now
+	if err := u.repo.CreateTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	if _, err := u.replaceTags(ctx, tag.Synonyms, slug); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// UpdateTag changes the name, description or synonyms of a tag; blogs using a new synonym are rewritten
+func (u *tagUseCase) UpdateTag(ctx context.Context, slug string, update *entities.TagUpdate) (*entities.Tag, error) {
+	tag, err := u.repo.GetTagBySlug(ctx, normalizeTag(slug))
+	if err != nil {
+		return nil, errors.New("tag not found")
+	}
+	if update.Name != nil {
+		if strings.TrimSpace(*update.Name) == "" {
+			return nil, errors.New("tag name cannot be empty")
+		}
+		tag.Name = strings.TrimSpace(*update.Name)
+	}
+	if update.Description != nil {
+		tag.Description = strings.TrimSpace(*update.Description)
+	}
+	if update.Synonyms != nil {
+		tag.Synonyms = normalizeTags(*update.Synonyms, tag.Slug)
+		if err := u.checkAvailable(ctx, tag.ID, tag.Synonyms); err != nil {
+			return nil, err
+		}
+	}
+
+	tag.UpdatedAt = time.Now()
+	if err := u.repo.UpdateTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	if _, err := u.replaceTags(ctx, tag.Synonyms, tag.Slug); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// RenameTag gives a tag a new slug and rewrites the blogs using it. The old slug becomes a synonym,
+// so blogs written with it later still get the new tag. Tags that were only used, never defined, are defined.
+func (u *tagUseCase) RenameTag(ctx context.Context, slug string, newSlug string) (*entities.TagChange, error) {
+	from, to := normalizeTag(slug), normalizeTag(newSlug)
+	if to == "" {
+		return nil, errors.New("new slug is required")
+	}
+	if from == to {
+		return nil, errors.New("new slug must differ from the current slug")
+	}
+
+	tag, err := u.definedTag(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkAvailable(ctx, tag.ID, []string{to}); err != nil {
+		return nil, err
+	}
+	if tag.Name == tag.Slug {
+		tag.Name = to
+	}
+	tag.Slug = to
+	tag.Synonyms = normalizeTags(append(tag.Synonyms, from), to)
+	if err := u.saveTag(ctx, tag); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.replaceTags(ctx, []string{from}, to)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.TagChange{Tag: tag, BlogsUpdated: updated}, nil
+}
+
+// MergeTags folds the source tags into the target tag: their slugs and synonyms become synonyms of the target,
+// the source tags are removed and every blog using them is rewritten to the target
+func (u *tagUseCase) MergeTags(ctx context.Context, merge *entities.TagMerge) (*entities.TagChange, error) {
+	target := normalizeTag(merge.Target)
+	sources := normalizeTags(merge.Sources, target)
+	if target == "" || len(sources) == 0 {
+		return nil, errors.New("a target and at least one other source tag are required")
+	}
+
+	tag, err := u.definedTag(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	defined, err := u.repo.FindTags(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+	merging := map[string]bool{}
+	for _, source := range sources {
+		merging[source] = true
+	}
+	from := sources
+	var merged []string
+	for _, source := range defined {
+		if source.ID == tag.ID {
+			continue
+		}
+		if !merging[source.Slug] {
+			return nil, fmt.Errorf("%q is a synonym of %q; merge %q instead", strings.Join(sharedSlugs(source.Synonyms, sources), ", "), source.Slug, source.Slug)
+		}
+		merged = append(merged, source.Slug)
+		from = append(from, source.Synonyms...)
+		if tag.Description == "" {
+			tag.Description = source.Description
+		}
+	}
+
+	tag.Synonyms = normalizeTags(append(tag.Synonyms, from...), target)
+	if err := u.saveTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	if len(merged) > 0 {
+		if err := u.repo.DeleteTags(ctx, merged); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := u.replaceTags(ctx, from, target)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.TagChange{Tag: tag, BlogsUpdated: updated}, nil
+}
+
+// NormalizeStoredTags rewrites the tags of blogs stored before tags were normalized (e.g. "Go" or "Golang")
+// to their canonical form, so tag filters and searches find them. Blogs already in canonical form are untouched.
+func (u *tagUseCase) NormalizeStoredTags(ctx context.Context) (int64, error) {
+	stored, err := u.blogRepo.GetDistinctTags(ctx)
+	if err != nil {
+		return 0, err
+	}
+	normalized := make(map[string]string, len(stored))
+	for _, tag := range stored {
+		if slug := normalizeTag(tag); slug != "" {
+			normalized[tag] = slug
+		}
+	}
+	if len(normalized) == 0 {
+		return 0, nil
+	}
+
+	slugs := make([]string, 0, len(normalized))
+	for _, slug := range normalized {
+		slugs = append(slugs, slug)
+	}
+	defined, err := u.repo.FindTags(ctx, normalizeTags(slugs))
+	if err != nil {
+		return 0, err
+	}
+	canonical := map[string]string{}
+	for _, tag := range defined {
+		for _, synonym := range tag.Synonyms {
+			canonical[synonym] = tag.Slug
+		}
+	}
+
+	// Group the stored spellings by the tag they become
+	rewrites := map[string][]string{}
+	for tag, slug := range normalized {
+		if target, ok := canonical[slug]; ok {
+			slug = target
+		}
+		if tag != slug {
+			rewrites[slug] = append(rewrites[slug], tag)
+		}
+	}
+	targets := make([]string, 0, len(rewrites))
+	for target := range rewrites {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var updated int64
+	for _, target := range targets {
+		from := rewrites[target]
+		sort.Strings(from)
+		n, err := u.replaceTags(ctx, from, target)
+		if err != nil {
+			return updated, err
+		}
+		updated += n
+	}
+	return updated, nil
+}
+
+// replaceTags rewrites the tags of the blogs and rebuilds the related blogs index when blogs changed
+func (u *tagUseCase) replaceTags(ctx context.Context, from []string, to string) (int64, error) {
+	updated, err := u.blogRepo.ReplaceTags(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if updated > 0 {
+		u.related.Invalidate()
+	}
+	return updated, nil
+}
+
+// definedTag returns the tag with the slug, or a new unsaved tag when the slug was never defined.
+// A slug that is a synonym of another tag cannot be used on its own.
+func (u *tagUseCase) definedTag(ctx context.Context, slug string) (*entities.Tag, error) {
+	found, err := u.repo.FindTags(ctx, []string{slug})
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range found {
+		if tag.Slug == slug {
+			return tag, nil
+		}
+	}
+	if len(found) > 0 {
+		return nil, fmt.Errorf("%q is a synonym of %q", slug, found[0].Slug)
+	}
+	return &entities.Tag{Slug: slug, Name: slug, Synonyms: []string{}}, nil
+}
+
+// saveTag creates a tag returned by definedTag or updates an existing one
+func (u *tagUseCase) saveTag(ctx context.Context, tag *entities.Tag) error {
+	now := time.Now()
+	tag.UpdatedAt = now
+	if tag.ID.IsZero() {
+		tag.ID = primitive.NewObjectID()
+		tag.CreatedAt = now
+		return u.repo.CreateTag(ctx, tag)
+	}
+	return u.repo.UpdateTag(ctx, tag)
+}
+
+// checkAvailable fails when one of the slugs is the slug or a synonym of a tag other than id
+func (u *tagUseCase) checkAvailable(ctx context.Context, id primitive.ObjectID, slugs []string) error {
+	found, err := u.repo.FindTags(ctx, slugs)
+	if err != nil {
+		return err
+	}
+	for _, tag := range found {
+		if tag.ID == id {
+			continue
+		}
+		taken := sharedSlugs(append([]string{tag.Slug}, tag.Synonyms...), slugs)
+		return fmt.Errorf("%q is already used by tag %q", strings.Join(taken, ", "), tag.Slug)
+	}
+	return nil
+}
+
+// sharedSlugs returns the slugs of a that are also in b
+func sharedSlugs(a []string, b []string) []string {
+	in := map[string]bool{}
+	for _, slug := range b {
+		in[slug] = true
+	}
+	shared := []string{}
+	for _, slug := range a {
+		if in[slug] {
+			shared = append(shared, slug)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Abenuterefe/a2sv-project/domain/entities"
+	repoMocks "github.com/Abenuterefe/a2sv-project/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	t.Parallel()
+	for input, expected := range map[string]string{
+		"Go":        "go",
+		" #Golang ": "golang",
+		"go_lang":   "go-lang",
+		"Node.js":   "node-js",
+		"C++":       "c++",
+		"--":        "",
+	} {
+		assert.Equal(t, expected, normalizeTag(input), input)
+	}
+}
+
+func TestCanonicalTags_ResolvesSynonyms(t *testing.T) {
+	t.Parallel()
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+
+	tagRepo.On("FindTags", mock.Anything, []string{"golang", "go-lang", "go", "api"}).
+		Return([]*entities.Tag{{Slug: "go", Synonyms: []string{"golang", "go-lang"}}}, nil)
+
+	tags, err := canonicalTags(context.Background(), tagRepo, []string{"Golang", "go-lang", "Go", "API", "api"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "api"}, tags)
+}
+
+func TestListTags_MergesCountsAndDefinitions(t *testing.T) {
+	t.Parallel()
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	index := repoMocks.NewRelatedBlogIndex(t)
+	uc := NewTagUseCase(tagRepo, blogRepo, index)
+
+	blogRepo.On("GetTagCounts", mock.Anything).Return([]entities.FacetCount{{Value: "go", Count: 7}, {Value: "docker", Count: 2}}, nil)
+	tagRepo.On("GetTags", mock.Anything).Return([]*entities.Tag{
+		{Slug: "go", Name: "Go", Description: "The Go language"},
+		{Slug: "rust", Name: "Rust"},
+	}, nil)
+
+	tags, err := uc.ListTags(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, tags, 3) {
+		assert.Equal(t, "Go", tags[0].Name)
+		assert.Equal(t, int64(7), tags[0].PostCount)
+		assert.Equal(t, "docker", tags[1].Slug) // used but never defined
+		assert.Equal(t, "rust", tags[2].Slug)
+		assert.Equal(t, int64(0), tags[2].PostCount)
+	}
+}
+
+func TestCreateTag_RewritesSynonymsAndRejectsConflicts(t *testing.T) {
+	t.Parallel()
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	index := repoMocks.NewRelatedBlogIndex(t)
+	uc := NewTagUseCase(tagRepo, blogRepo, index)
+
+	tagRepo.On("FindTags", mock.Anything, []string{"go", "golang", "go-lang"}).Return([]*entities.Tag{}, nil).Once()
+	tagRepo.On("CreateTag", mock.Anything, mock.AnythingOfType("*entities.Tag")).Return(nil)
+	blogRepo.On("ReplaceTags", mock.Anything, []string{"golang", "go-lang"}, "go").Return(int64(4), nil)
+	index.On("Invalidate").Return().Once()
+
+	tag, err := uc.CreateTag(context.Background(), &entities.Tag{Name: "Go", Synonyms: []string{"Golang", "go_lang", "go"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "go", tag.Slug)
+	assert.Equal(t, []string{"golang", "go-lang"}, tag.Synonyms)
+
+	// "golang" already belongs to "go"
+	tagRepo.On("FindTags", mock.Anything, []string{"gopher", "golang"}).Return([]*entities.Tag{tag}, nil).Once()
+	_, err = uc.CreateTag(context.Background(), &entities.Tag{Slug: "gopher", Synonyms: []string{"golang"}})
+	assert.EqualError(t, err, `"golang" is already used by tag "go"`)
+}
+
+func TestRenameTag_KeepsOldSlugAsSynonym(t *testing.T) {
+	t.Parallel()
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	index := repoMocks.NewRelatedBlogIndex(t)
+	uc := NewTagUseCase(tagRepo, blogRepo, index)
+
+	existing := &entities.Tag{ID: primitive.NewObjectID(), Slug: "js", Name: "js", Synonyms: []string{}}
+	tagRepo.On("FindTags", mock.Anything, []string{"js"}).Return([]*entities.Tag{existing}, nil)
+	tagRepo.On("FindTags", mock.Anything, []string{"javascript"}).Return([]*entities.Tag{}, nil)
+	tagRepo.On("UpdateTag", mock.Anything, existing).Return(nil)
+	blogRepo.On("ReplaceTags", mock.Anything, []string{"js"}, "javascript").Return(int64(12), nil)
+	index.On("Invalidate").Return().Once()
+
+	change, err := uc.RenameTag(context.Background(), "js", "JavaScript")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), change.BlogsUpdated)
+	assert.Equal(t, "javascript", change.Tag.Slug)
+	assert.Equal(t, "javascript", change.Tag.Name)
+	assert.Equal(t, []string{"js"}, change.Tag.Synonyms)
+
+	_, err = uc.RenameTag(context.Background(), "js", "JS")
+	assert.EqualError(t, err, "new slug must differ from the current slug")
+}
+
+func TestMergeTags_FoldsSourcesIntoTarget(t *testing.T) {
+	t.Parallel()
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	index := repoMocks.NewRelatedBlogIndex(t)
+	uc := NewTagUseCase(tagRepo, blogRepo, index)
+
+	target := &entities.Tag{ID: primitive.NewObjectID(), Slug: "go", Name: "Go", Synonyms: []string{}}
+	source := &entities.Tag{ID: primitive.NewObjectID(), Slug: "golang", Description: "Go posts", Synonyms: []string{"go-lang"}}
+	tagRepo.On("FindTags", mock.Anything, []string{"go"}).Return([]*entities.Tag{target}, nil)
+	tagRepo.On("FindTags", mock.Anything, []string{"golang", "gopher"}).Return([]*entities.Tag{source}, nil)
+	tagRepo.On("UpdateTag", mock.Anything, target).Return(nil)
+	tagRepo.On("DeleteTags", mock.Anything, []string{"golang"}).Return(nil)
+	blogRepo.On("ReplaceTags", mock.Anything, []string{"golang", "gopher", "go-lang"}, "go").Return(int64(9), nil)
+	index.On("Invalidate").Return().Once()
+
+	change, err := uc.MergeTags(context.Background(), &entities.TagMerge{Sources: []string{"Golang", "gopher", "go"}, Target: "Go"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), change.BlogsUpdated)
+	assert.Equal(t, []string{"golang", "gopher", "go-lang"}, change.Tag.Synonyms)
+	assert.Equal(t, "Go posts", change.Tag.Description)
+
+	_, err = uc.MergeTags(context.Background(), &entities.TagMerge{Sources: []string{"go"}, Target: "go"})
+	assert.Error(t, err)
+
+	tagRepo.On("FindTags", mock.Anything, []string{"rust"}).Return(nil, errors.New("db down"))
+	_, err = uc.MergeTags(context.Background(), &entities.TagMerge{Sources: []string{"golang"}, Target: "rust"})
+	assert.EqualError(t, err, "db down")
+}
+
+func TestNormalizeStoredTags_RewritesLegacyTags(t *testing.T) {
+	t.Parallel()
+	tagRepo := repoMocks.NewTagRepositoryInterface(t)
+	blogRepo := repoMocks.NewBlogRepositoryInterface(t)
+	index := repoMocks.NewRelatedBlogIndex(t)
+	uc := NewTagUseCase(tagRepo, blogRepo, index)
+
+	blogRepo.On("GetDistinctTags", mock.Anything).Return([]string{"Go", "Golang", "go", "api", "API", "!!"}, nil)
+	tagRepo.On("FindTags", mock.Anything, mock.MatchedBy(func(slugs []string) bool { return len(slugs) == 3 })).
+		Return([]*entities.Tag{{Slug: "go", Synonyms: []string{"golang"}}}, nil)
+	blogRepo.On("ReplaceTags", mock.Anything, []string{"API"}, "api").Return(int64(2), nil)
+	blogRepo.On("ReplaceTags", mock.Anything, []string{"Go", "Golang"}, "go").Return(int64(5), nil)
+	index.On("Invalidate").Return().Twice()
+
+	updated, err := uc.NormalizeStoredTags(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), updated)
+}
@@ -229,6 +229,24 @@ func (u *userUsecase) DemoteUser(ctx context.Context, userID string) error {
 	return u.userRepo.Update(ctx, user)
 }
 
+// make user a reviewer (reviewers approve blogs of regular users)
+func (u *userUsecase) MakeReviewer(ctx context.Context, userID string) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, objID)
+	if err != nil || user == nil {
+		return errors.New("user not found")
+	}
+
+	user.Role = entities.RoleReviewer
+	user.UpdatedAt = time.Now()
+
+	return u.userRepo.Update(ctx, user)
+}
+
 // logout user
 func (u *userUsecase) Logout(ctx context.Context, userID string) error {
 	return u.userRepo.DeleteToken(ctx, userID)
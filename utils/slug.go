@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxSlugLength keeps generated slugs readable in URLs
+const maxSlugLength = 80
+
+// Slugify turns a title into a lowercase, dash separated URL segment
+// Letters and digits of any script are kept, everything else becomes a single dash
+func Slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if runes := []rune(slug); len(runes) > maxSlugLength {
+		slug = strings.Trim(string(runes[:maxSlugLength]), "-")
+	}
+	if slug == "" {
+		slug = "post"
+	}
+	return slug
+}